@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.32.0
 // 	protoc        v3.20.1
 // source: lottery/v1/lottery.proto
 
@@ -26,8 +26,9 @@ type CommonRspInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Code   int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg    string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	UserId uint32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
 func (x *CommonRspInfo) Reset() {
@@ -76,14 +77,25 @@ func (x *CommonRspInfo) GetMsg() string {
 	return ""
 }
 
+func (x *CommonRspInfo) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type LotteryReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId   uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserName string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
-	Ip       string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	UserId     uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName   string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Ip         string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	ActivityId uint32 `protobuf:"varint,4,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"` // 活动ID，0表示默认活动
+	Token      string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`                              // 用户登录token
+	DeviceId   string `protobuf:"bytes,6,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`        // 设备ID
+	ClaimedIp  string `protobuf:"bytes,7,opt,name=claimed_ip,json=claimedIp,proto3" json:"claimed_ip,omitempty"`     // 请求中声明的客户端IP，ip以服务端根据连接地址和可信代理确定的为准
 }
 
 func (x *LotteryReq) Reset() {
@@ -139,6 +151,34 @@ func (x *LotteryReq) GetIp() string {
 	return ""
 }
 
+func (x *LotteryReq) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *LotteryReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LotteryReq) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LotteryReq) GetClaimedIp() string {
+	if x != nil {
+		return x.ClaimedIp
+	}
+	return ""
+}
+
 type LotteryPrizeInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -321,142 +361,2316 @@ func (x *LotteryRsp) GetPrizeInfo() *LotteryPrizeInfo {
 	return nil
 }
 
-var File_lottery_v1_lottery_proto protoreflect.FileDescriptor
+type GetMyWinsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_lottery_v1_lottery_proto_rawDesc = []byte{
-	0x0a, 0x18, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x74,
-	0x74, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x61, 0x70, 0x69, 0x2e,
-	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22,
-	0x52, 0x0a, 0x0a, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a,
-	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
-	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x70, 0x22, 0xda, 0x02, 0x0a, 0x10, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x50,
-	0x72, 0x69, 0x7a, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x6c,
-	0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c,
-	0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f,
-	0x63, 0x6f, 0x64, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
-	0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x12, 0x26, 0x0a, 0x0f,
-	0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65,
-	0x48, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6d, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x69, 0x6d, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
-	0x79, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64,
-	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
-	0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72,
-	0x69, 0x7a, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
-	0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65,
-	0x22, 0x8b, 0x01, 0x0a, 0x0a, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x73, 0x70, 0x12,
-	0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x12, 0x3f, 0x0a,
-	0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x32, 0x63,
-	0x0a, 0x07, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x58, 0x0a, 0x09, 0x4c, 0x6f, 0x74,
-	0x74, 0x65, 0x72, 0x79, 0x56, 0x31, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74,
-	0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x73, 0x70, 0x22, 0x13,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
-	0x3a, 0x01, 0x2a, 0x42, 0x50, 0x0a, 0x0e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65,
-	0x72, 0x79, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x74, 0x73, 0x74, 0x6f, 0x72, 0x6d, 0x68, 0x75, 0x62, 0x2f,
-	0x62, 0x69, 0x74, 0x73, 0x74, 0x6f, 0x72, 0x6d, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
-	0x73, 0x76, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f,
-	0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	UserId     uint32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ActivityId *uint32 `protobuf:"varint,2,opt,name=activity_id,json=activityId,proto3,oneof" json:"activity_id,omitempty"` // 不传时查询所有活动
+	Page       int32   `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32   `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 }
 
-var (
-	file_lottery_v1_lottery_proto_rawDescOnce sync.Once
-	file_lottery_v1_lottery_proto_rawDescData = file_lottery_v1_lottery_proto_rawDesc
-)
+func (x *GetMyWinsReq) Reset() {
+	*x = GetMyWinsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_lottery_v1_lottery_proto_rawDescGZIP() []byte {
-	file_lottery_v1_lottery_proto_rawDescOnce.Do(func() {
-		file_lottery_v1_lottery_proto_rawDescData = protoimpl.X.CompressGZIP(file_lottery_v1_lottery_proto_rawDescData)
-	})
-	return file_lottery_v1_lottery_proto_rawDescData
+func (x *GetMyWinsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_lottery_v1_lottery_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_lottery_v1_lottery_proto_goTypes = []interface{}{
-	(*CommonRspInfo)(nil),    // 0: api.lottery.v1.CommonRspInfo
-	(*LotteryReq)(nil),       // 1: api.lottery.v1.LotteryReq
-	(*LotteryPrizeInfo)(nil), // 2: api.lottery.v1.LotteryPrizeInfo
-	(*LotteryRsp)(nil),       // 3: api.lottery.v1.LotteryRsp
+func (*GetMyWinsReq) ProtoMessage() {}
+
+func (x *GetMyWinsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_lottery_v1_lottery_proto_depIdxs = []int32{
-	0, // 0: api.lottery.v1.LotteryRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
-	2, // 1: api.lottery.v1.LotteryRsp.prize_info:type_name -> api.lottery.v1.LotteryPrizeInfo
-	1, // 2: api.lottery.v1.Lottery.LotteryV1:input_type -> api.lottery.v1.LotteryReq
-	3, // 3: api.lottery.v1.Lottery.LotteryV1:output_type -> api.lottery.v1.LotteryRsp
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+// Deprecated: Use GetMyWinsReq.ProtoReflect.Descriptor instead.
+func (*GetMyWinsReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{4}
 }
 
-func init() { file_lottery_v1_lottery_proto_init() }
-func file_lottery_v1_lottery_proto_init() {
-	if File_lottery_v1_lottery_proto != nil {
-		return
+func (x *GetMyWinsReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_lottery_v1_lottery_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommonRspInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_lottery_v1_lottery_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LotteryReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *GetMyWinsReq) GetActivityId() uint32 {
+	if x != nil && x.ActivityId != nil {
+		return *x.ActivityId
+	}
+	return 0
+}
+
+func (x *GetMyWinsReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetMyWinsReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// WinInfo 用户的中奖纪录，时间格式为 2006-01-02 15:04:05
+type WinInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActivityId    uint32 `protobuf:"varint,2,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	PrizeId       uint32 `protobuf:"varint,3,opt,name=prize_id,json=prizeId,proto3" json:"prize_id,omitempty"`
+	PrizeName     string `protobuf:"bytes,4,opt,name=prize_name,json=prizeName,proto3" json:"prize_name,omitempty"`
+	PrizeType     uint32 `protobuf:"varint,5,opt,name=prize_type,json=prizeType,proto3" json:"prize_type,omitempty"`
+	PrizeData     string `protobuf:"bytes,6,opt,name=prize_data,json=prizeData,proto3" json:"prize_data,omitempty"`
+	PrizeCode     uint32 `protobuf:"varint,7,opt,name=prize_code,json=prizeCode,proto3" json:"prize_code,omitempty"`
+	FulfillStatus uint32 `protobuf:"varint,8,opt,name=fulfill_status,json=fulfillStatus,proto3" json:"fulfill_status,omitempty"`
+	ClaimDeadline string `protobuf:"bytes,9,opt,name=claim_deadline,json=claimDeadline,proto3" json:"claim_deadline,omitempty"` // 实物奖品的领取截止时间，其他奖品为空
+	WinTime       string `protobuf:"bytes,10,opt,name=win_time,json=winTime,proto3" json:"win_time,omitempty"`
+}
+
+func (x *WinInfo) Reset() {
+	*x = WinInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WinInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WinInfo) ProtoMessage() {}
+
+func (x *WinInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lottery_v1_lottery_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LotteryPrizeInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WinInfo.ProtoReflect.Descriptor instead.
+func (*WinInfo) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WinInfo) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WinInfo) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *WinInfo) GetPrizeId() uint32 {
+	if x != nil {
+		return x.PrizeId
+	}
+	return 0
+}
+
+func (x *WinInfo) GetPrizeName() string {
+	if x != nil {
+		return x.PrizeName
+	}
+	return ""
+}
+
+func (x *WinInfo) GetPrizeType() uint32 {
+	if x != nil {
+		return x.PrizeType
+	}
+	return 0
+}
+
+func (x *WinInfo) GetPrizeData() string {
+	if x != nil {
+		return x.PrizeData
+	}
+	return ""
+}
+
+func (x *WinInfo) GetPrizeCode() uint32 {
+	if x != nil {
+		return x.PrizeCode
+	}
+	return 0
+}
+
+func (x *WinInfo) GetFulfillStatus() uint32 {
+	if x != nil {
+		return x.FulfillStatus
+	}
+	return 0
+}
+
+func (x *WinInfo) GetClaimDeadline() string {
+	if x != nil {
+		return x.ClaimDeadline
+	}
+	return ""
+}
+
+func (x *WinInfo) GetWinTime() string {
+	if x != nil {
+		return x.WinTime
+	}
+	return ""
+}
+
+type GetMyWinsRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp *CommonRspInfo `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+	Total     int64          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	WinList   []*WinInfo     `protobuf:"bytes,3,rep,name=win_list,json=winList,proto3" json:"win_list,omitempty"`
+}
+
+func (x *GetMyWinsRsp) Reset() {
+	*x = GetMyWinsRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMyWinsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyWinsRsp) ProtoMessage() {}
+
+func (x *GetMyWinsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lottery_v1_lottery_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LotteryRsp); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyWinsRsp.ProtoReflect.Descriptor instead.
+func (*GetMyWinsRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMyWinsRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+func (x *GetMyWinsRsp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetMyWinsRsp) GetWinList() []*WinInfo {
+	if x != nil {
+		return x.WinList
+	}
+	return nil
+}
+
+type GetMyLotteryTimesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ActivityId uint32 `protobuf:"varint,2,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+}
+
+func (x *GetMyLotteryTimesReq) Reset() {
+	*x = GetMyLotteryTimesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMyLotteryTimesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyLotteryTimesReq) ProtoMessage() {}
+
+func (x *GetMyLotteryTimesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyLotteryTimesReq.ProtoReflect.Descriptor instead.
+func (*GetMyLotteryTimesReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMyLotteryTimesReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetMyLotteryTimesReq) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+type GetMyLotteryTimesRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp *CommonRspInfo `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+	DayMax    uint32         `protobuf:"varint,2,opt,name=day_max,json=dayMax,proto3" json:"day_max,omitempty"`
+	UsedNum   uint32         `protobuf:"varint,3,opt,name=used_num,json=usedNum,proto3" json:"used_num,omitempty"`
+	RemainNum uint32         `protobuf:"varint,4,opt,name=remain_num,json=remainNum,proto3" json:"remain_num,omitempty"`
+}
+
+func (x *GetMyLotteryTimesRsp) Reset() {
+	*x = GetMyLotteryTimesRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMyLotteryTimesRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyLotteryTimesRsp) ProtoMessage() {}
+
+func (x *GetMyLotteryTimesRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyLotteryTimesRsp.ProtoReflect.Descriptor instead.
+func (*GetMyLotteryTimesRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMyLotteryTimesRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+func (x *GetMyLotteryTimesRsp) GetDayMax() uint32 {
+	if x != nil {
+		return x.DayMax
+	}
+	return 0
+}
+
+func (x *GetMyLotteryTimesRsp) GetUsedNum() uint32 {
+	if x != nil {
+		return x.UsedNum
+	}
+	return 0
+}
+
+func (x *GetMyLotteryTimesRsp) GetRemainNum() uint32 {
+	if x != nil {
+		return x.RemainNum
+	}
+	return 0
+}
+
+type GetRecentWinnersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActivityId uint32 `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+}
+
+func (x *GetRecentWinnersReq) Reset() {
+	*x = GetRecentWinnersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecentWinnersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentWinnersReq) ProtoMessage() {}
+
+func (x *GetRecentWinnersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentWinnersReq.ProtoReflect.Descriptor instead.
+func (*GetRecentWinnersReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetRecentWinnersReq) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+type RecentWinner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserName  string `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"` // 打码后的用户名
+	PrizeName string `protobuf:"bytes,2,opt,name=prize_name,json=prizeName,proto3" json:"prize_name,omitempty"`
+	PrizeType uint32 `protobuf:"varint,3,opt,name=prize_type,json=prizeType,proto3" json:"prize_type,omitempty"`
+	WinTime   string `protobuf:"bytes,4,opt,name=win_time,json=winTime,proto3" json:"win_time,omitempty"`
+}
+
+func (x *RecentWinner) Reset() {
+	*x = RecentWinner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecentWinner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentWinner) ProtoMessage() {}
+
+func (x *RecentWinner) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentWinner.ProtoReflect.Descriptor instead.
+func (*RecentWinner) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecentWinner) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *RecentWinner) GetPrizeName() string {
+	if x != nil {
+		return x.PrizeName
+	}
+	return ""
+}
+
+func (x *RecentWinner) GetPrizeType() uint32 {
+	if x != nil {
+		return x.PrizeType
+	}
+	return 0
+}
+
+func (x *RecentWinner) GetWinTime() string {
+	if x != nil {
+		return x.WinTime
+	}
+	return ""
+}
+
+type GetRecentWinnersRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp  *CommonRspInfo  `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+	WinnerList []*RecentWinner `protobuf:"bytes,2,rep,name=winner_list,json=winnerList,proto3" json:"winner_list,omitempty"`
+}
+
+func (x *GetRecentWinnersRsp) Reset() {
+	*x = GetRecentWinnersRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecentWinnersRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentWinnersRsp) ProtoMessage() {}
+
+func (x *GetRecentWinnersRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentWinnersRsp.ProtoReflect.Descriptor instead.
+func (*GetRecentWinnersRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetRecentWinnersRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+func (x *GetRecentWinnersRsp) GetWinnerList() []*RecentWinner {
+	if x != nil {
+		return x.WinnerList
+	}
+	return nil
+}
+
+type GetShowcaseReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActivityId uint32 `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+}
+
+func (x *GetShowcaseReq) Reset() {
+	*x = GetShowcaseReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetShowcaseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowcaseReq) ProtoMessage() {}
+
+func (x *GetShowcaseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowcaseReq.ProtoReflect.Descriptor instead.
+func (*GetShowcaseReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetShowcaseReq) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+// ShowcasePrize 奖品展示信息，剩余数量为近似值，时间格式为 2006-01-02 15:04:05
+type ShowcasePrize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Img          string `protobuf:"bytes,3,opt,name=img,proto3" json:"img,omitempty"`
+	PrizeType    uint32 `protobuf:"varint,4,opt,name=prize_type,json=prizeType,proto3" json:"prize_type,omitempty"`
+	DisplayOrder uint32 `protobuf:"varint,5,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
+	PrizeNum     int32  `protobuf:"varint,6,opt,name=prize_num,json=prizeNum,proto3" json:"prize_num,omitempty"`
+	LeftNum      int32  `protobuf:"varint,7,opt,name=left_num,json=leftNum,proto3" json:"left_num,omitempty"` // 剩余数量
+	PoolNum      int32  `protobuf:"varint,8,opt,name=pool_num,json=poolNum,proto3" json:"pool_num,omitempty"` // 奖品池中已经发放可抽的数量
+	BeginTime    string `protobuf:"bytes,9,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime      string `protobuf:"bytes,10,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *ShowcasePrize) Reset() {
+	*x = ShowcasePrize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShowcasePrize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowcasePrize) ProtoMessage() {}
+
+func (x *ShowcasePrize) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowcasePrize.ProtoReflect.Descriptor instead.
+func (*ShowcasePrize) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShowcasePrize) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ShowcasePrize) GetImg() string {
+	if x != nil {
+		return x.Img
+	}
+	return ""
+}
+
+func (x *ShowcasePrize) GetPrizeType() uint32 {
+	if x != nil {
+		return x.PrizeType
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetDisplayOrder() uint32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetPrizeNum() int32 {
+	if x != nil {
+		return x.PrizeNum
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetLeftNum() int32 {
+	if x != nil {
+		return x.LeftNum
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetPoolNum() int32 {
+	if x != nil {
+		return x.PoolNum
+	}
+	return 0
+}
+
+func (x *ShowcasePrize) GetBeginTime() string {
+	if x != nil {
+		return x.BeginTime
+	}
+	return ""
+}
+
+func (x *ShowcasePrize) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+type GetShowcaseRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp *CommonRspInfo   `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+	PrizeList []*ShowcasePrize `protobuf:"bytes,2,rep,name=prize_list,json=prizeList,proto3" json:"prize_list,omitempty"`
+}
+
+func (x *GetShowcaseRsp) Reset() {
+	*x = GetShowcaseRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetShowcaseRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShowcaseRsp) ProtoMessage() {}
+
+func (x *GetShowcaseRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShowcaseRsp.ProtoReflect.Descriptor instead.
+func (*GetShowcaseRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetShowcaseRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+func (x *GetShowcaseRsp) GetPrizeList() []*ShowcasePrize {
+	if x != nil {
+		return x.PrizeList
+	}
+	return nil
+}
+
+// ViewPrize 管理后台奖品信息，时间格式为 2006-01-02 15:04:05
+type ViewPrize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActivityId   uint32 `protobuf:"varint,2,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	Title        string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Img          string `protobuf:"bytes,4,opt,name=img,proto3" json:"img,omitempty"`
+	PrizeNum     int32  `protobuf:"varint,5,opt,name=prize_num,json=prizeNum,proto3" json:"prize_num,omitempty"`
+	PrizeCode    string `protobuf:"bytes,6,opt,name=prize_code,json=prizeCode,proto3" json:"prize_code,omitempty"`
+	PrizeTime    uint32 `protobuf:"varint,7,opt,name=prize_time,json=prizeTime,proto3" json:"prize_time,omitempty"`
+	LeftNum      int32  `protobuf:"varint,8,opt,name=left_num,json=leftNum,proto3" json:"left_num,omitempty"`
+	PrizeType    uint32 `protobuf:"varint,9,opt,name=prize_type,json=prizeType,proto3" json:"prize_type,omitempty"`
+	BeginTime    string `protobuf:"bytes,11,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime      string `protobuf:"bytes,12,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	DisplayOrder uint32 `protobuf:"varint,13,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
+	SysStatus    uint32 `protobuf:"varint,14,opt,name=sys_status,json=sysStatus,proto3" json:"sys_status,omitempty"`
+	Weight       uint32 `protobuf:"varint,15,opt,name=weight,proto3" json:"weight,omitempty"`
+	UserWinMax   uint32 `protobuf:"varint,16,opt,name=user_win_max,json=userWinMax,proto3" json:"user_win_max,omitempty"`
+	PlanProfile  string `protobuf:"bytes,17,opt,name=plan_profile,json=planProfile,proto3" json:"plan_profile,omitempty"` // 发奖计划的分布曲线名称，空表示default
+}
+
+func (x *ViewPrize) Reset() {
+	*x = ViewPrize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewPrize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewPrize) ProtoMessage() {}
+
+func (x *ViewPrize) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewPrize.ProtoReflect.Descriptor instead.
+func (*ViewPrize) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ViewPrize) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetActivityId() uint32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ViewPrize) GetImg() string {
+	if x != nil {
+		return x.Img
+	}
+	return ""
+}
+
+func (x *ViewPrize) GetPrizeNum() int32 {
+	if x != nil {
+		return x.PrizeNum
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetPrizeCode() string {
+	if x != nil {
+		return x.PrizeCode
+	}
+	return ""
+}
+
+func (x *ViewPrize) GetPrizeTime() uint32 {
+	if x != nil {
+		return x.PrizeTime
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetLeftNum() int32 {
+	if x != nil {
+		return x.LeftNum
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetPrizeType() uint32 {
+	if x != nil {
+		return x.PrizeType
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetBeginTime() string {
+	if x != nil {
+		return x.BeginTime
+	}
+	return ""
+}
+
+func (x *ViewPrize) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *ViewPrize) GetDisplayOrder() uint32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetSysStatus() uint32 {
+	if x != nil {
+		return x.SysStatus
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetUserWinMax() uint32 {
+	if x != nil {
+		return x.UserWinMax
+	}
+	return 0
+}
+
+func (x *ViewPrize) GetPlanProfile() string {
+	if x != nil {
+		return x.PlanProfile
+	}
+	return ""
+}
+
+// ActivityInfo 抽奖活动信息，时间格式为 2006-01-02 15:04:05
+type ActivityInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title          string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	BeginTime      string `protobuf:"bytes,3,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime        string `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	UserDayMax     uint32 `protobuf:"varint,5,opt,name=user_day_max,json=userDayMax,proto3" json:"user_day_max,omitempty"`
+	IpDayMax       uint32 `protobuf:"varint,6,opt,name=ip_day_max,json=ipDayMax,proto3" json:"ip_day_max,omitempty"`
+	PrizeCodeMax   uint32 `protobuf:"varint,7,opt,name=prize_code_max,json=prizeCodeMax,proto3" json:"prize_code_max,omitempty"`
+	SysStatus      uint32 `protobuf:"varint,8,opt,name=sys_status,json=sysStatus,proto3" json:"sys_status,omitempty"`
+	SelectStrategy uint32 `protobuf:"varint,9,opt,name=select_strategy,json=selectStrategy,proto3" json:"select_strategy,omitempty"`
+	NoPrizeWeight  uint32 `protobuf:"varint,10,opt,name=no_prize_weight,json=noPrizeWeight,proto3" json:"no_prize_weight,omitempty"`
+	PityThreshold  uint32 `protobuf:"varint,11,opt,name=pity_threshold,json=pityThreshold,proto3" json:"pity_threshold,omitempty"`
+	PityPrizeType  uint32 `protobuf:"varint,12,opt,name=pity_prize_type,json=pityPrizeType,proto3" json:"pity_prize_type,omitempty"`
+	UserWinMax     uint32 `protobuf:"varint,13,opt,name=user_win_max,json=userWinMax,proto3" json:"user_win_max,omitempty"`
+	// 每个用户每天每种奖品类型最多中奖次数，格式 类型:次数,类型:次数
+	TypeDayWinMax string `protobuf:"bytes,14,opt,name=type_day_win_max,json=typeDayWinMax,proto3" json:"type_day_win_max,omitempty"`
+}
+
+func (x *ActivityInfo) Reset() {
+	*x = ActivityInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivityInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityInfo) ProtoMessage() {}
+
+func (x *ActivityInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityInfo.ProtoReflect.Descriptor instead.
+func (*ActivityInfo) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ActivityInfo) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ActivityInfo) GetBeginTime() string {
+	if x != nil {
+		return x.BeginTime
+	}
+	return ""
+}
+
+func (x *ActivityInfo) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *ActivityInfo) GetUserDayMax() uint32 {
+	if x != nil {
+		return x.UserDayMax
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetIpDayMax() uint32 {
+	if x != nil {
+		return x.IpDayMax
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetPrizeCodeMax() uint32 {
+	if x != nil {
+		return x.PrizeCodeMax
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetSysStatus() uint32 {
+	if x != nil {
+		return x.SysStatus
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetSelectStrategy() uint32 {
+	if x != nil {
+		return x.SelectStrategy
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetNoPrizeWeight() uint32 {
+	if x != nil {
+		return x.NoPrizeWeight
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetPityThreshold() uint32 {
+	if x != nil {
+		return x.PityThreshold
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetPityPrizeType() uint32 {
+	if x != nil {
+		return x.PityPrizeType
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetUserWinMax() uint32 {
+	if x != nil {
+		return x.UserWinMax
+	}
+	return 0
+}
+
+func (x *ActivityInfo) GetTypeDayWinMax() string {
+	if x != nil {
+		return x.TypeDayWinMax
+	}
+	return ""
+}
+
+type AdminRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp *CommonRspInfo `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+}
+
+func (x *AdminRsp) Reset() {
+	*x = AdminRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdminRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRsp) ProtoMessage() {}
+
+func (x *AdminRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRsp.ProtoReflect.Descriptor instead.
+func (*AdminRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AdminRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+type AddPrizeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32     `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Prize  *ViewPrize `protobuf:"bytes,2,opt,name=prize,proto3" json:"prize,omitempty"`
+}
+
+func (x *AddPrizeReq) Reset() {
+	*x = AddPrizeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPrizeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrizeReq) ProtoMessage() {}
+
+func (x *AddPrizeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrizeReq.ProtoReflect.Descriptor instead.
+func (*AddPrizeReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddPrizeReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddPrizeReq) GetPrize() *ViewPrize {
+	if x != nil {
+		return x.Prize
+	}
+	return nil
+}
+
+type AddPrizeListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    uint32       `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PrizeList []*ViewPrize `protobuf:"bytes,2,rep,name=prize_list,json=prizeList,proto3" json:"prize_list,omitempty"`
+}
+
+func (x *AddPrizeListReq) Reset() {
+	*x = AddPrizeListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPrizeListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrizeListReq) ProtoMessage() {}
+
+func (x *AddPrizeListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrizeListReq.ProtoReflect.Descriptor instead.
+func (*AddPrizeListReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddPrizeListReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddPrizeListReq) GetPrizeList() []*ViewPrize {
+	if x != nil {
+		return x.PrizeList
+	}
+	return nil
+}
+
+type AdminClearReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *AdminClearReq) Reset() {
+	*x = AdminClearReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdminClearReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminClearReq) ProtoMessage() {}
+
+func (x *AdminClearReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminClearReq.ProtoReflect.Descriptor instead.
+func (*AdminClearReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AdminClearReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ImportCouponReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PrizeId uint32 `protobuf:"varint,2,opt,name=prize_id,json=prizeId,proto3" json:"prize_id,omitempty"`
+	Codes   string `protobuf:"bytes,3,opt,name=codes,proto3" json:"codes,omitempty"` // 多个优惠券编码用换行分隔
+}
+
+func (x *ImportCouponReq) Reset() {
+	*x = ImportCouponReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportCouponReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCouponReq) ProtoMessage() {}
+
+func (x *ImportCouponReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCouponReq.ProtoReflect.Descriptor instead.
+func (*ImportCouponReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportCouponReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportCouponReq) GetPrizeId() uint32 {
+	if x != nil {
+		return x.PrizeId
+	}
+	return 0
+}
+
+func (x *ImportCouponReq) GetCodes() string {
+	if x != nil {
+		return x.Codes
+	}
+	return ""
+}
+
+type GetActivityListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *GetActivityListReq) Reset() {
+	*x = GetActivityListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetActivityListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityListReq) ProtoMessage() {}
+
+func (x *GetActivityListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityListReq.ProtoReflect.Descriptor instead.
+func (*GetActivityListReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetActivityListReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetActivityListRsp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommonRsp    *CommonRspInfo  `protobuf:"bytes,1,opt,name=common_rsp,json=commonRsp,proto3" json:"common_rsp,omitempty"`
+	ActivityList []*ActivityInfo `protobuf:"bytes,2,rep,name=activity_list,json=activityList,proto3" json:"activity_list,omitempty"`
+}
+
+func (x *GetActivityListRsp) Reset() {
+	*x = GetActivityListRsp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetActivityListRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityListRsp) ProtoMessage() {}
+
+func (x *GetActivityListRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityListRsp.ProtoReflect.Descriptor instead.
+func (*GetActivityListRsp) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetActivityListRsp) GetCommonRsp() *CommonRspInfo {
+	if x != nil {
+		return x.CommonRsp
+	}
+	return nil
+}
+
+func (x *GetActivityListRsp) GetActivityList() []*ActivityInfo {
+	if x != nil {
+		return x.ActivityList
+	}
+	return nil
+}
+
+type ActivityReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   uint32        `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Activity *ActivityInfo `protobuf:"bytes,2,opt,name=activity,proto3" json:"activity,omitempty"`
+}
+
+func (x *ActivityReq) Reset() {
+	*x = ActivityReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lottery_v1_lottery_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivityReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityReq) ProtoMessage() {}
+
+func (x *ActivityReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lottery_v1_lottery_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityReq.ProtoReflect.Descriptor instead.
+func (*ActivityReq) Descriptor() ([]byte, []int) {
+	return file_lottery_v1_lottery_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ActivityReq) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ActivityReq) GetActivity() *ActivityInfo {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
+var File_lottery_v1_lottery_proto protoreflect.FileDescriptor
+
+var file_lottery_v1_lottery_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x61, 0x70, 0x69, 0x2e,
+	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4e, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x73, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc5, 0x01, 0x0a, 0x0a, 0x4c, 0x6f, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x69, 0x70, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x49, 0x70,
+	0x22, 0xda, 0x02, 0x0a, 0x10, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x50, 0x72, 0x69, 0x7a,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x66, 0x74,
+	0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x65, 0x66, 0x74,
+	0x4e, 0x75, 0x6d, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x72, 0x69,
+	0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x72, 0x69,
+	0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x48, 0x69, 0x67,
+	0x68, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6d, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x69, 0x6d, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72,
+	0x69, 0x7a, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x69, 0x7a, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x8b, 0x01,
+	0x0a, 0x0a, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x12, 0x3f, 0x0a, 0x0a, 0x70, 0x72,
+	0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x8e, 0x01, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x4d, 0x79, 0x57, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x0e, 0x0a, 0x0c,
+	0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x22, 0xba, 0x02, 0x0a,
+	0x07, 0x57, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x72, 0x69,
+	0x7a, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x66, 0x75, 0x6c, 0x66, 0x69, 0x6c, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x66, 0x75, 0x6c, 0x66, 0x69, 0x6c,
+	0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x69, 0x6d,
+	0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x77, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x4d, 0x79, 0x57, 0x69, 0x6e, 0x73, 0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x32,
+	0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x22, 0x50, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x4c, 0x6f, 0x74, 0x74, 0x65,
+	0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69,
+	0x74, 0x79, 0x49, 0x64, 0x22, 0xa7, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x4c, 0x6f,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x61, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x64, 0x61,
+	0x79, 0x4d, 0x61, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x6e, 0x75, 0x6d,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x75, 0x73, 0x65, 0x64, 0x4e, 0x75, 0x6d, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x75, 0x6d, 0x22, 0x36,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x69, 0x74, 0x79, 0x49, 0x64, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x92, 0x01,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x73, 0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f,
+	0x72, 0x73, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x52, 0x73, 0x70, 0x12, 0x3d, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c,
+	0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74,
+	0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x4c, 0x69,
+	0x73, 0x74, 0x22, 0x31, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x49, 0x64, 0x22, 0x98, 0x02, 0x0a, 0x0d, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61,
+	0x73, 0x65, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x69, 0x6d, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x6d, 0x67, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6e, 0x75, 0x6d,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x4e, 0x75, 0x6d,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x70,
+	0x6f, 0x6f, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70,
+	0x6f, 0x6f, 0x6c, 0x4e, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x65, 0x67, 0x69,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0x8c, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65,
+	0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52,
+	0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73,
+	0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65, 0x50,
+	0x72, 0x69, 0x7a, 0x65, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22,
+	0xda, 0x03, 0x0a, 0x09, 0x56, 0x69, 0x65, 0x77, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6d, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x69, 0x6d, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f,
+	0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x7a, 0x65,
+	0x4e, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x65, 0x67, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x79,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x73, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x6d, 0x61,
+	0x78, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x57, 0x69, 0x6e,
+	0x4d, 0x61, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0b, 0x22, 0xde, 0x03, 0x0a,
+	0x0c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x79, 0x4d, 0x61, 0x78, 0x12,
+	0x1c, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x64, 0x61, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x70, 0x44, 0x61, 0x79, 0x4d, 0x61, 0x78, 0x12, 0x24, 0x0a,
+	0x0e, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x4d, 0x61, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x79, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x79, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x6f, 0x5f, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6e, 0x6f, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x69, 0x74,
+	0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x69,
+	0x74, 0x79, 0x5f, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x69, 0x74, 0x79, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x6d,
+	0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x57, 0x69,
+	0x6e, 0x4d, 0x61, 0x78, 0x12, 0x27, 0x0a, 0x10, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x64, 0x61, 0x79,
+	0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x74, 0x79, 0x70, 0x65, 0x44, 0x61, 0x79, 0x57, 0x69, 0x6e, 0x4d, 0x61, 0x78, 0x22, 0x48, 0x0a,
+	0x08, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x57, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x50, 0x72,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x2f, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x69, 0x65, 0x77, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x05, 0x70, 0x72, 0x69, 0x7a, 0x65,
+	0x22, 0x64, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0a,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x09, 0x70, 0x72, 0x69,
+	0x7a, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x28, 0x0a, 0x0d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0x5b, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x70, 0x72, 0x69, 0x7a, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x2d, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x95, 0x01, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x73, 0x70, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x72, 0x73,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52,
+	0x73, 0x70, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x52, 0x73,
+	0x70, 0x12, 0x41, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c,
+	0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69,
+	0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79,
+	0x4c, 0x69, 0x73, 0x74, 0x22, 0x60, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x08,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x32, 0xee, 0x05, 0x0a, 0x07, 0x4c, 0x6f, 0x74, 0x74, 0x65,
+	0x72, 0x79, 0x12, 0x58, 0x0a, 0x09, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x56, 0x31, 0x12,
+	0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x52, 0x73, 0x70, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x3a,
+	0x01, 0x2a, 0x22, 0x08, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x5b, 0x0a, 0x09,
+	0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x56, 0x32, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x73,
+	0x70, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x22, 0x0b, 0x2f, 0x6c,
+	0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x76, 0x32, 0x12, 0x5b, 0x0a, 0x09, 0x4c, 0x6f, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x56, 0x33, 0x12, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x73, 0x70, 0x22, 0x16,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x22, 0x0b, 0x2f, 0x6c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x2f, 0x76, 0x33, 0x12, 0x61, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x57,
+	0x69, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x57, 0x69, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x57, 0x69, 0x6e, 0x73, 0x52, 0x73, 0x70, 0x22,
+	0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x2f, 0x6d, 0x79, 0x5f, 0x77, 0x69, 0x6e, 0x73, 0x12, 0x82, 0x01, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x4d, 0x79, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12,
+	0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x79, 0x4c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x73, 0x70, 0x22, 0x21, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x6d, 0x79,
+	0x5f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x7d,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x73, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x57, 0x69, 0x6e,
+	0x6e, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x73, 0x70, 0x22, 0x1f, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x72,
+	0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x68, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65, 0x52, 0x73, 0x70, 0x22, 0x19, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x73,
+	0x68, 0x6f, 0x77, 0x63, 0x61, 0x73, 0x65, 0x32, 0xbc, 0x09, 0x0a, 0x0c, 0x4c, 0x6f, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x5e, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x50,
+	0x72, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x15, 0x3a, 0x01, 0x2a, 0x22, 0x10, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x61,
+	0x64, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x6b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x50,
+	0x72, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c,
+	0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x69,
+	0x7a, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x52, 0x73, 0x70, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x3a, 0x01, 0x2a, 0x22, 0x15,
+	0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x61, 0x64, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x7a, 0x65,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x50, 0x72,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52,
+	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x17, 0x3a, 0x01, 0x2a, 0x22, 0x12, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f,
+	0x63, 0x6c, 0x65, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x6a, 0x0a, 0x0c, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01,
+	0x2a, 0x22, 0x14, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x12, 0x79, 0x0a, 0x15, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x25, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x69,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x12, 0x66, 0x0a, 0x0b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x75, 0x70, 0x6f,
+	0x6e, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71,
+	0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x12, 0x73, 0x0a, 0x11, 0x43, 0x6c,
+	0x65, 0x61, 0x72, 0x4c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12,
+	0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x18,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f,
+	0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x5f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x12,
+	0x66, 0x0a, 0x0b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x3a,
+	0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x63, 0x6c, 0x65, 0x61, 0x72,
+	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x7b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x22,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x73, 0x70, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x12, 0x18, 0x2f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2f, 0x67, 0x65, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x73, 0x70, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x61, 0x64,
+	0x64, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x6a, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e,
+	0x52, 0x73, 0x70, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x22, 0x16,
+	0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x42, 0x47, 0x0a, 0x0e, 0x61, 0x70, 0x69, 0x2e, 0x6c, 0x6f,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x42, 0x69, 0x74, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x48,
+	0x75, 0x62, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x73, 0x76, 0x72, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6c, 0x6f, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_lottery_v1_lottery_proto_rawDescOnce sync.Once
+	file_lottery_v1_lottery_proto_rawDescData = file_lottery_v1_lottery_proto_rawDesc
+)
+
+func file_lottery_v1_lottery_proto_rawDescGZIP() []byte {
+	file_lottery_v1_lottery_proto_rawDescOnce.Do(func() {
+		file_lottery_v1_lottery_proto_rawDescData = protoimpl.X.CompressGZIP(file_lottery_v1_lottery_proto_rawDescData)
+	})
+	return file_lottery_v1_lottery_proto_rawDescData
+}
+
+var file_lottery_v1_lottery_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_lottery_v1_lottery_proto_goTypes = []interface{}{
+	(*CommonRspInfo)(nil),        // 0: api.lottery.v1.CommonRspInfo
+	(*LotteryReq)(nil),           // 1: api.lottery.v1.LotteryReq
+	(*LotteryPrizeInfo)(nil),     // 2: api.lottery.v1.LotteryPrizeInfo
+	(*LotteryRsp)(nil),           // 3: api.lottery.v1.LotteryRsp
+	(*GetMyWinsReq)(nil),         // 4: api.lottery.v1.GetMyWinsReq
+	(*WinInfo)(nil),              // 5: api.lottery.v1.WinInfo
+	(*GetMyWinsRsp)(nil),         // 6: api.lottery.v1.GetMyWinsRsp
+	(*GetMyLotteryTimesReq)(nil), // 7: api.lottery.v1.GetMyLotteryTimesReq
+	(*GetMyLotteryTimesRsp)(nil), // 8: api.lottery.v1.GetMyLotteryTimesRsp
+	(*GetRecentWinnersReq)(nil),  // 9: api.lottery.v1.GetRecentWinnersReq
+	(*RecentWinner)(nil),         // 10: api.lottery.v1.RecentWinner
+	(*GetRecentWinnersRsp)(nil),  // 11: api.lottery.v1.GetRecentWinnersRsp
+	(*GetShowcaseReq)(nil),       // 12: api.lottery.v1.GetShowcaseReq
+	(*ShowcasePrize)(nil),        // 13: api.lottery.v1.ShowcasePrize
+	(*GetShowcaseRsp)(nil),       // 14: api.lottery.v1.GetShowcaseRsp
+	(*ViewPrize)(nil),            // 15: api.lottery.v1.ViewPrize
+	(*ActivityInfo)(nil),         // 16: api.lottery.v1.ActivityInfo
+	(*AdminRsp)(nil),             // 17: api.lottery.v1.AdminRsp
+	(*AddPrizeReq)(nil),          // 18: api.lottery.v1.AddPrizeReq
+	(*AddPrizeListReq)(nil),      // 19: api.lottery.v1.AddPrizeListReq
+	(*AdminClearReq)(nil),        // 20: api.lottery.v1.AdminClearReq
+	(*ImportCouponReq)(nil),      // 21: api.lottery.v1.ImportCouponReq
+	(*GetActivityListReq)(nil),   // 22: api.lottery.v1.GetActivityListReq
+	(*GetActivityListRsp)(nil),   // 23: api.lottery.v1.GetActivityListRsp
+	(*ActivityReq)(nil),          // 24: api.lottery.v1.ActivityReq
+}
+var file_lottery_v1_lottery_proto_depIdxs = []int32{
+	0,  // 0: api.lottery.v1.LotteryRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	2,  // 1: api.lottery.v1.LotteryRsp.prize_info:type_name -> api.lottery.v1.LotteryPrizeInfo
+	0,  // 2: api.lottery.v1.GetMyWinsRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	5,  // 3: api.lottery.v1.GetMyWinsRsp.win_list:type_name -> api.lottery.v1.WinInfo
+	0,  // 4: api.lottery.v1.GetMyLotteryTimesRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	0,  // 5: api.lottery.v1.GetRecentWinnersRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	10, // 6: api.lottery.v1.GetRecentWinnersRsp.winner_list:type_name -> api.lottery.v1.RecentWinner
+	0,  // 7: api.lottery.v1.GetShowcaseRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	13, // 8: api.lottery.v1.GetShowcaseRsp.prize_list:type_name -> api.lottery.v1.ShowcasePrize
+	0,  // 9: api.lottery.v1.AdminRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	15, // 10: api.lottery.v1.AddPrizeReq.prize:type_name -> api.lottery.v1.ViewPrize
+	15, // 11: api.lottery.v1.AddPrizeListReq.prize_list:type_name -> api.lottery.v1.ViewPrize
+	0,  // 12: api.lottery.v1.GetActivityListRsp.common_rsp:type_name -> api.lottery.v1.CommonRspInfo
+	16, // 13: api.lottery.v1.GetActivityListRsp.activity_list:type_name -> api.lottery.v1.ActivityInfo
+	16, // 14: api.lottery.v1.ActivityReq.activity:type_name -> api.lottery.v1.ActivityInfo
+	1,  // 15: api.lottery.v1.Lottery.LotteryV1:input_type -> api.lottery.v1.LotteryReq
+	1,  // 16: api.lottery.v1.Lottery.LotteryV2:input_type -> api.lottery.v1.LotteryReq
+	1,  // 17: api.lottery.v1.Lottery.LotteryV3:input_type -> api.lottery.v1.LotteryReq
+	4,  // 18: api.lottery.v1.Lottery.GetMyWins:input_type -> api.lottery.v1.GetMyWinsReq
+	7,  // 19: api.lottery.v1.Lottery.GetMyLotteryTimes:input_type -> api.lottery.v1.GetMyLotteryTimesReq
+	9,  // 20: api.lottery.v1.Lottery.GetRecentWinners:input_type -> api.lottery.v1.GetRecentWinnersReq
+	12, // 21: api.lottery.v1.Lottery.GetShowcase:input_type -> api.lottery.v1.GetShowcaseReq
+	18, // 22: api.lottery.v1.LotteryAdmin.AddPrize:input_type -> api.lottery.v1.AddPrizeReq
+	19, // 23: api.lottery.v1.LotteryAdmin.AddPrizeList:input_type -> api.lottery.v1.AddPrizeListReq
+	20, // 24: api.lottery.v1.LotteryAdmin.ClearPrize:input_type -> api.lottery.v1.AdminClearReq
+	21, // 25: api.lottery.v1.LotteryAdmin.ImportCoupon:input_type -> api.lottery.v1.ImportCouponReq
+	21, // 26: api.lottery.v1.LotteryAdmin.ImportCouponWithCache:input_type -> api.lottery.v1.ImportCouponReq
+	20, // 27: api.lottery.v1.LotteryAdmin.ClearCoupon:input_type -> api.lottery.v1.AdminClearReq
+	20, // 28: api.lottery.v1.LotteryAdmin.ClearLotteryTimes:input_type -> api.lottery.v1.AdminClearReq
+	20, // 29: api.lottery.v1.LotteryAdmin.ClearResult:input_type -> api.lottery.v1.AdminClearReq
+	22, // 30: api.lottery.v1.LotteryAdmin.GetActivityList:input_type -> api.lottery.v1.GetActivityListReq
+	24, // 31: api.lottery.v1.LotteryAdmin.AddActivity:input_type -> api.lottery.v1.ActivityReq
+	24, // 32: api.lottery.v1.LotteryAdmin.UpdateActivity:input_type -> api.lottery.v1.ActivityReq
+	3,  // 33: api.lottery.v1.Lottery.LotteryV1:output_type -> api.lottery.v1.LotteryRsp
+	3,  // 34: api.lottery.v1.Lottery.LotteryV2:output_type -> api.lottery.v1.LotteryRsp
+	3,  // 35: api.lottery.v1.Lottery.LotteryV3:output_type -> api.lottery.v1.LotteryRsp
+	6,  // 36: api.lottery.v1.Lottery.GetMyWins:output_type -> api.lottery.v1.GetMyWinsRsp
+	8,  // 37: api.lottery.v1.Lottery.GetMyLotteryTimes:output_type -> api.lottery.v1.GetMyLotteryTimesRsp
+	11, // 38: api.lottery.v1.Lottery.GetRecentWinners:output_type -> api.lottery.v1.GetRecentWinnersRsp
+	14, // 39: api.lottery.v1.Lottery.GetShowcase:output_type -> api.lottery.v1.GetShowcaseRsp
+	17, // 40: api.lottery.v1.LotteryAdmin.AddPrize:output_type -> api.lottery.v1.AdminRsp
+	17, // 41: api.lottery.v1.LotteryAdmin.AddPrizeList:output_type -> api.lottery.v1.AdminRsp
+	17, // 42: api.lottery.v1.LotteryAdmin.ClearPrize:output_type -> api.lottery.v1.AdminRsp
+	17, // 43: api.lottery.v1.LotteryAdmin.ImportCoupon:output_type -> api.lottery.v1.AdminRsp
+	17, // 44: api.lottery.v1.LotteryAdmin.ImportCouponWithCache:output_type -> api.lottery.v1.AdminRsp
+	17, // 45: api.lottery.v1.LotteryAdmin.ClearCoupon:output_type -> api.lottery.v1.AdminRsp
+	17, // 46: api.lottery.v1.LotteryAdmin.ClearLotteryTimes:output_type -> api.lottery.v1.AdminRsp
+	17, // 47: api.lottery.v1.LotteryAdmin.ClearResult:output_type -> api.lottery.v1.AdminRsp
+	23, // 48: api.lottery.v1.LotteryAdmin.GetActivityList:output_type -> api.lottery.v1.GetActivityListRsp
+	17, // 49: api.lottery.v1.LotteryAdmin.AddActivity:output_type -> api.lottery.v1.AdminRsp
+	17, // 50: api.lottery.v1.LotteryAdmin.UpdateActivity:output_type -> api.lottery.v1.AdminRsp
+	33, // [33:51] is the sub-list for method output_type
+	15, // [15:33] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_lottery_v1_lottery_proto_init() }
+func file_lottery_v1_lottery_proto_init() {
+	if File_lottery_v1_lottery_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_lottery_v1_lottery_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommonRspInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LotteryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LotteryPrizeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LotteryRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMyWinsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WinInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMyWinsRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMyLotteryTimesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMyLotteryTimesRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecentWinnersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecentWinner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecentWinnersRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetShowcaseReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowcasePrize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetShowcaseRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewPrize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivityInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPrizeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPrizeListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminClearReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportCouponReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetActivityListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetActivityListRsp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lottery_v1_lottery_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivityReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -468,15 +2682,16 @@ func file_lottery_v1_lottery_proto_init() {
 			}
 		}
 	}
+	file_lottery_v1_lottery_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lottery_v1_lottery_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   25,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_lottery_v1_lottery_proto_goTypes,
 		DependencyIndexes: file_lottery_v1_lottery_proto_depIdxs,
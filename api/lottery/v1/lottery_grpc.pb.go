@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Lottery_LotteryV1_FullMethodName = "/api.lottery.v1.Lottery/LotteryV1"
+	Lottery_LotteryV1_FullMethodName         = "/api.lottery.v1.Lottery/LotteryV1"
+	Lottery_LotteryV2_FullMethodName         = "/api.lottery.v1.Lottery/LotteryV2"
+	Lottery_LotteryV3_FullMethodName         = "/api.lottery.v1.Lottery/LotteryV3"
+	Lottery_GetMyWins_FullMethodName         = "/api.lottery.v1.Lottery/GetMyWins"
+	Lottery_GetMyLotteryTimes_FullMethodName = "/api.lottery.v1.Lottery/GetMyLotteryTimes"
+	Lottery_GetRecentWinners_FullMethodName  = "/api.lottery.v1.Lottery/GetRecentWinners"
+	Lottery_GetShowcase_FullMethodName       = "/api.lottery.v1.Lottery/GetShowcase"
 )
 
 // LotteryClient is the client API for Lottery service.
@@ -27,6 +33,18 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LotteryClient interface {
 	LotteryV1(ctx context.Context, in *LotteryReq, opts ...grpc.CallOption) (*LotteryRsp, error)
+	// LotteryV2 带缓存优化的抽奖
+	LotteryV2(ctx context.Context, in *LotteryReq, opts ...grpc.CallOption) (*LotteryRsp, error)
+	// LotteryV3 带奖品池的抽奖
+	LotteryV3(ctx context.Context, in *LotteryReq, opts ...grpc.CallOption) (*LotteryRsp, error)
+	// GetMyWins 分页查询用户的中奖纪录
+	GetMyWins(ctx context.Context, in *GetMyWinsReq, opts ...grpc.CallOption) (*GetMyWinsRsp, error)
+	// GetMyLotteryTimes 查询用户今天剩余的抽奖次数
+	GetMyLotteryTimes(ctx context.Context, in *GetMyLotteryTimesReq, opts ...grpc.CallOption) (*GetMyLotteryTimesRsp, error)
+	// GetRecentWinners 最近中奖的用户，用户名打码
+	GetRecentWinners(ctx context.Context, in *GetRecentWinnersReq, opts ...grpc.CallOption) (*GetRecentWinnersRsp, error)
+	// GetShowcase 活动当前可抽的奖品，按展示顺序排列
+	GetShowcase(ctx context.Context, in *GetShowcaseReq, opts ...grpc.CallOption) (*GetShowcaseRsp, error)
 }
 
 type lotteryClient struct {
@@ -46,11 +64,77 @@ func (c *lotteryClient) LotteryV1(ctx context.Context, in *LotteryReq, opts ...g
 	return out, nil
 }
 
+func (c *lotteryClient) LotteryV2(ctx context.Context, in *LotteryReq, opts ...grpc.CallOption) (*LotteryRsp, error) {
+	out := new(LotteryRsp)
+	err := c.cc.Invoke(ctx, Lottery_LotteryV2_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) LotteryV3(ctx context.Context, in *LotteryReq, opts ...grpc.CallOption) (*LotteryRsp, error) {
+	out := new(LotteryRsp)
+	err := c.cc.Invoke(ctx, Lottery_LotteryV3_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetMyWins(ctx context.Context, in *GetMyWinsReq, opts ...grpc.CallOption) (*GetMyWinsRsp, error) {
+	out := new(GetMyWinsRsp)
+	err := c.cc.Invoke(ctx, Lottery_GetMyWins_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetMyLotteryTimes(ctx context.Context, in *GetMyLotteryTimesReq, opts ...grpc.CallOption) (*GetMyLotteryTimesRsp, error) {
+	out := new(GetMyLotteryTimesRsp)
+	err := c.cc.Invoke(ctx, Lottery_GetMyLotteryTimes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetRecentWinners(ctx context.Context, in *GetRecentWinnersReq, opts ...grpc.CallOption) (*GetRecentWinnersRsp, error) {
+	out := new(GetRecentWinnersRsp)
+	err := c.cc.Invoke(ctx, Lottery_GetRecentWinners_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryClient) GetShowcase(ctx context.Context, in *GetShowcaseReq, opts ...grpc.CallOption) (*GetShowcaseRsp, error) {
+	out := new(GetShowcaseRsp)
+	err := c.cc.Invoke(ctx, Lottery_GetShowcase_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LotteryServer is the server API for Lottery service.
 // All implementations must embed UnimplementedLotteryServer
 // for forward compatibility
 type LotteryServer interface {
 	LotteryV1(context.Context, *LotteryReq) (*LotteryRsp, error)
+	// LotteryV2 带缓存优化的抽奖
+	LotteryV2(context.Context, *LotteryReq) (*LotteryRsp, error)
+	// LotteryV3 带奖品池的抽奖
+	LotteryV3(context.Context, *LotteryReq) (*LotteryRsp, error)
+	// GetMyWins 分页查询用户的中奖纪录
+	GetMyWins(context.Context, *GetMyWinsReq) (*GetMyWinsRsp, error)
+	// GetMyLotteryTimes 查询用户今天剩余的抽奖次数
+	GetMyLotteryTimes(context.Context, *GetMyLotteryTimesReq) (*GetMyLotteryTimesRsp, error)
+	// GetRecentWinners 最近中奖的用户，用户名打码
+	GetRecentWinners(context.Context, *GetRecentWinnersReq) (*GetRecentWinnersRsp, error)
+	// GetShowcase 活动当前可抽的奖品，按展示顺序排列
+	GetShowcase(context.Context, *GetShowcaseReq) (*GetShowcaseRsp, error)
 	mustEmbedUnimplementedLotteryServer()
 }
 
@@ -61,6 +145,24 @@ type UnimplementedLotteryServer struct {
 func (UnimplementedLotteryServer) LotteryV1(context.Context, *LotteryReq) (*LotteryRsp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LotteryV1 not implemented")
 }
+func (UnimplementedLotteryServer) LotteryV2(context.Context, *LotteryReq) (*LotteryRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LotteryV2 not implemented")
+}
+func (UnimplementedLotteryServer) LotteryV3(context.Context, *LotteryReq) (*LotteryRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LotteryV3 not implemented")
+}
+func (UnimplementedLotteryServer) GetMyWins(context.Context, *GetMyWinsReq) (*GetMyWinsRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyWins not implemented")
+}
+func (UnimplementedLotteryServer) GetMyLotteryTimes(context.Context, *GetMyLotteryTimesReq) (*GetMyLotteryTimesRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyLotteryTimes not implemented")
+}
+func (UnimplementedLotteryServer) GetRecentWinners(context.Context, *GetRecentWinnersReq) (*GetRecentWinnersRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentWinners not implemented")
+}
+func (UnimplementedLotteryServer) GetShowcase(context.Context, *GetShowcaseReq) (*GetShowcaseRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShowcase not implemented")
+}
 func (UnimplementedLotteryServer) mustEmbedUnimplementedLotteryServer() {}
 
 // UnsafeLotteryServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +194,114 @@ func _Lottery_LotteryV1_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lottery_LotteryV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).LotteryV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_LotteryV2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).LotteryV2(ctx, req.(*LotteryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_LotteryV3_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotteryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).LotteryV3(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_LotteryV3_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).LotteryV3(ctx, req.(*LotteryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetMyWins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyWinsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetMyWins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_GetMyWins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetMyWins(ctx, req.(*GetMyWinsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetMyLotteryTimes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyLotteryTimesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetMyLotteryTimes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_GetMyLotteryTimes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetMyLotteryTimes(ctx, req.(*GetMyLotteryTimesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetRecentWinners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentWinnersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetRecentWinners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_GetRecentWinners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetRecentWinners(ctx, req.(*GetRecentWinnersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lottery_GetShowcase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShowcaseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryServer).GetShowcase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lottery_GetShowcase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryServer).GetShowcase(ctx, req.(*GetShowcaseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Lottery_ServiceDesc is the grpc.ServiceDesc for Lottery service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +313,490 @@ var Lottery_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LotteryV1",
 			Handler:    _Lottery_LotteryV1_Handler,
 		},
+		{
+			MethodName: "LotteryV2",
+			Handler:    _Lottery_LotteryV2_Handler,
+		},
+		{
+			MethodName: "LotteryV3",
+			Handler:    _Lottery_LotteryV3_Handler,
+		},
+		{
+			MethodName: "GetMyWins",
+			Handler:    _Lottery_GetMyWins_Handler,
+		},
+		{
+			MethodName: "GetMyLotteryTimes",
+			Handler:    _Lottery_GetMyLotteryTimes_Handler,
+		},
+		{
+			MethodName: "GetRecentWinners",
+			Handler:    _Lottery_GetRecentWinners_Handler,
+		},
+		{
+			MethodName: "GetShowcase",
+			Handler:    _Lottery_GetShowcase_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lottery/v1/lottery.proto",
+}
+
+const (
+	LotteryAdmin_AddPrize_FullMethodName              = "/api.lottery.v1.LotteryAdmin/AddPrize"
+	LotteryAdmin_AddPrizeList_FullMethodName          = "/api.lottery.v1.LotteryAdmin/AddPrizeList"
+	LotteryAdmin_ClearPrize_FullMethodName            = "/api.lottery.v1.LotteryAdmin/ClearPrize"
+	LotteryAdmin_ImportCoupon_FullMethodName          = "/api.lottery.v1.LotteryAdmin/ImportCoupon"
+	LotteryAdmin_ImportCouponWithCache_FullMethodName = "/api.lottery.v1.LotteryAdmin/ImportCouponWithCache"
+	LotteryAdmin_ClearCoupon_FullMethodName           = "/api.lottery.v1.LotteryAdmin/ClearCoupon"
+	LotteryAdmin_ClearLotteryTimes_FullMethodName     = "/api.lottery.v1.LotteryAdmin/ClearLotteryTimes"
+	LotteryAdmin_ClearResult_FullMethodName           = "/api.lottery.v1.LotteryAdmin/ClearResult"
+	LotteryAdmin_GetActivityList_FullMethodName       = "/api.lottery.v1.LotteryAdmin/GetActivityList"
+	LotteryAdmin_AddActivity_FullMethodName           = "/api.lottery.v1.LotteryAdmin/AddActivity"
+	LotteryAdmin_UpdateActivity_FullMethodName        = "/api.lottery.v1.LotteryAdmin/UpdateActivity"
+)
+
+// LotteryAdminClient is the client API for LotteryAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LotteryAdminClient interface {
+	AddPrize(ctx context.Context, in *AddPrizeReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	AddPrizeList(ctx context.Context, in *AddPrizeListReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ClearPrize(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ImportCoupon(ctx context.Context, in *ImportCouponReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ImportCouponWithCache(ctx context.Context, in *ImportCouponReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ClearCoupon(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ClearLotteryTimes(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	ClearResult(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	GetActivityList(ctx context.Context, in *GetActivityListReq, opts ...grpc.CallOption) (*GetActivityListRsp, error)
+	AddActivity(ctx context.Context, in *ActivityReq, opts ...grpc.CallOption) (*AdminRsp, error)
+	UpdateActivity(ctx context.Context, in *ActivityReq, opts ...grpc.CallOption) (*AdminRsp, error)
+}
+
+type lotteryAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLotteryAdminClient(cc grpc.ClientConnInterface) LotteryAdminClient {
+	return &lotteryAdminClient{cc}
+}
+
+func (c *lotteryAdminClient) AddPrize(ctx context.Context, in *AddPrizeReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_AddPrize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) AddPrizeList(ctx context.Context, in *AddPrizeListReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_AddPrizeList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ClearPrize(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ClearPrize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ImportCoupon(ctx context.Context, in *ImportCouponReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ImportCoupon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ImportCouponWithCache(ctx context.Context, in *ImportCouponReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ImportCouponWithCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ClearCoupon(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ClearCoupon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ClearLotteryTimes(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ClearLotteryTimes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) ClearResult(ctx context.Context, in *AdminClearReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_ClearResult_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) GetActivityList(ctx context.Context, in *GetActivityListReq, opts ...grpc.CallOption) (*GetActivityListRsp, error) {
+	out := new(GetActivityListRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_GetActivityList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) AddActivity(ctx context.Context, in *ActivityReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_AddActivity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lotteryAdminClient) UpdateActivity(ctx context.Context, in *ActivityReq, opts ...grpc.CallOption) (*AdminRsp, error) {
+	out := new(AdminRsp)
+	err := c.cc.Invoke(ctx, LotteryAdmin_UpdateActivity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LotteryAdminServer is the server API for LotteryAdmin service.
+// All implementations must embed UnimplementedLotteryAdminServer
+// for forward compatibility
+type LotteryAdminServer interface {
+	AddPrize(context.Context, *AddPrizeReq) (*AdminRsp, error)
+	AddPrizeList(context.Context, *AddPrizeListReq) (*AdminRsp, error)
+	ClearPrize(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ImportCoupon(context.Context, *ImportCouponReq) (*AdminRsp, error)
+	ImportCouponWithCache(context.Context, *ImportCouponReq) (*AdminRsp, error)
+	ClearCoupon(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ClearLotteryTimes(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ClearResult(context.Context, *AdminClearReq) (*AdminRsp, error)
+	GetActivityList(context.Context, *GetActivityListReq) (*GetActivityListRsp, error)
+	AddActivity(context.Context, *ActivityReq) (*AdminRsp, error)
+	UpdateActivity(context.Context, *ActivityReq) (*AdminRsp, error)
+	mustEmbedUnimplementedLotteryAdminServer()
+}
+
+// UnimplementedLotteryAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedLotteryAdminServer struct {
+}
+
+func (UnimplementedLotteryAdminServer) AddPrize(context.Context, *AddPrizeReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrize not implemented")
+}
+func (UnimplementedLotteryAdminServer) AddPrizeList(context.Context, *AddPrizeListReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrizeList not implemented")
+}
+func (UnimplementedLotteryAdminServer) ClearPrize(context.Context, *AdminClearReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearPrize not implemented")
+}
+func (UnimplementedLotteryAdminServer) ImportCoupon(context.Context, *ImportCouponReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCoupon not implemented")
+}
+func (UnimplementedLotteryAdminServer) ImportCouponWithCache(context.Context, *ImportCouponReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCouponWithCache not implemented")
+}
+func (UnimplementedLotteryAdminServer) ClearCoupon(context.Context, *AdminClearReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCoupon not implemented")
+}
+func (UnimplementedLotteryAdminServer) ClearLotteryTimes(context.Context, *AdminClearReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearLotteryTimes not implemented")
+}
+func (UnimplementedLotteryAdminServer) ClearResult(context.Context, *AdminClearReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearResult not implemented")
+}
+func (UnimplementedLotteryAdminServer) GetActivityList(context.Context, *GetActivityListReq) (*GetActivityListRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivityList not implemented")
+}
+func (UnimplementedLotteryAdminServer) AddActivity(context.Context, *ActivityReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddActivity not implemented")
+}
+func (UnimplementedLotteryAdminServer) UpdateActivity(context.Context, *ActivityReq) (*AdminRsp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateActivity not implemented")
+}
+func (UnimplementedLotteryAdminServer) mustEmbedUnimplementedLotteryAdminServer() {}
+
+// UnsafeLotteryAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LotteryAdminServer will
+// result in compilation errors.
+type UnsafeLotteryAdminServer interface {
+	mustEmbedUnimplementedLotteryAdminServer()
+}
+
+func RegisterLotteryAdminServer(s grpc.ServiceRegistrar, srv LotteryAdminServer) {
+	s.RegisterService(&LotteryAdmin_ServiceDesc, srv)
+}
+
+func _LotteryAdmin_AddPrize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPrizeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).AddPrize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_AddPrize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).AddPrize(ctx, req.(*AddPrizeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_AddPrizeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPrizeListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).AddPrizeList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_AddPrizeList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).AddPrizeList(ctx, req.(*AddPrizeListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ClearPrize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminClearReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ClearPrize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ClearPrize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ClearPrize(ctx, req.(*AdminClearReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ImportCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCouponReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ImportCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ImportCoupon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ImportCoupon(ctx, req.(*ImportCouponReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ImportCouponWithCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCouponReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ImportCouponWithCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ImportCouponWithCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ImportCouponWithCache(ctx, req.(*ImportCouponReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ClearCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminClearReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ClearCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ClearCoupon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ClearCoupon(ctx, req.(*AdminClearReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ClearLotteryTimes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminClearReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ClearLotteryTimes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ClearLotteryTimes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ClearLotteryTimes(ctx, req.(*AdminClearReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_ClearResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminClearReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).ClearResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_ClearResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).ClearResult(ctx, req.(*AdminClearReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_GetActivityList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivityListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).GetActivityList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_GetActivityList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).GetActivityList(ctx, req.(*GetActivityListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_AddActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).AddActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_AddActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).AddActivity(ctx, req.(*ActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LotteryAdmin_UpdateActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LotteryAdminServer).UpdateActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LotteryAdmin_UpdateActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LotteryAdminServer).UpdateActivity(ctx, req.(*ActivityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LotteryAdmin_ServiceDesc is the grpc.ServiceDesc for LotteryAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LotteryAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.lottery.v1.LotteryAdmin",
+	HandlerType: (*LotteryAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddPrize",
+			Handler:    _LotteryAdmin_AddPrize_Handler,
+		},
+		{
+			MethodName: "AddPrizeList",
+			Handler:    _LotteryAdmin_AddPrizeList_Handler,
+		},
+		{
+			MethodName: "ClearPrize",
+			Handler:    _LotteryAdmin_ClearPrize_Handler,
+		},
+		{
+			MethodName: "ImportCoupon",
+			Handler:    _LotteryAdmin_ImportCoupon_Handler,
+		},
+		{
+			MethodName: "ImportCouponWithCache",
+			Handler:    _LotteryAdmin_ImportCouponWithCache_Handler,
+		},
+		{
+			MethodName: "ClearCoupon",
+			Handler:    _LotteryAdmin_ClearCoupon_Handler,
+		},
+		{
+			MethodName: "ClearLotteryTimes",
+			Handler:    _LotteryAdmin_ClearLotteryTimes_Handler,
+		},
+		{
+			MethodName: "ClearResult",
+			Handler:    _LotteryAdmin_ClearResult_Handler,
+		},
+		{
+			MethodName: "GetActivityList",
+			Handler:    _LotteryAdmin_GetActivityList_Handler,
+		},
+		{
+			MethodName: "AddActivity",
+			Handler:    _LotteryAdmin_AddActivity_Handler,
+		},
+		{
+			MethodName: "UpdateActivity",
+			Handler:    _LotteryAdmin_UpdateActivity_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lottery/v1/lottery.proto",
@@ -20,14 +20,32 @@ var _ = binding.EncodeURL
 const _ = http.SupportPackageIsVersion1
 
 const OperationLotteryLotteryV1 = "/api.lottery.v1.Lottery/LotteryV1"
+const OperationLotteryLotteryV2 = "/api.lottery.v1.Lottery/LotteryV2"
+const OperationLotteryLotteryV3 = "/api.lottery.v1.Lottery/LotteryV3"
+const OperationLotteryGetMyWins = "/api.lottery.v1.Lottery/GetMyWins"
+const OperationLotteryGetMyLotteryTimes = "/api.lottery.v1.Lottery/GetMyLotteryTimes"
+const OperationLotteryGetRecentWinners = "/api.lottery.v1.Lottery/GetRecentWinners"
+const OperationLotteryGetShowcase = "/api.lottery.v1.Lottery/GetShowcase"
 
 type LotteryHTTPServer interface {
 	LotteryV1(context.Context, *LotteryReq) (*LotteryRsp, error)
+	LotteryV2(context.Context, *LotteryReq) (*LotteryRsp, error)
+	LotteryV3(context.Context, *LotteryReq) (*LotteryRsp, error)
+	GetMyWins(context.Context, *GetMyWinsReq) (*GetMyWinsRsp, error)
+	GetMyLotteryTimes(context.Context, *GetMyLotteryTimesReq) (*GetMyLotteryTimesRsp, error)
+	GetRecentWinners(context.Context, *GetRecentWinnersReq) (*GetRecentWinnersRsp, error)
+	GetShowcase(context.Context, *GetShowcaseReq) (*GetShowcaseRsp, error)
 }
 
 func RegisterLotteryHTTPServer(s *http.Server, srv LotteryHTTPServer) {
 	r := s.Route("/")
 	r.POST("/lottery", _Lottery_LotteryV10_HTTP_Handler(srv))
+	r.POST("/lottery/v2", _Lottery_LotteryV20_HTTP_Handler(srv))
+	r.POST("/lottery/v3", _Lottery_LotteryV30_HTTP_Handler(srv))
+	r.GET("/lottery/my_wins", _Lottery_GetMyWins0_HTTP_Handler(srv))
+	r.GET("/lottery/my_lottery_times", _Lottery_GetMyLotteryTimes0_HTTP_Handler(srv))
+	r.GET("/lottery/recent_winners", _Lottery_GetRecentWinners0_HTTP_Handler(srv))
+	r.GET("/lottery/showcase", _Lottery_GetShowcase0_HTTP_Handler(srv))
 }
 
 func _Lottery_LotteryV10_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
@@ -52,8 +70,134 @@ func _Lottery_LotteryV10_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Conte
 	}
 }
 
+func _Lottery_LotteryV20_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in LotteryReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryLotteryV2)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.LotteryV2(ctx, req.(*LotteryReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*LotteryRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Lottery_LotteryV30_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in LotteryReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryLotteryV3)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.LotteryV3(ctx, req.(*LotteryReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*LotteryRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Lottery_GetMyWins0_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetMyWinsReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryGetMyWins)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMyWins(ctx, req.(*GetMyWinsReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMyWinsRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Lottery_GetMyLotteryTimes0_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetMyLotteryTimesReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryGetMyLotteryTimes)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetMyLotteryTimes(ctx, req.(*GetMyLotteryTimesReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetMyLotteryTimesRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Lottery_GetRecentWinners0_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetRecentWinnersReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryGetRecentWinners)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetRecentWinners(ctx, req.(*GetRecentWinnersReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetRecentWinnersRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _Lottery_GetShowcase0_HTTP_Handler(srv LotteryHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetShowcaseReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryGetShowcase)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetShowcase(ctx, req.(*GetShowcaseReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetShowcaseRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
 type LotteryHTTPClient interface {
 	LotteryV1(ctx context.Context, req *LotteryReq, opts ...http.CallOption) (rsp *LotteryRsp, err error)
+	LotteryV2(ctx context.Context, req *LotteryReq, opts ...http.CallOption) (rsp *LotteryRsp, err error)
+	LotteryV3(ctx context.Context, req *LotteryReq, opts ...http.CallOption) (rsp *LotteryRsp, err error)
+	GetMyWins(ctx context.Context, req *GetMyWinsReq, opts ...http.CallOption) (rsp *GetMyWinsRsp, err error)
+	GetMyLotteryTimes(ctx context.Context, req *GetMyLotteryTimesReq, opts ...http.CallOption) (rsp *GetMyLotteryTimesRsp, err error)
+	GetRecentWinners(ctx context.Context, req *GetRecentWinnersReq, opts ...http.CallOption) (rsp *GetRecentWinnersRsp, err error)
+	GetShowcase(ctx context.Context, req *GetShowcaseReq, opts ...http.CallOption) (rsp *GetShowcaseRsp, err error)
 }
 
 type LotteryHTTPClientImpl struct {
@@ -76,3 +220,526 @@ func (c *LotteryHTTPClientImpl) LotteryV1(ctx context.Context, in *LotteryReq, o
 	}
 	return &out, err
 }
+
+func (c *LotteryHTTPClientImpl) LotteryV2(ctx context.Context, in *LotteryReq, opts ...http.CallOption) (*LotteryRsp, error) {
+	var out LotteryRsp
+	pattern := "/lottery/v2"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryLotteryV2))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryHTTPClientImpl) LotteryV3(ctx context.Context, in *LotteryReq, opts ...http.CallOption) (*LotteryRsp, error) {
+	var out LotteryRsp
+	pattern := "/lottery/v3"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryLotteryV3))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryHTTPClientImpl) GetMyWins(ctx context.Context, in *GetMyWinsReq, opts ...http.CallOption) (*GetMyWinsRsp, error) {
+	var out GetMyWinsRsp
+	pattern := "/lottery/my_wins"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLotteryGetMyWins))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryHTTPClientImpl) GetMyLotteryTimes(ctx context.Context, in *GetMyLotteryTimesReq, opts ...http.CallOption) (*GetMyLotteryTimesRsp, error) {
+	var out GetMyLotteryTimesRsp
+	pattern := "/lottery/my_lottery_times"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLotteryGetMyLotteryTimes))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryHTTPClientImpl) GetRecentWinners(ctx context.Context, in *GetRecentWinnersReq, opts ...http.CallOption) (*GetRecentWinnersRsp, error) {
+	var out GetRecentWinnersRsp
+	pattern := "/lottery/recent_winners"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLotteryGetRecentWinners))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryHTTPClientImpl) GetShowcase(ctx context.Context, in *GetShowcaseReq, opts ...http.CallOption) (*GetShowcaseRsp, error) {
+	var out GetShowcaseRsp
+	pattern := "/lottery/showcase"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLotteryGetShowcase))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+const OperationLotteryAdminAddPrize = "/api.lottery.v1.LotteryAdmin/AddPrize"
+const OperationLotteryAdminAddPrizeList = "/api.lottery.v1.LotteryAdmin/AddPrizeList"
+const OperationLotteryAdminClearPrize = "/api.lottery.v1.LotteryAdmin/ClearPrize"
+const OperationLotteryAdminImportCoupon = "/api.lottery.v1.LotteryAdmin/ImportCoupon"
+const OperationLotteryAdminImportCouponWithCache = "/api.lottery.v1.LotteryAdmin/ImportCouponWithCache"
+const OperationLotteryAdminClearCoupon = "/api.lottery.v1.LotteryAdmin/ClearCoupon"
+const OperationLotteryAdminClearLotteryTimes = "/api.lottery.v1.LotteryAdmin/ClearLotteryTimes"
+const OperationLotteryAdminClearResult = "/api.lottery.v1.LotteryAdmin/ClearResult"
+const OperationLotteryAdminGetActivityList = "/api.lottery.v1.LotteryAdmin/GetActivityList"
+const OperationLotteryAdminAddActivity = "/api.lottery.v1.LotteryAdmin/AddActivity"
+const OperationLotteryAdminUpdateActivity = "/api.lottery.v1.LotteryAdmin/UpdateActivity"
+
+type LotteryAdminHTTPServer interface {
+	AddPrize(context.Context, *AddPrizeReq) (*AdminRsp, error)
+	AddPrizeList(context.Context, *AddPrizeListReq) (*AdminRsp, error)
+	ClearPrize(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ImportCoupon(context.Context, *ImportCouponReq) (*AdminRsp, error)
+	ImportCouponWithCache(context.Context, *ImportCouponReq) (*AdminRsp, error)
+	ClearCoupon(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ClearLotteryTimes(context.Context, *AdminClearReq) (*AdminRsp, error)
+	ClearResult(context.Context, *AdminClearReq) (*AdminRsp, error)
+	GetActivityList(context.Context, *GetActivityListReq) (*GetActivityListRsp, error)
+	AddActivity(context.Context, *ActivityReq) (*AdminRsp, error)
+	UpdateActivity(context.Context, *ActivityReq) (*AdminRsp, error)
+}
+
+func RegisterLotteryAdminHTTPServer(s *http.Server, srv LotteryAdminHTTPServer) {
+	r := s.Route("/")
+	r.POST("/admin/add_prize", _LotteryAdmin_AddPrize0_HTTP_Handler(srv))
+	r.POST("/admin/add_prize_list", _LotteryAdmin_AddPrizeList0_HTTP_Handler(srv))
+	r.POST("/admin/clear_prize", _LotteryAdmin_ClearPrize0_HTTP_Handler(srv))
+	r.POST("/admin/import_coupon", _LotteryAdmin_ImportCoupon0_HTTP_Handler(srv))
+	r.POST("/admin/import_coupon_cache", _LotteryAdmin_ImportCouponWithCache0_HTTP_Handler(srv))
+	r.POST("/admin/clear_coupon", _LotteryAdmin_ClearCoupon0_HTTP_Handler(srv))
+	r.POST("/admin/clear_lottery_times", _LotteryAdmin_ClearLotteryTimes0_HTTP_Handler(srv))
+	r.POST("/admin/clear_result", _LotteryAdmin_ClearResult0_HTTP_Handler(srv))
+	r.GET("/admin/get_activity_list", _LotteryAdmin_GetActivityList0_HTTP_Handler(srv))
+	r.POST("/admin/add_activity", _LotteryAdmin_AddActivity0_HTTP_Handler(srv))
+	r.POST("/admin/update_activity", _LotteryAdmin_UpdateActivity0_HTTP_Handler(srv))
+}
+
+func _LotteryAdmin_AddPrize0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AddPrizeReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminAddPrize)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AddPrize(ctx, req.(*AddPrizeReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_AddPrizeList0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AddPrizeListReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminAddPrizeList)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AddPrizeList(ctx, req.(*AddPrizeListReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ClearPrize0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AdminClearReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminClearPrize)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ClearPrize(ctx, req.(*AdminClearReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ImportCoupon0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportCouponReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminImportCoupon)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportCoupon(ctx, req.(*ImportCouponReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ImportCouponWithCache0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ImportCouponReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminImportCouponWithCache)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ImportCouponWithCache(ctx, req.(*ImportCouponReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ClearCoupon0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AdminClearReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminClearCoupon)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ClearCoupon(ctx, req.(*AdminClearReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ClearLotteryTimes0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AdminClearReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminClearLotteryTimes)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ClearLotteryTimes(ctx, req.(*AdminClearReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_ClearResult0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AdminClearReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminClearResult)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ClearResult(ctx, req.(*AdminClearReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_GetActivityList0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetActivityListReq
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminGetActivityList)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetActivityList(ctx, req.(*GetActivityListReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetActivityListRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_AddActivity0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ActivityReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminAddActivity)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AddActivity(ctx, req.(*ActivityReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _LotteryAdmin_UpdateActivity0_HTTP_Handler(srv LotteryAdminHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ActivityReq
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationLotteryAdminUpdateActivity)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UpdateActivity(ctx, req.(*ActivityReq))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AdminRsp)
+		return ctx.Result(200, reply)
+	}
+}
+
+type LotteryAdminHTTPClient interface {
+	AddPrize(ctx context.Context, req *AddPrizeReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	AddPrizeList(ctx context.Context, req *AddPrizeListReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ClearPrize(ctx context.Context, req *AdminClearReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ImportCoupon(ctx context.Context, req *ImportCouponReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ImportCouponWithCache(ctx context.Context, req *ImportCouponReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ClearCoupon(ctx context.Context, req *AdminClearReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ClearLotteryTimes(ctx context.Context, req *AdminClearReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	ClearResult(ctx context.Context, req *AdminClearReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	GetActivityList(ctx context.Context, req *GetActivityListReq, opts ...http.CallOption) (rsp *GetActivityListRsp, err error)
+	AddActivity(ctx context.Context, req *ActivityReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+	UpdateActivity(ctx context.Context, req *ActivityReq, opts ...http.CallOption) (rsp *AdminRsp, err error)
+}
+
+type LotteryAdminHTTPClientImpl struct {
+	cc *http.Client
+}
+
+func NewLotteryAdminHTTPClient(client *http.Client) LotteryAdminHTTPClient {
+	return &LotteryAdminHTTPClientImpl{client}
+}
+
+func (c *LotteryAdminHTTPClientImpl) AddPrize(ctx context.Context, in *AddPrizeReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/add_prize"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminAddPrize))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) AddPrizeList(ctx context.Context, in *AddPrizeListReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/add_prize_list"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminAddPrizeList))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ClearPrize(ctx context.Context, in *AdminClearReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/clear_prize"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminClearPrize))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ImportCoupon(ctx context.Context, in *ImportCouponReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/import_coupon"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminImportCoupon))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ImportCouponWithCache(ctx context.Context, in *ImportCouponReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/import_coupon_cache"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminImportCouponWithCache))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ClearCoupon(ctx context.Context, in *AdminClearReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/clear_coupon"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminClearCoupon))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ClearLotteryTimes(ctx context.Context, in *AdminClearReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/clear_lottery_times"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminClearLotteryTimes))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) ClearResult(ctx context.Context, in *AdminClearReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/clear_result"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminClearResult))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) GetActivityList(ctx context.Context, in *GetActivityListReq, opts ...http.CallOption) (*GetActivityListRsp, error) {
+	var out GetActivityListRsp
+	pattern := "/admin/get_activity_list"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationLotteryAdminGetActivityList))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) AddActivity(ctx context.Context, in *ActivityReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/add_activity"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminAddActivity))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
+
+func (c *LotteryAdminHTTPClientImpl) UpdateActivity(ctx context.Context, in *ActivityReq, opts ...http.CallOption) (*AdminRsp, error) {
+	var out AdminRsp
+	pattern := "/admin/update_activity"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationLotteryAdminUpdateActivity))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, err
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/conf"
+	"github.com/BitofferHub/lotterysvr/internal/data"
 	"github.com/BitofferHub/lotterysvr/internal/task"
 	"github.com/BitofferHub/pkg/middlewares/discovery"
 	"github.com/BitofferHub/pkg/middlewares/log"
@@ -15,6 +18,8 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 
 	_ "go.uber.org/automaxprocs"
+	// 镜像中没有时区数据，业务时区使用内置的时区数据
+	_ "time/tzdata"
 )
 
 // go build -ldflags "-X main.Version=x.y.z"
@@ -70,7 +75,11 @@ func main() {
 	}
 
 	InitSource(&bc)
-	app, cleanup, err := wireApp(bc.GetServer(), bc.GetData())
+	lotteryConf, err := newLotteryConfig(c, bc.GetLottery())
+	if err != nil {
+		panic(err)
+	}
+	app, cleanup, err := wireApp(bc.GetServer(), bc.GetData(), lotteryConf)
 	if err != nil {
 		panic(err)
 	}
@@ -82,6 +91,40 @@ func main() {
 	}
 }
 
+// newLotteryConfig 加载抽奖限制，配置文件的 lottery 部分修改后实时生效
+func newLotteryConfig(c config.Config, lc *conf.Lottery) (*biz.LotteryConfig, error) {
+	limits, err := data.NewLotteryLimits(lc)
+	if err != nil {
+		return nil, err
+	}
+	lotteryConf := biz.NewLotteryConfig(limits)
+	err = c.Watch("lottery", func(key string, value config.Value) {
+		next := &conf.Lottery{}
+		if err := value.Scan(next); err != nil {
+			log.Errorf("watch %s|scan err:%v", key, err)
+			return
+		}
+		limits, err := data.NewLotteryLimits(next)
+		if err != nil {
+			log.Errorf("watch %s|err:%v", key, err)
+			return
+		}
+		// 缓存分片数量只在启动时生效，Update 会保留原值
+		if current := lotteryConf.Limits(); limits.IpFrameSize != current.IpFrameSize ||
+			limits.UserFrameSize != current.UserFrameSize {
+			log.Warnf("watch %s|ip_frame_size %d -> %d, user_frame_size %d -> %d take effect after restart", key,
+				current.IpFrameSize, limits.IpFrameSize, current.UserFrameSize, limits.UserFrameSize)
+		}
+		lotteryConf.Update(limits)
+		log.Infof("watch %s|reload lottery config:%+v", key, lotteryConf.Limits())
+	})
+	// 配置文件中没有 lottery 部分时使用默认值，不监听修改
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, err
+	}
+	return lotteryConf, nil
+}
+
 func InitSource(c *conf.Bootstrap) {
 	l := c.GetLog()
 	// 初始化日志
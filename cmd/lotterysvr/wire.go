@@ -12,6 +12,7 @@ import (
 	"github.com/BitofferHub/lotterysvr/internal/server"
 	"github.com/BitofferHub/lotterysvr/internal/service"
 	"github.com/BitofferHub/lotterysvr/internal/task"
+	"github.com/go-kratos/kratos/v2"
 	"github.com/google/wire"
 )
 
@@ -21,10 +22,11 @@ import (
 //	@Description: wireApp init kratos application.
 //	@param *conf.Server
 //	@param *conf.Data
+//	@param *biz.LotteryConfig
 //	@return *kratos.App
 //	@return func()
 //	@return error
-func wireApp(*conf.Server, *conf.Data) (*kratos.App, func(), error) {
+func wireApp(*conf.Server, *conf.Data, *biz.LotteryConfig) (*kratos.App, func(), error) {
 	panic(wire.Build(
 		server.ProviderSet,
 		data.ProviderSet,
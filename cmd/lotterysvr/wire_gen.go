@@ -19,6 +19,7 @@ import (
 
 import (
 	_ "go.uber.org/automaxprocs"
+	_ "time/tzdata"
 )
 
 // Injectors from wire.go:
@@ -29,10 +30,11 @@ import (
 //	@Description: wireApp init kratos application.
 //	@param *conf.Server
 //	@param *conf.Data
+//	@param *biz.LotteryConfig
 //	@return *kratos.App
 //	@return func()
 //	@return error
-func wireApp(confServer *conf.Server, confData *conf.Data) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, lotteryConfig *biz.LotteryConfig) (*kratos.App, func(), error) {
 	db := data.NewDatabase(confData)
 	client := data.NewCache(confData)
 	dataData := data.NewData(db, client)
@@ -41,18 +43,58 @@ func wireApp(confServer *conf.Server, confData *conf.Data) (*kratos.App, func(),
 	blackUserRepo := data.NewBlackUserRepo(dataData)
 	blackIpRepo := data.NewBlackIpRepo(dataData)
 	resultRepo := data.NewResultRepo(dataData)
+	activityRepo := data.NewActivityRepo(dataData)
+	winCapRepo := data.NewWinCapRepo(dataData)
+	drawRepo := data.NewDrawRepo(dataData, lotteryConfig)
+	drawOutboxRepo := data.NewDrawOutboxRepo(dataData)
+	resultSink, cleanup, err := data.NewResultSink(confData, dataData)
+	if err != nil {
+		return nil, nil, err
+	}
+	prizeDeliveryRepo := data.NewPrizeDeliveryRepo(dataData)
+	prizeDelivererSet, cleanup2, err := data.NewPrizeDelivererSet(confData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	transaction := data.NewTransaction(dataData)
-	lotteryCase := biz.NewLotteryCase(prizeRepo, couponRepo, blackUserRepo, blackIpRepo, resultRepo, transaction)
-	lotteryTimesRepo := data.NewLotteryTimesRepo(dataData)
-	limitCase := biz.NewLimitCase(blackUserRepo, blackIpRepo, lotteryTimesRepo, transaction)
-	adminCase := biz.NewAdminCase(prizeRepo, couponRepo, lotteryTimesRepo, resultRepo)
-	lotteryService := service.NewLotteryService(lotteryCase, limitCase, adminCase)
-	grpcServer := server.NewGRPCServer(confServer, lotteryService)
-	adminService := service.NewAdminService(adminCase)
-	handler := interfaces.NewHandler(lotteryService, adminService)
+	deliveryCase := biz.NewDeliveryCase(prizeDeliveryRepo, prizeDelivererSet, transaction)
+	lotteryCase := biz.NewLotteryCase(prizeRepo, couponRepo, blackUserRepo, blackIpRepo, resultRepo, activityRepo, winCapRepo, drawRepo, drawOutboxRepo, resultSink, deliveryCase, lotteryConfig, transaction)
+	lotteryTimesRepo := data.NewLotteryTimesRepo(dataData, lotteryConfig)
+	limitCase := biz.NewLimitCase(blackUserRepo, blackIpRepo, lotteryTimesRepo, lotteryConfig, transaction)
+	adminAuditRepo := data.NewAdminAuditRepo(dataData)
+	prizePlanRepo := data.NewPrizePlanRepo(dataData)
+	prizePoolLedgerRepo := data.NewPrizePoolLedgerRepo(dataData)
+	adminCase := biz.NewAdminCase(prizeRepo, couponRepo, lotteryTimesRepo, resultRepo, activityRepo, adminAuditRepo, blackUserRepo, blackIpRepo, prizePlanRepo, prizePoolLedgerRepo, lotteryConfig, transaction)
+	fulfillCase := biz.NewFulfillCase(resultRepo, prizeRepo, transaction)
+	riskRepo := data.NewRiskRepo(dataData)
+	userRepo := data.NewUserRepo(dataData)
+	riskCase := biz.NewRiskCase(riskRepo, userRepo, lotteryConfig)
+	lotteryService := service.NewLotteryService(lotteryCase, limitCase, adminCase, fulfillCase, deliveryCase, riskCase)
+	taskRepo := data.NewTaskRepo(dataData)
+	taskCase := biz.NewTaskCase(taskRepo, lotteryConfig)
+	adminService := service.NewAdminService(adminCase, fulfillCase, deliveryCase, taskCase, riskCase)
+	lotteryAdminService := service.NewLotteryAdminService(adminService)
+	userCase := biz.NewUserCase(userRepo)
+	userService := service.NewUserService(userCase)
+	clientIpResolver, err := server.NewClientIpResolver(confServer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	grpcServer := server.NewGRPCServer(confServer, lotteryService, lotteryAdminService, userService, adminService, clientIpResolver)
+	handler := interfaces.NewHandler(lotteryService, adminService, userService, clientIpResolver)
 	httpServer := server.NewHTTPServer(confServer, handler)
-	taskServer := task.NewTaskServer(lotteryService, confServer)
+	taskServer, err := task.NewTaskServer(lotteryService, taskCase, confServer)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	app := newApp(grpcServer, httpServer, taskServer)
 	return app, func() {
+		cleanup2()
+		cleanup()
 	}, nil
 }
@@ -0,0 +1,92 @@
+package biz
+
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"time"
+)
+
+// Activity 抽奖活动表，每个活动拥有独立的奖品、编码空间、每日限制和有效期
+// 概率权重策略的精度由权重的量级决定，如权重总和为1000000时精度为百万分之一
+type Activity struct {
+	Id             uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	Title          string     `gorm:"column:title;type:varchar(255);comment:活动名称;NOT NULL" json:"title"`
+	BeginTime      time.Time  `gorm:"column:begin_time;type:datetime;default:1000-01-01 00:00:00;comment:活动开始时间;NOT NULL" json:"begin_time"`
+	EndTime        time.Time  `gorm:"column:end_time;type:datetime;default:1000-01-01 00:00:00;comment:活动结束时间;NOT NULL" json:"end_time"`
+	UserDayMax     uint       `gorm:"column:user_day_max;type:int(10) unsigned;default:0;comment:用户每天最多抽奖次数，0使用默认值;NOT NULL" json:"user_day_max"`
+	IpDayMax       uint       `gorm:"column:ip_day_max;type:int(10) unsigned;default:0;comment:同一个IP每天最多抽奖次数，0使用默认值;NOT NULL" json:"ip_day_max"`
+	PrizeCodeMax   uint       `gorm:"column:prize_code_max;type:int(10) unsigned;default:0;comment:中奖编码空间，0使用默认值;NOT NULL" json:"prize_code_max"`
+	SelectStrategy uint       `gorm:"column:select_strategy;type:tinyint(3) unsigned;default:0;comment:奖品选择策略，0 中奖编码区间，1 概率权重;NOT NULL" json:"select_strategy"`
+	NoPrizeWeight  uint       `gorm:"column:no_prize_weight;type:int(10) unsigned;default:0;comment:不中奖权重，概率权重策略使用;NOT NULL" json:"no_prize_weight"`
+	PityThreshold  uint       `gorm:"column:pity_threshold;type:int(10) unsigned;default:0;comment:保底阈值，连续未中奖达到该次数后必中，0不保底;NOT NULL" json:"pity_threshold"`
+	PityPrizeType  uint       `gorm:"column:pity_prize_type;type:tinyint(3) unsigned;default:0;comment:保底奖品的最低类型，保底时只发放类型不低于该值的奖品;NOT NULL" json:"pity_prize_type"`
+	UserWinMax     uint       `gorm:"column:user_win_max;type:int(10) unsigned;default:0;comment:每个用户在活动中最多中奖次数，0不限制;NOT NULL" json:"user_win_max"`
+	TypeDayWinMax  string     `gorm:"column:type_day_win_max;type:varchar(255);comment:每个用户每天每种奖品类型最多中奖次数，格式 类型:次数,类型:次数;NOT NULL" json:"type_day_win_max"`
+	SysStatus      uint       `gorm:"column:sys_status;type:smallint(5) unsigned;default:0;comment:状态，1 正常，2 删除;NOT NULL" json:"sys_status"`
+	SysCreated     *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间;NOT NULL" json:"sys_created"`
+	SysUpdated     *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间;NOT NULL" json:"sys_updated"`
+}
+
+func (a *Activity) TableName() string {
+	return "t_activity"
+}
+
+// DefaultActivity 默认活动，不限制有效期，使用系统默认的限制
+// 选奖策略、保底和中奖上限使用配置文件 lottery.default_activity 的设置
+func DefaultActivity(limits *LotteryLimits) *Activity {
+	activity := &Activity{
+		Id:           constant.DefaultActivityID,
+		Title:        "default",
+		UserDayMax:   limits.UserPrizeMax,
+		IpDayMax:     limits.IpLimitMax,
+		PrizeCodeMax: limits.PrizeCodeMax,
+		SysStatus:    constant.ActivityStatusNormal,
+	}
+	if c := limits.DefaultActivity; c != nil {
+		activity.SelectStrategy = c.SelectStrategy
+		activity.NoPrizeWeight = c.NoPrizeWeight
+		activity.PityThreshold = c.PityThreshold
+		activity.PityPrizeType = c.PityPrizeType
+		activity.UserWinMax = c.UserWinMax
+		activity.TypeDayWinMax = c.TypeDayWinMax
+	}
+	return activity
+}
+
+// FillDefault 活动没有配置的限制使用系统默认值
+func (a *Activity) FillDefault(limits *LotteryLimits) {
+	if a.UserDayMax == 0 {
+		a.UserDayMax = limits.UserPrizeMax
+	}
+	if a.IpDayMax == 0 {
+		a.IpDayMax = limits.IpLimitMax
+	}
+	if a.PrizeCodeMax == 0 {
+		a.PrizeCodeMax = limits.PrizeCodeMax
+	}
+}
+
+// IsPity 用户连续未中奖次数是否达到保底阈值
+func (a *Activity) IsPity(missNum int64) bool {
+	return a.PityThreshold > 0 && missNum >= int64(a.PityThreshold)
+}
+
+// IsActive 活动是否处于有效期内
+func (a *Activity) IsActive(now time.Time) bool {
+	if a.SysStatus != constant.ActivityStatusNormal {
+		return false
+	}
+	if a.Id == constant.DefaultActivityID {
+		return true
+	}
+	return !a.BeginTime.After(now) && !a.EndTime.Before(now)
+}
+
+type ActivityRepo interface {
+	Get(id uint) (*Activity, error)
+	GetWithCache(id uint) (*Activity, error)
+	GetAll() ([]*Activity, error)
+	Create(activity *Activity) error
+	Update(activity *Activity, cols ...string) error
+	UpdateWithCache(activity *Activity, cols ...string) error
+	UpdateByCache(activity *Activity) error
+}
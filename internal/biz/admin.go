@@ -2,7 +2,6 @@ package biz
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/BitofferHub/lotterysvr/internal/utils"
@@ -17,17 +16,41 @@ type AdminCase struct {
 	prizeRepo        PrizeRepo
 	lotteryTimesRepo LotteryTimesRepo
 	resultRepo       ResultRepo
+	activityRepo     ActivityRepo
+	adminAuditRepo   AdminAuditRepo
+	blackUserRepo    BlackUserRepo
+	blackIpRepo      BlackIpRepo
+	prizePlanRepo    PrizePlanRepo
+	poolLedgerRepo   PrizePoolLedgerRepo
+	lotteryConf      *LotteryConfig
+	tm               Transaction
 }
 
-func NewAdminCase(pr PrizeRepo, cr CouponRepo, lr LotteryTimesRepo, rp ResultRepo) *AdminCase {
+func NewAdminCase(pr PrizeRepo, cr CouponRepo, lr LotteryTimesRepo, rp ResultRepo, ar ActivityRepo,
+	aar AdminAuditRepo, bur BlackUserRepo, bir BlackIpRepo, ppr PrizePlanRepo,
+	plr PrizePoolLedgerRepo, lc *LotteryConfig, tm Transaction) *AdminCase {
 	return &AdminCase{
 		couponRepo:       cr,
 		prizeRepo:        pr,
 		lotteryTimesRepo: lr,
 		resultRepo:       rp,
+		activityRepo:     ar,
+		adminAuditRepo:   aar,
+		blackUserRepo:    bur,
+		blackIpRepo:      bir,
+		prizePlanRepo:    ppr,
+		poolLedgerRepo:   plr,
+		lotteryConf:      lc,
+		tm:               tm,
 	}
 }
 
+// sysIPFromContext 获取接入层写入context的操作人IP
+func sysIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(constant.SysIP).(string)
+	return ip
+}
+
 // GetPrizeList 获取db奖品列表
 func (a *AdminCase) GetPrizeList(ctx context.Context) ([]*Prize, error) {
 	//log.InfoContextf(ctx, "GetPrizeList!!!!!")
@@ -39,9 +62,9 @@ func (a *AdminCase) GetPrizeList(ctx context.Context) ([]*Prize, error) {
 	return list, nil
 }
 
-// GetPrizeListWithCache 获取db奖品列表
-func (a *AdminCase) GetPrizeListWithCache(ctx context.Context) ([]*Prize, error) {
-	list, err := a.prizeRepo.GetAllWithCache()
+// GetPrizeListWithCache 获取某个活动的奖品列表，优先从缓存获取
+func (a *AdminCase) GetPrizeListWithCache(ctx context.Context, activityID uint) ([]*Prize, error) {
+	list, err := a.prizeRepo.GetAllWithCache(activityID)
 	if err != nil {
 		log.ErrorContextf(ctx, "prizeCase|GetPrizeList err:%v", err)
 		return nil, fmt.Errorf("prizeCase|GetPrizeList: %v", err)
@@ -49,9 +72,9 @@ func (a *AdminCase) GetPrizeListWithCache(ctx context.Context) ([]*Prize, error)
 	return list, nil
 }
 
-// GetViewPrizeList 获取奖品列表,这个方法用于管理后台使用，因为管理后台不需要高性能，所以不走缓存
-func (a *AdminCase) GetViewPrizeList(ctx context.Context) ([]*ViewPrize, error) {
-	list, err := a.prizeRepo.GetAll()
+// GetViewPrizeList 获取某个活动的奖品列表,这个方法用于管理后台使用，因为管理后台不需要高性能，所以不走缓存
+func (a *AdminCase) GetViewPrizeList(ctx context.Context, activityID uint) ([]*ViewPrize, error) {
+	list, err := a.prizeRepo.GetAllByActivity(activityID)
 	if err != nil {
 		log.ErrorContextf(ctx, "prizeCase|GetPrizeList err:%v", err)
 		return nil, fmt.Errorf("prizeCase|GetPrizeList: %v", err)
@@ -61,27 +84,21 @@ func (a *AdminCase) GetViewPrizeList(ctx context.Context) ([]*ViewPrize, error)
 		if prize.SysStatus != constant.PrizeStatusNormal {
 			continue
 		}
-		num, err := a.prizeRepo.GetPrizePoolNum(prize.Id)
+		num, err := a.prizeRepo.GetPrizePoolNum(prize.ActivityId, prize.Id)
 		if err != nil {
 			return nil, fmt.Errorf("prizeCase|GetPrizeList: %v", err)
 		}
-		title := fmt.Sprintf("【%d】%s", num, prize.Title)
-		prizeList = append(prizeList, &ViewPrize{
-			Id:        prize.Id,
-			Title:     title,
-			Img:       prize.Img,
-			PrizeNum:  prize.PrizeNum,
-			LeftNum:   prize.LeftNum,
-			PrizeType: prize.PrizeType,
-		})
+		viewPrize := toViewPrize(prize)
+		viewPrize.Title = fmt.Sprintf("【%d】%s", num, prize.Title)
+		prizeList = append(prizeList, viewPrize)
 
 	}
 	return prizeList, nil
 }
 
-// GetViewPrizeListWithCache 获取奖品列表,优先从缓存获取
-func (a *AdminCase) GetViewPrizeListWithCache(ctx context.Context) ([]*ViewPrize, error) {
-	list, err := a.prizeRepo.GetAllWithCache()
+// GetViewPrizeListWithCache 获取某个活动的奖品列表,优先从缓存获取
+func (a *AdminCase) GetViewPrizeListWithCache(ctx context.Context, activityID uint) ([]*ViewPrize, error) {
+	list, err := a.prizeRepo.GetAllWithCache(activityID)
 	if err != nil {
 		log.ErrorContextf(ctx, "prizeCase|GetPrizeList err:%v", err)
 		return nil, fmt.Errorf("prizeCase|GetPrizeList: %v", err)
@@ -92,12 +109,13 @@ func (a *AdminCase) GetViewPrizeListWithCache(ctx context.Context) ([]*ViewPrize
 			continue
 		}
 		prizeList = append(prizeList, &ViewPrize{
-			Id:        prize.Id,
-			Title:     prize.Title,
-			Img:       prize.Img,
-			PrizeNum:  prize.PrizeNum,
-			LeftNum:   prize.LeftNum,
-			PrizeType: prize.PrizeType,
+			Id:         prize.Id,
+			ActivityId: prize.ActivityId,
+			Title:      prize.Title,
+			Img:        prize.Img,
+			PrizeNum:   prize.PrizeNum,
+			LeftNum:    prize.LeftNum,
+			PrizeType:  prize.PrizeType,
 		})
 	}
 	return prizeList, nil
@@ -110,15 +128,32 @@ func (a *AdminCase) GetPrize(ctx context.Context, id uint) (*ViewPrize, error) {
 		log.ErrorContextf(ctx, "prizeCase|GetPrize:%v", err)
 		return nil, fmt.Errorf("prizeCase|GetPrize:%v", err)
 	}
-	prize := &ViewPrize{
-		Id:        prizeModel.Id,
-		Title:     prizeModel.Title,
-		Img:       prizeModel.Img,
-		PrizeNum:  prizeModel.PrizeNum,
-		LeftNum:   prizeModel.LeftNum,
-		PrizeType: prizeModel.PrizeType,
+	if prizeModel == nil {
+		return nil, nil
+	}
+	return toViewPrize(prizeModel), nil
+}
+
+// toViewPrize 存储层奖品转换为后台展示的奖品
+func toViewPrize(prize *Prize) *ViewPrize {
+	return &ViewPrize{
+		Id:           prize.Id,
+		ActivityId:   prize.ActivityId,
+		Title:        prize.Title,
+		Img:          prize.Img,
+		PrizeNum:     prize.PrizeNum,
+		PrizeCode:    prize.PrizeCode,
+		Weight:       prize.Weight,
+		UserWinMax:   prize.UserWinMax,
+		PrizeTime:    prize.PrizeTime,
+		LeftNum:      prize.LeftNum,
+		PrizeType:    prize.PrizeType,
+		PlanProfile:  prize.PlanProfile,
+		BeginTime:    prize.BeginTime,
+		EndTime:      prize.EndTime,
+		DisplayOrder: prize.DisplayOrder,
+		SysStatus:    prize.SysStatus,
 	}
-	return prize, nil
 }
 
 // AddPrize 新增奖品
@@ -129,18 +164,25 @@ func (a *AdminCase) AddPrize(ctx context.Context, viewPrize *ViewPrize) error {
 		}
 	}()
 	prize := Prize{
+		ActivityId:   viewPrize.ActivityId,
 		Title:        viewPrize.Title,
 		PrizeNum:     viewPrize.PrizeNum,
 		LeftNum:      viewPrize.PrizeNum,
 		PrizeCode:    viewPrize.PrizeCode,
+		Weight:       viewPrize.Weight,
+		UserWinMax:   viewPrize.UserWinMax,
 		PrizeTime:    viewPrize.PrizeTime,
 		Img:          viewPrize.Img,
 		DisplayOrder: viewPrize.DisplayOrder,
 		PrizeType:    viewPrize.PrizeType,
 		BeginTime:    viewPrize.BeginTime,
 		EndTime:      viewPrize.EndTime,
-		PrizePlan:    viewPrize.PrizePlan,
+		PlanProfile:  viewPrize.PlanProfile,
 		SysStatus:    1,
+		SysIp:        sysIPFromContext(ctx),
+	}
+	if err := a.ValidatePrizes(ctx, []*Prize{&prize}); err != nil {
+		return err
 	}
 	// 因为奖品是全量string缓存，新增奖品之后缓存有变动，所有要更新
 	if err := a.prizeRepo.Create(&prize); err != nil {
@@ -160,21 +202,32 @@ func (a *AdminCase) AddPrizeList(ctx context.Context, viewPrizeList []*ViewPrize
 	prizeList := make([]Prize, 0)
 	for _, viewPrize := range viewPrizeList {
 		prize := Prize{
+			ActivityId:   viewPrize.ActivityId,
 			Title:        viewPrize.Title,
 			PrizeNum:     viewPrize.PrizeNum,
 			LeftNum:      viewPrize.PrizeNum,
 			PrizeCode:    viewPrize.PrizeCode,
+			Weight:       viewPrize.Weight,
+			UserWinMax:   viewPrize.UserWinMax,
 			PrizeTime:    viewPrize.PrizeTime,
 			Img:          viewPrize.Img,
 			DisplayOrder: viewPrize.DisplayOrder,
 			PrizeType:    viewPrize.PrizeType,
 			BeginTime:    viewPrize.BeginTime,
 			EndTime:      viewPrize.EndTime,
-			PrizePlan:    viewPrize.PrizePlan,
+			PlanProfile:  viewPrize.PlanProfile,
 			SysStatus:    1,
+			SysIp:        sysIPFromContext(ctx),
 		}
 		prizeList = append(prizeList, prize)
 	}
+	checkList := make([]*Prize, 0, len(prizeList))
+	for i := range prizeList {
+		checkList = append(checkList, &prizeList[i])
+	}
+	if err := a.ValidatePrizes(ctx, checkList); err != nil {
+		return err
+	}
 	// 因为奖品是全量string缓存，新增奖品之后缓存有变动，所有要更新
 	if err := a.prizeRepo.CreateInBatches(prizeList); err != nil {
 		log.ErrorContextf(ctx, "adminCase|AddPrizeList err:%v", err)
@@ -183,8 +236,9 @@ func (a *AdminCase) AddPrizeList(ctx context.Context, viewPrizeList []*ViewPrize
 	return nil
 }
 
+// ClearPrize 清空奖品，奖品做软删除并记录操作人IP
 func (a *AdminCase) ClearPrize(ctx context.Context) error {
-	if err := a.prizeRepo.DeleteAll(); err != nil {
+	if err := a.prizeRepo.DeleteAll(sysIPFromContext(ctx)); err != nil {
 		log.ErrorContextf(ctx, "adminCase|ClearPrize err:%v", err)
 		return fmt.Errorf("adminCase|ClearPrize:%v", err)
 	}
@@ -202,20 +256,27 @@ func (a *AdminCase) ClearCoupon(ctx context.Context) error {
 // AddPrizeWithPool 带奖品池的新增奖品实现
 func (a *AdminCase) AddPrizeWithPool(ctx context.Context, viewPrize *ViewPrize) error {
 	prize := Prize{
+		ActivityId:   viewPrize.ActivityId,
 		Title:        viewPrize.Title,
 		PrizeNum:     viewPrize.PrizeNum,
 		LeftNum:      viewPrize.PrizeNum,
 		PrizeCode:    viewPrize.PrizeCode,
+		Weight:       viewPrize.Weight,
+		UserWinMax:   viewPrize.UserWinMax,
 		PrizeTime:    viewPrize.PrizeTime,
 		Img:          viewPrize.Img,
 		DisplayOrder: viewPrize.DisplayOrder,
 		PrizeType:    viewPrize.PrizeType,
 		BeginTime:    viewPrize.BeginTime,
 		EndTime:      viewPrize.EndTime,
-		PrizePlan:    viewPrize.PrizePlan,
+		PlanProfile:  viewPrize.PlanProfile,
 		SysStatus:    1,
+		SysIp:        sysIPFromContext(ctx),
 		//SysUpdated:   time.Now(),
 	}
+	if err := a.ValidatePrizes(ctx, []*Prize{&prize}); err != nil {
+		return err
+	}
 	// 因为奖品是全量string缓存，新增奖品之后缓存有变动，所有要更新
 	if err := a.prizeRepo.CreateWithCache(&prize); err != nil {
 		log.Errorf("adminCase|AddPrize err:%v", err)
@@ -231,20 +292,27 @@ func (a *AdminCase) AddPrizeWithPool(ctx context.Context, viewPrize *ViewPrize)
 // AddPrizeWithCache 带缓存优化的新增奖品
 func (a *AdminCase) AddPrizeWithCache(ctx context.Context, viewPrize *ViewPrize) error {
 	prize := Prize{
+		ActivityId:   viewPrize.ActivityId,
 		Title:        viewPrize.Title,
 		PrizeNum:     viewPrize.PrizeNum,
 		LeftNum:      viewPrize.PrizeNum,
 		PrizeCode:    viewPrize.PrizeCode,
+		Weight:       viewPrize.Weight,
+		UserWinMax:   viewPrize.UserWinMax,
 		PrizeTime:    viewPrize.PrizeTime,
 		Img:          viewPrize.Img,
 		DisplayOrder: viewPrize.DisplayOrder,
 		PrizeType:    viewPrize.PrizeType,
 		BeginTime:    viewPrize.BeginTime,
 		EndTime:      viewPrize.EndTime,
-		PrizePlan:    viewPrize.PrizePlan,
+		PlanProfile:  viewPrize.PlanProfile,
 		SysStatus:    1,
+		SysIp:        sysIPFromContext(ctx),
 		//SysUpdated:   time.Now(),
 	}
+	if err := a.ValidatePrizes(ctx, []*Prize{&prize}); err != nil {
+		return err
+	}
 	// 因为奖品是全量string缓存，新增奖品之后缓存有变动，所有要更新
 	if err := a.prizeRepo.CreateWithCache(&prize); err != nil {
 		log.ErrorContextf(ctx, "adminCase|AddPrize err:%v", err)
@@ -275,18 +343,22 @@ func (a *AdminCase) UpdatePrize(ctx context.Context, viewPrize *ViewPrize) error
 		return fmt.Errorf("adminCase|UpdatePrize invalid prize")
 	}
 	prize := Prize{
+		Id:           viewPrize.Id,
 		Title:        viewPrize.Title,
 		PrizeNum:     viewPrize.PrizeNum,
 		LeftNum:      viewPrize.LeftNum,
 		PrizeCode:    viewPrize.PrizeCode,
+		Weight:       viewPrize.Weight,
+		UserWinMax:   viewPrize.UserWinMax,
 		PrizeTime:    viewPrize.PrizeTime,
 		Img:          viewPrize.Img,
 		DisplayOrder: viewPrize.DisplayOrder,
 		PrizeType:    viewPrize.PrizeType,
 		BeginTime:    viewPrize.BeginTime,
 		EndTime:      viewPrize.EndTime,
-		PrizePlan:    viewPrize.PrizePlan,
+		PlanProfile:  viewPrize.PlanProfile,
 		SysStatus:    viewPrize.SysStatus,
+		SysIp:        sysIPFromContext(ctx),
 	}
 	oldPrize, err := a.prizeRepo.Get(viewPrize.Id)
 	if err != nil {
@@ -297,6 +369,11 @@ func (a *AdminCase) UpdatePrize(ctx context.Context, viewPrize *ViewPrize) error
 		log.ErrorContextf(ctx, "adminCase|UpdatePrize prize not exists with id: %d", viewPrize.Id)
 		return fmt.Errorf("adminCase|UpdatePrize prize not exists with id: %d", viewPrize.Id)
 	}
+	// 奖品所属活动不允许修改
+	prize.ActivityId = oldPrize.ActivityId
+	if err = a.ValidatePrizes(ctx, []*Prize{&prize}); err != nil {
+		return err
+	}
 	// 奖品数量发生了改变
 	if prize.PrizeNum != oldPrize.PrizeNum {
 		if prize.PrizeNum <= 0 {
@@ -306,8 +383,8 @@ func (a *AdminCase) UpdatePrize(ctx context.Context, viewPrize *ViewPrize) error
 			prize.LeftNum = 0
 		}
 	}
-	if a.prizeRepo.Update(&prize, "title", "prize_num", "left_num", "prize_code", "prize_time", "img",
-		"display_order", "prize_type", "begin_time", "end_time", "prize_plan"); err != nil {
+	if err = a.prizeRepo.UpdateWithCache(&prize, "title", "prize_num", "left_num", "prize_code", "weight", "user_win_max", "prize_time", "img",
+		"display_order", "prize_type", "begin_time", "end_time", "plan_profile", "sys_ip"); err != nil {
 		log.Errorf("adminCase|UpdatePrize Update prize err:%v", err)
 		return fmt.Errorf("adminCase|UpdatePrize Update prize:%v", err)
 	}
@@ -320,18 +397,22 @@ func (a *AdminCase) UpdatePrizeWithPool(ctx context.Context, viewPrize *ViewPriz
 		return fmt.Errorf("adminCase|UpdatePrize invalid prize")
 	}
 	prize := Prize{
+		Id:           viewPrize.Id,
 		Title:        viewPrize.Title,
 		PrizeNum:     viewPrize.PrizeNum,
 		LeftNum:      viewPrize.LeftNum,
 		PrizeCode:    viewPrize.PrizeCode,
+		Weight:       viewPrize.Weight,
+		UserWinMax:   viewPrize.UserWinMax,
 		PrizeTime:    viewPrize.PrizeTime,
 		Img:          viewPrize.Img,
 		DisplayOrder: viewPrize.DisplayOrder,
 		PrizeType:    viewPrize.PrizeType,
 		BeginTime:    viewPrize.BeginTime,
 		EndTime:      viewPrize.EndTime,
-		PrizePlan:    viewPrize.PrizePlan,
+		PlanProfile:  viewPrize.PlanProfile,
 		SysStatus:    viewPrize.SysStatus,
+		SysIp:        sysIPFromContext(ctx),
 	}
 	oldPrize, err := a.prizeRepo.Get(viewPrize.Id)
 	if err != nil {
@@ -342,6 +423,11 @@ func (a *AdminCase) UpdatePrizeWithPool(ctx context.Context, viewPrize *ViewPriz
 		log.Errorf("adminCase|UpdatePrize prize not exists with id: %d", viewPrize.Id)
 		return fmt.Errorf("adminCase|UpdatePrize prize not exists with id: %d", viewPrize.Id)
 	}
+	// 奖品所属活动不允许修改
+	prize.ActivityId = oldPrize.ActivityId
+	if err = a.ValidatePrizes(ctx, []*Prize{&prize}); err != nil {
+		return err
+	}
 	// 奖品数量发生了改变
 	if prize.PrizeNum != oldPrize.PrizeNum {
 		if prize.PrizeNum <= 0 {
@@ -361,14 +447,36 @@ func (a *AdminCase) UpdatePrizeWithPool(ctx context.Context, viewPrize *ViewPriz
 			return fmt.Errorf("adminCase|UpdatePrize ResetPrizePlan prize err:%v", err)
 		}
 	}
-	if a.prizeRepo.Update(&prize, "title", "prize_num", "left_num", "prize_code", "prize_time", "img",
-		"display_order", "prize_type", "begin_time", "end_time", "prize_plan"); err != nil {
+	if err = a.prizeRepo.UpdateWithCache(&prize, "title", "prize_num", "left_num", "prize_code", "weight", "user_win_max", "prize_time", "img",
+		"display_order", "prize_type", "begin_time", "end_time", "plan_profile", "sys_ip"); err != nil {
 		log.Errorf("adminCase|UpdatePrize Update prize err:%v", err)
 		return fmt.Errorf("adminCase|UpdatePrize Update prize:%v", err)
 	}
 	return nil
 }
 
+// DeletePrize 软删除奖品，同时清空奖品的发放计划和奖品池
+func (a *AdminCase) DeletePrize(ctx context.Context, id uint) error {
+	prize, err := a.prizeRepo.Get(id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|DeletePrize get prize err:%v", err)
+		return fmt.Errorf("adminCase|DeletePrize:%v", err)
+	}
+	if prize == nil {
+		return fmt.Errorf("adminCase|DeletePrize prize not exists with id: %d", id)
+	}
+	prize.SysStatus = constant.PrizeStatusDelete
+	prize.SysIp = sysIPFromContext(ctx)
+	if err = a.prizeRepo.UpdateWithCache(prize, "sys_status", "sys_ip"); err != nil {
+		log.ErrorContextf(ctx, "adminCase|DeletePrize update prize err:%v", err)
+		return fmt.Errorf("adminCase|DeletePrize:%v", err)
+	}
+	if err = a.clearPrizePlan(ctx, prize); err != nil {
+		return fmt.Errorf("adminCase|DeletePrize:%v", err)
+	}
+	return nil
+}
+
 // GetCouponList 获取优惠券列表,库存优惠券数量和缓存优惠券数量，当这两个数量不一致的时候，需要重置缓存优惠券数量
 func (a *AdminCase) GetCouponList(ctx context.Context, prizeID uint) ([]*ViewCouponInfo, int64, int64, error) {
 	var (
@@ -379,12 +487,17 @@ func (a *AdminCase) GetCouponList(ctx context.Context, prizeID uint) ([]*ViewCou
 		cacheNum       int64
 	)
 	if prizeID > 0 {
+		prize, err := a.prizeRepo.Get(prizeID)
+		if err != nil || prize == nil {
+			log.ErrorContextf(ctx, "AdminCase|GetCouponList|prizeRepo.Get|%v", err)
+			return nil, 0, 0, fmt.Errorf("adminCase|GetCouponList invalid prize_id:%d", prizeID)
+		}
 		couponList, err = a.couponRepo.GetCouponListByPrizeID(prizeID)
 		if err != nil {
 			log.ErrorContextf(ctx, "AdminCase|GetCouponListByPrizeID|%v", err)
 			return nil, 0, 0, fmt.Errorf("adminCase|GetCouponList invalid prize_id:%d", prizeID)
 		}
-		dbNum, cacheNum, err = a.couponRepo.GetCacheCouponNum(prizeID)
+		dbNum, cacheNum, err = a.couponRepo.GetCacheCouponNum(prize.ActivityId, prizeID)
 		if err != nil {
 			log.ErrorContextf(ctx, "AdminCase|GetCacheCouponNum|%v", err)
 			return nil, 0, 0, fmt.Errorf("adminCase|GetCouponList invalid prize_id:%d", prizeID)
@@ -398,9 +511,10 @@ func (a *AdminCase) GetCouponList(ctx context.Context, prizeID uint) ([]*ViewCou
 	}
 	for _, coupon := range couponList {
 		viewCouponList = append(viewCouponList, &ViewCouponInfo{
-			Id:      coupon.Id,
-			PrizeId: coupon.PrizeId,
-			Code:    coupon.Code,
+			Id:         coupon.Id,
+			ActivityId: coupon.ActivityId,
+			PrizeId:    coupon.PrizeId,
+			Code:       coupon.Code,
 			//SysCreated: coupon.SysCreated,
 			//SysUpdated: coupon.SysUpdated,
 			SysStatus: coupon.SysStatus,
@@ -409,6 +523,80 @@ func (a *AdminCase) GetCouponList(ctx context.Context, prizeID uint) ([]*ViewCou
 	return viewCouponList, dbNum, cacheNum, nil
 }
 
+// GetCoupon 获取某个优惠券
+func (a *AdminCase) GetCoupon(ctx context.Context, id uint) (*ViewCouponInfo, error) {
+	coupon, err := a.couponRepo.Get(id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|GetCoupon:%v", err)
+		return nil, fmt.Errorf("adminCase|GetCoupon:%v", err)
+	}
+	if coupon == nil {
+		return nil, nil
+	}
+	viewCoupon := &ViewCouponInfo{
+		Id:         coupon.Id,
+		ActivityId: coupon.ActivityId,
+		PrizeId:    coupon.PrizeId,
+		Code:       coupon.Code,
+		SysStatus:  coupon.SysStatus,
+	}
+	if coupon.SysCreated != nil {
+		viewCoupon.SysCreated = *coupon.SysCreated
+	}
+	if coupon.SysUpdated != nil {
+		viewCoupon.SysUpdated = *coupon.SysUpdated
+	}
+	return viewCoupon, nil
+}
+
+// UpdateCoupon 修改优惠券编码和状态，修改后按db重置该奖品的优惠券缓存
+func (a *AdminCase) UpdateCoupon(ctx context.Context, viewCoupon *ViewCouponInfo) error {
+	if viewCoupon == nil || viewCoupon.Id <= 0 {
+		return fmt.Errorf("adminCase|UpdateCoupon invalid coupon")
+	}
+	coupon, err := a.couponRepo.Get(viewCoupon.Id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|UpdateCoupon get coupon err:%v", err)
+		return fmt.Errorf("adminCase|UpdateCoupon:%v", err)
+	}
+	if coupon == nil {
+		return fmt.Errorf("adminCase|UpdateCoupon coupon not exists with id: %d", viewCoupon.Id)
+	}
+	coupon.Code = strings.TrimSpace(viewCoupon.Code)
+	coupon.SysStatus = viewCoupon.SysStatus
+	if err = a.couponRepo.Update(coupon, "code", "sys_status"); err != nil {
+		log.ErrorContextf(ctx, "adminCase|UpdateCoupon update coupon err:%v", err)
+		return fmt.Errorf("adminCase|UpdateCoupon:%v", err)
+	}
+	if _, _, err = a.couponRepo.ReSetCacheCoupon(coupon.ActivityId, coupon.PrizeId); err != nil {
+		log.ErrorContextf(ctx, "adminCase|UpdateCoupon ReSetCacheCoupon err:%v", err)
+		return fmt.Errorf("adminCase|UpdateCoupon:%v", err)
+	}
+	return nil
+}
+
+// DeleteCoupon 作废优惠券，作废后按db重置该奖品的优惠券缓存
+func (a *AdminCase) DeleteCoupon(ctx context.Context, id uint) error {
+	coupon, err := a.couponRepo.Get(id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|DeleteCoupon get coupon err:%v", err)
+		return fmt.Errorf("adminCase|DeleteCoupon:%v", err)
+	}
+	if coupon == nil {
+		return fmt.Errorf("adminCase|DeleteCoupon coupon not exists with id: %d", id)
+	}
+	coupon.SysStatus = constant.CouponStatusDelete
+	if err = a.couponRepo.Update(coupon, "sys_status"); err != nil {
+		log.ErrorContextf(ctx, "adminCase|DeleteCoupon update coupon err:%v", err)
+		return fmt.Errorf("adminCase|DeleteCoupon:%v", err)
+	}
+	if _, _, err = a.couponRepo.ReSetCacheCoupon(coupon.ActivityId, coupon.PrizeId); err != nil {
+		log.ErrorContextf(ctx, "adminCase|DeleteCoupon ReSetCacheCoupon err:%v", err)
+		return fmt.Errorf("adminCase|DeleteCoupon:%v", err)
+	}
+	return nil
+}
+
 // ImportCoupon 导入优惠券
 func (a *AdminCase) ImportCoupon(ctx context.Context, prizeID uint, codes string) (int, int, error) {
 	if prizeID <= 0 {
@@ -430,8 +618,9 @@ func (a *AdminCase) ImportCoupon(ctx context.Context, prizeID uint, codes string
 	for _, code := range codeList {
 		code = strings.TrimSpace(code)
 		coupon := &Coupon{
-			PrizeId: prizeID,
-			Code:    code,
+			ActivityId: prize.ActivityId,
+			PrizeId:    prizeID,
+			Code:       code,
 			//SysCreated: time.Now(),
 			SysStatus: 1,
 		}
@@ -449,12 +638,13 @@ func (a *AdminCase) ImportCouponWithCache(ctx context.Context, prizeID uint, cod
 	if prizeID <= 0 {
 		return 0, 0, fmt.Errorf("adminCase|ImportCoupon invalid prizeID:%d", prizeID)
 	}
-	prize, err := a.prizeRepo.GetWithCache(prizeID)
+	// 优惠券导入前并不知道奖品所属活动，直接从db获取奖品
+	prize, err := a.prizeRepo.Get(prizeID)
 	if err != nil {
 		return 0, 0, fmt.Errorf("adminCase|ImportCoupon invalid prizeID:%d", prizeID)
 	}
 	if prize == nil || prize.PrizeType != constant.PrizeTypeCouponDiff {
-		log.InfoContextf(ctx, "adminCase|ImportCoupon invalid prize type:%d with prize_id %d", prize.PrizeType, prizeID)
+		log.InfoContextf(ctx, "adminCase|ImportCoupon invalid prize with prize_id %d", prizeID)
 		return 0, 0, fmt.Errorf("adminCase|ImportCoupon prize_type is not coupon with prize_id %d", prizeID)
 	}
 	var (
@@ -465,8 +655,9 @@ func (a *AdminCase) ImportCouponWithCache(ctx context.Context, prizeID uint, cod
 	for _, code := range codeList {
 		code = strings.TrimSpace(code)
 		coupon := &Coupon{
-			PrizeId: prizeID,
-			Code:    code,
+			ActivityId: prize.ActivityId,
+			PrizeId:    prizeID,
+			Code:       code,
 			//SysCreated: time.Now(),
 			SysStatus: 1,
 		}
@@ -474,7 +665,7 @@ func (a *AdminCase) ImportCouponWithCache(ctx context.Context, prizeID uint, cod
 			failNum++
 		} else {
 			// db导入成功之后，再导入缓存
-			ok, err := a.couponRepo.ImportCacheCoupon(prizeID, code)
+			ok, err := a.couponRepo.ImportCacheCoupon(prize.ActivityId, prizeID, code)
 			if err != nil {
 				return 0, 0, fmt.Errorf("adminCase|ImportCoupon prize_type is not coupon with prize_id %d", prizeID)
 			}
@@ -493,7 +684,12 @@ func (a *AdminCase) ReCacheCoupon(ctx context.Context, prizeID uint) (int64, int
 	if prizeID <= 0 {
 		return 0, 0, fmt.Errorf("adminCase|ReCacheCoupon invalid prizeID:%d", prizeID)
 	}
-	successNum, failureNum, err := a.couponRepo.ReSetCacheCoupon(prizeID)
+	prize, err := a.prizeRepo.Get(prizeID)
+	if err != nil || prize == nil {
+		log.ErrorContextf(ctx, "AdminCase|ReCacheCoupon|prizeRepo.Get|%v", err)
+		return 0, 0, fmt.Errorf("adminCase|ReCacheCoupon invalid prizeID:%d", prizeID)
+	}
+	successNum, failureNum, err := a.couponRepo.ReSetCacheCoupon(prize.ActivityId, prizeID)
 	if err != nil {
 		log.ErrorContextf(ctx, "AdminCase|ReSetCacheCoupon|%v", err)
 		return 0, 0, fmt.Errorf("adminCase|ReCacheCoupon:%v", err)
@@ -513,73 +709,45 @@ func (a *AdminCase) ResetPrizePlan(ctx context.Context, prize *Prize) error {
 		prize.EndTime.Before(now) || // 已经结束
 		prize.LeftNum <= 0 ||
 		prize.PrizeNum <= 0 {
-		if prize.PrizePlan != "" {
-			// 在重置的时候，如果发现原来奖品的发奖计划不为空，需要清空发奖计划
-			a.clearPrizePlan(ctx, prize)
-		}
+		// 在重置的时候，如果原来奖品有发奖计划，需要清空发奖计划
+		a.clearPrizePlan(ctx, prize)
 		//log.InfoContextf(ctx, "prize can not be given out")
 		return nil
 	}
 	// PrizeTime, 发奖周期，这类奖品需要在多少天内发完
 	prizePlanDays := int(prize.PrizeTime)
 	if prizePlanDays <= 0 {
-		a.setPrizePool(ctx, prize.Id, prize.LeftNum)
+		a.setPrizePool(ctx, prize.ActivityId, prize.Id, prize.LeftNum)
 		//log.InfoContextf(ctx, "adminCase|ResetGiftPrizePlan|prizePlanDays <= 0")
 		return nil
 	}
+	profile, ok := a.lotteryConf.Limits().GetPlanProfile(prize.PlanProfile)
+	if !ok {
+		log.ErrorContextf(ctx, "limitCase|ResetPrizePlan|plan_profile %s not configured", prize.PlanProfile)
+		return fmt.Errorf("limitCase|ResetPrizePlan plan_profile %s not configured", prize.PlanProfile)
+	}
 	// 对于设置发奖周期的奖品重新计算出来合适的奖品发放节奏
 	// 奖品池的剩余数先设置为空
-	a.setPrizePool(ctx, prize.Id, 0)
-	// 发奖周期中的每天的发奖概率一样，一天内24小时，每个小时的概率是不一样的，每个小时内的每一分钟的概率一样
-	prizeNum := prize.PrizeNum
-	// 先计算每天至少发多少奖
-	avgPrizeNum := prizeNum / prizePlanDays
-
-	// 每天可以分配到的奖品数量
-	dayPrizeNumMap := make(map[int]int)
-	// 发奖周期大雨1天，并且平均每天发的奖品书大于等于1
-	if prizePlanDays > 0 && avgPrizeNum >= 1 {
-		for day := 0; day < prizePlanDays; day++ {
-			dayPrizeNumMap[day] = avgPrizeNum
-		}
-	}
-	// 剩下的奖品一个一个的随机分配到任意哪天
-	prizeNum -= prizePlanDays * avgPrizeNum
-	for prizeNum > 0 {
-		prizeNum--
-		day := utils.Random(prizePlanDays)
-		dayPrizeNumMap[day] += 1
-	}
-	// 发奖map：map[int]map[int][60]int
-	//map[天]map[小时][60]奖品数量：后一个map表示value是一个60大小的数组，表示一个小时中每分钟要发的奖品数量
-	prizePlanMap := make(map[int]map[int][60]int)
-	//log.Infof("prize_id = %d\ndayPrizeNumMap = %+v", prize.Id, dayPrizeNumMap)
-	for day, num := range dayPrizeNumMap {
-		//计算一天的发奖计划
-		dayPrizePlan := a.prizePlanOneDay(num)
-		prizePlanMap[day] = dayPrizePlan
-	}
-	//log.Infof("prize_id = %d\nprizePlanMap = %+v", prize.Id, prizePlanMap)
-	// 格式化 dayPrizePlan数据，序列化成为一个[时间:数量]二元组的数组
-	planList, err := a.formatPrizePlan(now, prizePlanDays, prizePlanMap)
-	if err != nil {
-		log.ErrorContextf(ctx, "limitCase|ResetPrizePlan|formatPrizePlan err:", err)
-		return fmt.Errorf("limitCase|ResetGiftPrizePlan:%v", err)
-	}
-	bytes, err := json.Marshal(planList)
-	if err != nil {
-		log.ErrorContextf(ctx, "limitCase|ResetPrizePlan|planList json marshal error=", err)
-		return fmt.Errorf("limitCase|ResetGiftPrizePlan:%v", err)
+	a.setPrizePool(ctx, prize.ActivityId, prize.Id, 0)
+	// 按分布曲线把奖品分配到发奖周期中的每一天、每小时、每分钟，写入发奖计划表
+	planList := newPrizePlan(now.In(a.lotteryConf.Limits().Location), prizePlanDays, prize.PrizeNum, profile)
+	for _, plan := range planList {
+		plan.PrizeId = prize.Id
+		plan.ActivityId = prize.ActivityId
+	}
+	if err := a.prizePlanRepo.Replace(ctx, prize.Id, planList); err != nil {
+		log.ErrorContextf(ctx, "limitCase|ResetPrizePlan|prizePlanRepo.Replace err:%v", err)
+		return fmt.Errorf("limitCase|ResetPrizePlan:%v", err)
 	}
-	// 保存奖品的分布计划数据
+	// 保存奖品的发奖计划周期
 	info := &Prize{
 		Id:         prize.Id,
+		ActivityId: prize.ActivityId,
 		LeftNum:    prize.PrizeNum,
-		PrizePlan:  string(bytes),
 		PrizeBegin: now,
 		PrizeEnd:   now.Add(time.Second * time.Duration(86400*prizePlanDays)),
 	}
-	err = a.prizeRepo.UpdateWithCache(info, "prize_plan", "prize_begin", "prize_end")
+	err := a.prizeRepo.UpdateWithCache(info, "prize_begin", "prize_end")
 	if err != nil {
 		log.ErrorContextf(ctx, "limitCase|ResetPrizePlan|prizeRepo.Update err:", err)
 		return fmt.Errorf("limitCase|ResetPrizePlan:%v", err)
@@ -587,27 +755,57 @@ func (a *AdminCase) ResetPrizePlan(ctx context.Context, prize *Prize) error {
 	return nil
 }
 
+// PreviewPrizePlan 按奖品当前的数量和发奖周期计算发奖计划，不保存，profileName不为空时使用指定的分布曲线
+func (a *AdminCase) PreviewPrizePlan(ctx context.Context, id uint, profileName string) (*PrizePlanPreview, error) {
+	prize, err := a.prizeRepo.Get(id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|PreviewPrizePlan|prizeRepo.Get err:%v", err)
+		return nil, fmt.Errorf("adminCase|PreviewPrizePlan:%v", err)
+	}
+	if prize == nil {
+		return nil, nil
+	}
+	if profileName != "" {
+		prize.PlanProfile = profileName
+	}
+	profile, ok := a.lotteryConf.Limits().GetPlanProfile(prize.PlanProfile)
+	if !ok {
+		return nil, &PrizeValidationError{Issues: []*PrizeIssue{
+			newPrizeIssue(prize, "plan_profile", constant.ErrPlanProfileInvalid, ""),
+		}}
+	}
+	now := time.Now()
+	preview := &PrizePlanPreview{
+		PrizeId:    prize.Id,
+		Profile:    prize.PlanProfile,
+		PrizeNum:   prize.PrizeNum,
+		PrizeBegin: now,
+		PrizeEnd:   now.Add(time.Duration(prize.PrizeTime) * 24 * time.Hour),
+		PlanList:   make([]*TimePrizeInfo, 0),
+	}
+	if prize.PrizeTime > 0 && prize.PrizeNum > 0 {
+		preview.PlanList = toTimePrizeInfo(newPrizePlan(now.In(a.lotteryConf.Limits().Location), int(prize.PrizeTime),
+			prize.PrizeNum, profile))
+	}
+	return preview, nil
+}
+
 // clearPrizeData 清空奖品的发放计划
 func (a *AdminCase) clearPrizePlan(ctx context.Context, prize *Prize) error {
-	info := &Prize{
-		Id:        prize.Id,
-		PrizePlan: "",
-	}
-	err := a.prizeRepo.UpdateWithCache(info, "prize_plan")
-	if err != nil {
-		log.ErrorContextf(ctx, "limitCase|clearPrizePlan|prizeRepo.Update err", err)
+	if err := a.prizePlanRepo.Clear(ctx, prize.Id); err != nil {
+		log.ErrorContextf(ctx, "limitCase|clearPrizePlan|prizePlanRepo.Clear err:%v", err)
 		return fmt.Errorf("limitCase|clearPrizePlan:%v", err)
 	}
 	//奖品池也设为0
-	if err = a.setPrizePool(ctx, prize.Id, 0); err != nil {
+	if err := a.setPrizePool(ctx, prize.ActivityId, prize.Id, 0); err != nil {
 		return fmt.Errorf("limitCase|clearPrizePlan:%v", err)
 	}
 	return nil
 }
 
 // setGiftPool 设置奖品池中某种奖品的数量
-func (a *AdminCase) setPrizePool(ctx context.Context, id uint, num int) error {
-	key := constant.PrizePoolCacheKey
+func (a *AdminCase) setPrizePool(ctx context.Context, activityID, id uint, num int) error {
+	key := constant.ActivityCacheKey(activityID, constant.PrizePoolCacheKey)
 	if err := a.prizeRepo.SetPrizePoolNum(key, id, num); err != nil {
 		log.ErrorContextf(ctx, "AdminCase|setPrizePool|%v", err)
 		return fmt.Errorf("AdminCase|setPrizePool|%v", err)
@@ -615,221 +813,132 @@ func (a *AdminCase) setPrizePool(ctx context.Context, id uint, num int) error {
 	return nil
 }
 
-// prizePlanOneDay 计算一天内具体到每小时每分钟应该发出的奖品，map[int][60]int： map[hour][minute]num
-func (a *AdminCase) prizePlanOneDay(num int) map[int][60]int {
-	resultMap := make(map[int][60]int)
-	hourPrizeNumList := [24]int{} // 长度为24的数组表示1天中每个小时对应的奖品数
-	// 计算一天中的24个小时，每个小时应该发出的奖品数，为什么是100，100表示每一天的权重百分比
-	if num > 100 {
-		for _, h := range DayPrizeWeights {
-			hourPrizeNumList[h]++
-		}
-		for h := 0; h < 24; h++ {
-			d := hourPrizeNumList[h]
-			n := num * d / 100 // d / 100 每个小时所占的奖品数量概率
-			hourPrizeNumList[h] = n
-			num -= n
-		}
-	}
-	//log.Infof("num = %d", num)
-	for num > 0 {
-		num--
-		// 随机将这个奖品分配到某一个小时上
-		hourIndex := utils.Random(100)
-		//log.Infof("hourIndex = %d", hourIndex)
-		h := DayPrizeWeights[hourIndex]
-		hourPrizeNumList[h]++
-	}
-	//log.Infof("hourPrizeNumList = %v", hourPrizeNumList)
-	// 将每个小时内的奖品数量分配到60分钟
-	for h, hourPrizenum := range hourPrizeNumList {
-		if hourPrizenum <= 0 {
-			continue
-		}
-		minutePrizeNumList := [60]int{}
-		if hourPrizenum >= 60 {
-			avgMinutePrizeNum := hourPrizenum / 60
-			for i := 0; i < 60; i++ {
-				minutePrizeNumList[i] = avgMinutePrizeNum
-			}
-			hourPrizenum -= avgMinutePrizeNum * 60
-		}
-		for hourPrizenum > 0 {
-			hourPrizenum--
-			// 随机将这个奖品分配到某一分钟上
-			m := utils.Random(60)
-			//log.Infof("minuteIndex = %d", m)
-			minutePrizeNumList[m]++
-		}
-		//log.Infof("minutePrizeNumList = %v", minutePrizeNumList)
-		resultMap[h] = minutePrizeNumList
-	}
-	//log.Infof("resultMap=%v", resultMap)
-	//log.Infof("-----------------------------------------------------------")
-	return resultMap
-}
-
-// 将prizeData格式化成具体到一个时间（分钟）的奖品数量
-// 结构为： [day][hour][minute]num
-// result: [][时间, 数量]
-func (a *AdminCase) formatPrizePlan(now time.Time, prizePlanDays int, prizePlan map[int]map[int][60]int) ([]*TimePrizeInfo, error) {
-	result := make([]*TimePrizeInfo, 0)
-	nowHour := now.Hour()
-	for i := 0; i < prizePlanDays; i++ {
-		dayPrizePlanMap, ok := prizePlan[i]
-		if !ok {
-			continue
-		}
-		dayTimeStamp := int(now.Unix()) + i*86400 // dayTimeStamp 为发奖周期中的每一天对应当前时间的时刻
-		for h := 0; h < 24; h++ {
-			hourPrizePlanMap, ok := dayPrizePlanMap[(h+nowHour)%24]
-			if !ok {
-				continue
-			}
-			hourTimeStamp := dayTimeStamp + h*3600 // hourTimeStamp 为发奖周期中的每一天中每个小时对应的时刻
-			for m := 0; m < 60; m++ {
-				num := hourPrizePlanMap[m]
-				if num <= 0 {
-					continue
-				}
-				// 找到特定一个时间的计划数据
-				minuteTimeStamp := hourTimeStamp + m*60 // minuteTimeStamp 为发奖周期中的每一分钟对应的时刻
-				result = append(result, &TimePrizeInfo{
-					Time: utils.FormatFromUnixTime(int64(minuteTimeStamp)),
-					Num:  num,
-				})
-			}
-		}
-	}
-	return result, nil
-}
-
-// ResetAllPrizePlan 重置所有奖品的发奖计划
-func (a *AdminCase) ResetAllPrizePlan() {
+// ResetAllPrizePlan 重置所有奖品的发奖计划，有奖品重置失败时返回最后一个错误
+func (a *AdminCase) ResetAllPrizePlan() error {
 	//log.Infof("Resetting all prizes!!!!!")
 	prizeList, err := a.GetPrizeList(context.Background())
 	if err != nil {
 		log.Errorf("ResetAllPrizePlan err:%v", err)
+		return fmt.Errorf("ResetAllPrizePlan|GetPrizeList:%v", err)
+	}
+	planned, err := a.prizePlanRepo.GetPlannedPrizeIds()
+	if err != nil {
+		log.Errorf("ResetAllPrizePlan err:%v", err)
+		return fmt.Errorf("ResetAllPrizePlan|GetPlannedPrizeIds:%v", err)
 	}
 	now := time.Now()
+	var resetErr error
 	for _, prize := range prizeList {
-		if prize.PrizeTime > 0 && (prize.PrizePlan == "" || prize.PrizeEnd.Before(now)) {
+		if prize.PrizeTime > 0 && (!planned[prize.Id] || prize.PrizeEnd.Before(now)) {
 			// ResetPrizePlan只会更新db的数据
 			if err = a.ResetPrizePlan(context.Background(), prize); err != nil {
 				log.Errorf("ResetAllPrizePlan err:%v", err)
+				resetErr = fmt.Errorf("ResetAllPrizePlan|ResetPrizePlan prize_id=%d:%v", prize.Id, err)
 			}
 			// 通过读取缓存将db的数据同步到缓存中
-			_, err = a.GetPrizeListWithCache(context.Background())
+			_, err = a.GetPrizeListWithCache(context.Background(), prize.ActivityId)
 			if err != nil {
 				log.Errorf("ResetAllPrizePlan err:%v", err)
 			}
 		}
 	}
+	return resetErr
 }
 
-func (a *AdminCase) FillAllPrizePool() {
+func (a *AdminCase) FillAllPrizePool() error {
 	log.Infof("FillAllPrizePool!!!!")
 	totalNum, err := a.fillPrizePool()
 	if err != nil {
 		log.Errorf("FillAllPrizePool err:%v", err)
+		return err
 	}
 	log.Infof("FillAllPrizePool with num:%d", totalNum)
+	return nil
 }
 
+// fillPrizePool 把到期的发奖计划放入奖品池
+// 计划先在一个事务中写入放入记录并标记为已放入，再按放入记录增加奖品池，放入记录和奖品池都会去重，重复执行不会重复放入
 func (a *AdminCase) fillPrizePool() (int, error) {
-	totalNum := 0
 	prizeList, err := a.GetPrizeList(context.Background())
 	now := time.Now()
 	if err != nil {
 		log.Errorf("FillPrizePool err:%v", err)
 		return 0, fmt.Errorf("FillPrizePool|GetPrizeList:%v", err)
 	}
-	if prizeList == nil || len(prizeList) == 0 {
-		return 0, nil
-	}
 	for _, prize := range prizeList {
 		if prize.SysStatus != 1 {
 			continue
 		}
-		if prize.PrizeNum <= 0 {
+		if prize.PrizeNum <= 0 || prize.PrizeTime <= 0 {
 			continue
 		}
 		if prize.BeginTime.After(now) || prize.EndTime.Before(now) {
 			continue
 		}
-		// 发奖计划数据不正确
-		if len(prize.PrizePlan) <= 7 {
-			continue
+		if err = a.releasePrizePlan(prize, now); err != nil {
+			log.Errorf("FillPrizePool|releasePrizePlan err:%v", err)
+			return 0, fmt.Errorf("FillPrizePool|releasePrizePlan:%v", err)
 		}
-		prizePlanList := []*TimePrizeInfo{}
-		if err = json.Unmarshal([]byte(prize.PrizePlan), &prizePlanList); err != nil {
-			log.Errorf("FillPrizePool|Unmarshal TimePrizeInfo err:%v", err)
-			return 0, fmt.Errorf("FillPrizePool|Unmarshal TimePrizeInfo:%v", err)
+	}
+	// 包括之前写入了放入记录但是没有加到奖品池的
+	return a.applyPoolLedger()
+}
+
+// releasePrizePlan 奖品到期的计划写入放入记录
+func (a *AdminCase) releasePrizePlan(prize *Prize, now time.Time) error {
+	planList, err := a.prizePlanRepo.GetDueList(prize.Id, now)
+	if err != nil || len(planList) == 0 {
+		return err
+	}
+	ids := make([]uint, 0, len(planList))
+	ledgerList := make([]*PrizePoolLedger, 0, len(planList))
+	for _, plan := range planList {
+		ids = append(ids, plan.Id)
+		ledgerList = append(ledgerList, &PrizePoolLedger{
+			PlanId:     plan.Id,
+			PrizeId:    plan.PrizeId,
+			ActivityId: plan.ActivityId,
+			ReleaseAt:  plan.ReleaseAt,
+			Num:        plan.Num,
+		})
+	}
+	err = a.tm.InTx(context.Background(), func(ctx context.Context) error {
+		num, err := a.prizePlanRepo.MarkReleased(ctx, ids)
+		if err != nil {
+			return err
 		}
-		index := 0
-		prizeNum := 0
-		for i, prizePlanInfo := range prizePlanList {
-			t, err := utils.ParseTime(prizePlanInfo.Time)
-			if err != nil {
-				log.Errorf("FillPrizePool|ParseTime err:%v", err)
-				return 0, fmt.Errorf("FillPrizePool|ParseTime:%v", err)
-			}
-			if t.After(now) {
-				break
-			}
-			//log.Infof("fillPrizePool|prize_id=%d\n", prize.Id, prizePlanInfo)
-			// 该类奖品中，之前没有发放的奖品数量都要放入奖品池
-			prizeNum += prizePlanInfo.Num
-			index = i + 1
+		// 部分计划已经被其他实例放入，这次不处理，下次重新获取
+		if num != int64(len(ids)) {
+			return errPlanReleased
 		}
-		if prizeNum > 0 {
-			a.incrPrizePool(prize.Id, prizeNum)
-			totalNum += prizeNum
+		return a.poolLedgerRepo.CreateIgnore(ctx, ledgerList)
+	})
+	if err == errPlanReleased {
+		return nil
+	}
+	return err
+}
+
+// applyPoolLedger 把还没有加到奖品池的放入记录加到奖品池，返回加到奖品池的奖品数量
+func (a *AdminCase) applyPoolLedger() (int, error) {
+	totalNum := 0
+	for {
+		list, err := a.poolLedgerRepo.GetPendingList(constant.PoolLedgerTaskLimit)
+		if err != nil {
+			log.Errorf("FillPrizePool|GetPendingList err:%v", err)
+			return totalNum, fmt.Errorf("FillPrizePool|GetPendingList:%v", err)
 		}
-		// 更新发奖计划
-		if index > 0 {
-			if index < len(prizePlanList) {
-				prizePlanList = prizePlanList[index:]
-			} else {
-				prizePlanList = make([]*TimePrizeInfo, 0)
-			}
-			// 将新的发奖计划更新到数据库
-			bytes, err := json.Marshal(&prizePlanList)
-			if err != nil {
-				log.Errorf("FillPrizePool|Marshal err:%v", err)
-				return 0, fmt.Errorf("FillPrizePool|Marshal:%v", err)
-			}
-			updatePrize := &Prize{
-				Id:        prize.Id,
-				PrizePlan: string(bytes),
-			}
-			if err = a.UpdateDbPrizeWithCache(context.Background(), updatePrize, "prize_plan"); err != nil {
-				log.Errorf("FillPrizePool|UpdateDbPrizeWithCache err:%v", err)
-				return 0, fmt.Errorf("FillPrizePool|UpdateDbPrizeWithCache:%v", err)
+		for _, ledger := range list {
+			if err = a.poolLedgerRepo.ApplyToPool(ledger); err != nil {
+				log.Errorf("FillPrizePool|ApplyToPool err:%v", err)
+				return totalNum, fmt.Errorf("FillPrizePool|ApplyToPool:%v", err)
 			}
+			totalNum += ledger.Num
 		}
-		if totalNum > 0 {
-			// totalNum>0,说明有奖品被填充到奖品池中，有奖品的发奖计划发生了改变，需要将更新后的数据加载到缓存中
-			_, err = a.GetPrizeListWithCache(context.Background())
-			if err != nil {
-				log.Errorf("FillPrizePool|GetPrizeListWithCache err:%v", err)
-				return 0, fmt.Errorf("FillPrizePool|GetPrizeListWithCache:%v", err)
-			}
+		if len(list) < constant.PoolLedgerTaskLimit {
+			return totalNum, nil
 		}
 	}
-	return totalNum, nil
-}
-
-// incrPrizePool 根据计划数据，往奖品池增加奖品数量
-func (a *AdminCase) incrPrizePool(prizeID uint, num int) (int, error) {
-	key := constant.PrizePoolCacheKey
-	cnt, err := a.prizeRepo.IncrPrizePoolNum(key, prizeID, num)
-	if err != nil {
-		log.Errorf("AdminCase|incrPrizePool|%v", err)
-		return 0, fmt.Errorf("AdminCase|incrPrizePool|%v", err)
-	}
-	log.Infof("AdminCase|incrPrizePool|prize_id=%d, num=%d", prizeID, num)
-	return cnt, nil
 }
 
 func (a *AdminCase) ClearLotteryTimes(ctx context.Context) error {
@@ -847,3 +956,129 @@ func (a *AdminCase) ClearResult(ctx context.Context) error {
 	}
 	return nil
 }
+
+// GetActivityList 获取所有活动列表
+func (a *AdminCase) GetActivityList(ctx context.Context) ([]*Activity, error) {
+	list, err := a.activityRepo.GetAll()
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|GetActivityList err:%v", err)
+		return nil, fmt.Errorf("adminCase|GetActivityList:%v", err)
+	}
+	return list, nil
+}
+
+// AddActivity 新增抽奖活动
+func (a *AdminCase) AddActivity(ctx context.Context, activity *Activity) error {
+	if activity == nil || activity.Title == "" || activity.EndTime.Before(activity.BeginTime) {
+		return fmt.Errorf("adminCase|AddActivity invalid activity")
+	}
+	if _, err := ParseTypeDayWinMax(activity.TypeDayWinMax); err != nil {
+		return fmt.Errorf("adminCase|AddActivity:%v", err)
+	}
+	activity.Id = 0
+	activity.SysStatus = constant.ActivityStatusNormal
+	if err := a.activityRepo.Create(activity); err != nil {
+		log.ErrorContextf(ctx, "adminCase|AddActivity err:%v", err)
+		return fmt.Errorf("adminCase|AddActivity:%v", err)
+	}
+	return nil
+}
+
+// UpdateActivity 修改抽奖活动，同时清空活动缓存
+func (a *AdminCase) UpdateActivity(ctx context.Context, activity *Activity) error {
+	if activity == nil || activity.Id <= 0 {
+		return fmt.Errorf("adminCase|UpdateActivity invalid activity")
+	}
+	if _, err := ParseTypeDayWinMax(activity.TypeDayWinMax); err != nil {
+		return fmt.Errorf("adminCase|UpdateActivity:%v", err)
+	}
+	if err := a.activityRepo.UpdateWithCache(activity, "title", "begin_time", "end_time",
+		"user_day_max", "ip_day_max", "prize_code_max", "select_strategy", "no_prize_weight", "pity_threshold", "pity_prize_type",
+		"user_win_max", "type_day_win_max", "sys_status"); err != nil {
+		log.ErrorContextf(ctx, "adminCase|UpdateActivity err:%v", err)
+		return fmt.Errorf("adminCase|UpdateActivity:%v", err)
+	}
+	return nil
+}
+
+// GetResultList 分页查询中奖记录
+func (a *AdminCase) GetResultList(ctx context.Context, filter *ResultFilter, page *PageQuery) ([]*Result, int64, error) {
+	page.Normalize()
+	list, total, err := a.resultRepo.GetPage(filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|GetResultList err:%v", err)
+		return nil, 0, fmt.Errorf("adminCase|GetResultList:%v", err)
+	}
+	return list, total, nil
+}
+
+// GetBlackUserList 分页查询用户黑名单
+func (a *AdminCase) GetBlackUserList(ctx context.Context, filter *BlackUserFilter, page *PageQuery) ([]*BlackUser, int64, error) {
+	page.Normalize()
+	list, total, err := a.blackUserRepo.GetPage(filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|GetBlackUserList err:%v", err)
+		return nil, 0, fmt.Errorf("adminCase|GetBlackUserList:%v", err)
+	}
+	return list, total, nil
+}
+
+// GetBlackIpList 分页查询ip黑名单
+func (a *AdminCase) GetBlackIpList(ctx context.Context, filter *BlackIpFilter, page *PageQuery) ([]*BlackIp, int64, error) {
+	page.Normalize()
+	list, total, err := a.blackIpRepo.GetPage(filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|GetBlackIpList err:%v", err)
+		return nil, 0, fmt.Errorf("adminCase|GetBlackIpList:%v", err)
+	}
+	return list, total, nil
+}
+
+// AddBlackIp 添加或修改ip黑名单，单个IP按规范写法记录，网段按主机位清零后的CIDR记录
+func (a *AdminCase) AddBlackIp(ctx context.Context, ip string, blackTime time.Time) error {
+	prefix, err := utils.ParseIpPrefix(ip)
+	if err != nil {
+		return ErrInvalidIp
+	}
+	ip = prefix.String()
+	if prefix.IsSingleIP() {
+		ip = prefix.Addr().String()
+	}
+	info, err := a.blackIpRepo.GetByIP(ip)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|AddBlackIp|GetByIP err:%v", err)
+		return fmt.Errorf("adminCase|AddBlackIp:%v", err)
+	}
+	blackIp := &BlackIp{
+		Ip:        ip,
+		BlackTime: blackTime,
+	}
+	if info == nil {
+		err = a.blackIpRepo.Create(ctx, blackIp)
+	} else {
+		err = a.blackIpRepo.Update(ctx, ip, blackIp, "black_time")
+	}
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|AddBlackIp err:%v", err)
+		return fmt.Errorf("adminCase|AddBlackIp:%v", err)
+	}
+	if blackIp.IsCidr() {
+		if err = a.blackIpRepo.DeleteCidrCache(); err != nil {
+			log.ErrorContextf(ctx, "adminCase|AddBlackIp|DeleteCidrCache err:%v", err)
+			return fmt.Errorf("adminCase|AddBlackIp:%v", err)
+		}
+	}
+	return nil
+}
+
+// AddAudit 记录后台操作审计日志
+func (a *AdminCase) AddAudit(ctx context.Context, audit *AdminAudit) error {
+	if len(audit.Payload) > constant.AuditPayloadMaxLen {
+		audit.Payload = strings.ToValidUTF8(audit.Payload[:constant.AuditPayloadMaxLen], "")
+	}
+	if err := a.adminAuditRepo.Create(audit); err != nil {
+		log.ErrorContextf(ctx, "adminCase|AddAudit err:%v", err)
+		return fmt.Errorf("adminCase|AddAudit:%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package biz
+
+import "time"
+
+// AdminAudit 后台操作审计表，只允许追加，不提供修改和删除
+type AdminAudit struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	UserId     uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:操作人ID;NOT NULL" json:"user_id"`
+	UserName   string     `gorm:"column:user_name;type:varchar(50);comment:操作人用户名;NOT NULL" json:"user_name"`
+	Operation  string     `gorm:"column:operation;type:varchar(255);comment:后台操作;NOT NULL" json:"operation"`
+	Payload    string     `gorm:"column:payload;type:text;comment:请求内容" json:"payload"`
+	ResultCode int        `gorm:"column:result_code;type:int(11);default:0;comment:结果错误码，0 成功;NOT NULL" json:"result_code"`
+	ResultMsg  string     `gorm:"column:result_msg;type:varchar(255);comment:结果描述;NOT NULL" json:"result_msg"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysIp      string     `gorm:"column:sys_ip;type:varchar(50);comment:操作人IP;NOT NULL" json:"sys_ip"`
+}
+
+func (a *AdminAudit) TableName() string {
+	return "t_admin_audit"
+}
+
+type AdminAuditRepo interface {
+	Create(audit *AdminAudit) error
+}
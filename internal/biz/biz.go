@@ -5,7 +5,8 @@ import (
 	"github.com/google/wire"
 )
 
-var ProviderSet = wire.NewSet(NewLotteryCase, NewLimitCase, NewAdminCase)
+var ProviderSet = wire.NewSet(NewLotteryCase, NewLimitCase, NewAdminCase, NewUserCase, NewFulfillCase, NewDeliveryCase,
+	NewTaskCase, NewRiskCase)
 
 // Transaction 解耦biz与data层，biz层只调用接口的方法
 type Transaction interface {
@@ -1,11 +1,22 @@
 package biz
 
-import "time"
+import (
+	"context"
+	"errors"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+)
 
-// BlackIp ip黑明单表
+// ErrInvalidIp 不是合法的IP或者CIDR网段
+var ErrInvalidIp = errors.New("invalid ip or cidr")
+
+// BlackIp ip黑明单表，Ip为单个IP或者CIDR网段，如10.0.0.0/8、2001:db8::/32
 type BlackIp struct {
 	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
-	Ip         string     `gorm:"column:ip;type:varchar(50);comment:IP地址;NOT NULL" json:"ip"`
+	Ip         string     `gorm:"column:ip;type:varchar(50);comment:IP地址或CIDR网段;NOT NULL" json:"ip"`
 	BlackTime  time.Time  `gorm:"column:black_time;type:datetime;default:1000-01-01 00:00:00;comment:黑名单限制到期时间;NOT NULL" json:"black_time"`
 	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间;NOT NULL" json:"sys_created"`
 	SysUpdated *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间;NOT NULL" json:"sys_updated"`
@@ -15,18 +26,93 @@ func (m *BlackIp) TableName() string {
 	return "t_black_ip"
 }
 
+// IsCidr 是否网段黑名单
+func (m *BlackIp) IsCidr() bool {
+	return strings.Contains(m.Ip, "/")
+}
+
 type BlackIpRepo interface {
 	Get(id uint) (*BlackIp, error)
 	GetByIP(ip string) (*BlackIp, error)
 	GetByIPWithCache(ip string) (*BlackIp, error)
 	GetAll() ([]*BlackIp, error)
+	GetPage(filter *BlackIpFilter, page *PageQuery) ([]*BlackIp, int64, error)
 	CountAll() (int64, error)
-	Create(blackIp *BlackIp) error
+	Create(ctx context.Context, blackIp *BlackIp) error
 	Delete(id uint) error
-	Update(ip string, blackIp *BlackIp, cols ...string) error
+	Update(ctx context.Context, ip string, blackIp *BlackIp, cols ...string) error
 	UpdateWithCache(ip string, blackIp *BlackIp, cols ...string) error
 	GetFromCache(id uint) (*BlackIp, error)
 	SetByCache(blackIp *BlackIp) error
 	GetByCache(ip string) (*BlackIp, error)
 	UpdateByCache(blackIp *BlackIp) error
+	// GetCidrList 还在限制期内的网段黑名单
+	GetCidrList() ([]*BlackIp, error)
+	// GetCidrListWithCache 优先从缓存获取网段黑名单，缓存没有时从db加载并写入缓存
+	GetCidrListWithCache() ([]*BlackIp, error)
+	// DeleteCidrCache 网段黑名单修改后删除缓存
+	DeleteCidrCache() error
+}
+
+// BlackIpCidrSet 网段黑名单的内存索引，按前缀长度分组，匹配时每种前缀长度查一次map
+type BlackIpCidrSet struct {
+	loadTime time.Time
+	bits4    []int // IPv4网段的前缀长度，从长到短
+	bits6    []int
+	entries  map[netip.Prefix]*BlackIp
+}
+
+// NewBlackIpCidrSet 根据网段黑名单建立索引，无法解析的记录跳过
+func NewBlackIpCidrSet(list []*BlackIp, loadTime time.Time) *BlackIpCidrSet {
+	s := &BlackIpCidrSet{
+		loadTime: loadTime,
+		entries:  make(map[netip.Prefix]*BlackIp, len(list)),
+	}
+	seen4, seen6 := make(map[int]bool), make(map[int]bool)
+	for _, info := range list {
+		prefix, err := utils.ParseIpPrefix(info.Ip)
+		if err != nil {
+			continue
+		}
+		if old, ok := s.entries[prefix]; ok && old.BlackTime.After(info.BlackTime) {
+			continue
+		}
+		s.entries[prefix] = info
+		bits := prefix.Bits()
+		if prefix.Addr().Is4() && !seen4[bits] {
+			seen4[bits] = true
+			s.bits4 = append(s.bits4, bits)
+		} else if prefix.Addr().Is6() && !seen6[bits] {
+			seen6[bits] = true
+			s.bits6 = append(s.bits6, bits)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(s.bits4)))
+	sort.Sort(sort.Reverse(sort.IntSlice(s.bits6)))
+	return s
+}
+
+// Match 返回包含该IP并且还在限制期内的网段黑名单，多个网段匹配时返回最长的
+func (s *BlackIpCidrSet) Match(ip string, now time.Time) *BlackIp {
+	if s == nil || len(s.entries) == 0 {
+		return nil
+	}
+	addr, err := utils.ParseIp(ip)
+	if err != nil {
+		return nil
+	}
+	bitsList := s.bits6
+	if addr.Is4() {
+		bitsList = s.bits4
+	}
+	for _, bits := range bitsList {
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if info, ok := s.entries[prefix]; ok && now.Before(info.BlackTime) {
+			return info
+		}
+	}
+	return nil
 }
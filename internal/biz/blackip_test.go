@@ -0,0 +1,36 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlackIpCidrSet(t *testing.T) {
+	now := time.Now()
+	set := NewBlackIpCidrSet([]*BlackIp{
+		{Id: 1, Ip: "10.0.0.0/8", BlackTime: now.Add(time.Hour)},
+		{Id: 2, Ip: "10.1.0.0/16", BlackTime: now.Add(time.Hour)},
+		{Id: 3, Ip: "172.16.0.0/12", BlackTime: now.Add(-time.Hour)},
+		{Id: 4, Ip: "2001:db8::/32", BlackTime: now.Add(time.Hour)},
+		{Id: 5, Ip: "bad/8", BlackTime: now.Add(time.Hour)},
+	}, now)
+	cases := map[string]uint{
+		"10.1.2.3":        2,
+		"10.2.2.3":        1,
+		"::ffff:10.2.2.3": 1,
+		"172.16.0.1":      0,
+		"2001:db8:1::1":   4,
+		"2001:db9::1":     0,
+		"192.168.1.1":     0,
+		"not an ip":       0,
+	}
+	for ip, want := range cases {
+		var got uint
+		if info := set.Match(ip, now); info != nil {
+			got = info.Id
+		}
+		if got != want {
+			t.Errorf("Match(%s) = %d, want %d", ip, got, want)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package biz
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // BlackUser 用户黑明单表
 type BlackUser struct {
@@ -24,11 +27,12 @@ type BlackUserRepo interface {
 	GetByUserID(uid uint) (*BlackUser, error)
 	GetByUserIDWithCache(uid uint) (*BlackUser, error)
 	GetAll() ([]*BlackUser, error)
+	GetPage(filter *BlackUserFilter, page *PageQuery) ([]*BlackUser, int64, error)
 	CountAll() (int64, error)
-	Create(blackUser *BlackUser) error
+	Create(ctx context.Context, blackUser *BlackUser) error
 	Delete(id uint) error
 	DeleteWithCache(uid uint) error
-	Update(userID uint, blackUser *BlackUser, cols ...string) error
+	Update(ctx context.Context, userID uint, blackUser *BlackUser, cols ...string) error
 	UpdateWithCache(userID uint, blackUser *BlackUser, cols ...string) error
 	GetFromCache(id uint) (*BlackUser, error)
 	GetByCache(uid uint) (*BlackUser, error)
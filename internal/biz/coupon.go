@@ -1,9 +1,13 @@
 package biz
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Coupon struct {
 	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
 	PrizeId    uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID，关联lt_prize表;NOT NULL" json:"prize_id"`
 	Code       string     `gorm:"column:code;type:varchar(255);comment:虚拟券编码;NOT NULL" json:"code"`
 	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间;NOT NULL" json:"sys_created"`
@@ -24,11 +28,11 @@ type CouponRepo interface {
 	Delete(id uint) error
 	DeleteAllWithCache() error
 	Update(coupon *Coupon, cols ...string) error
-	UpdateByCode(code string, coupon *Coupon, cols ...string) error
+	UpdateByCode(ctx context.Context, code string, coupon *Coupon, cols ...string) error
 	GetFromCache(id uint) (*Coupon, error)
 	GetGetNextUsefulCoupon(prizeID, couponID int) (*Coupon, error)
-	ImportCacheCoupon(prizeID uint, code string) (bool, error)
-	ReSetCacheCoupon(prizeID uint) (int64, int64, error)
-	GetCacheCouponNum(prizeID uint) (int64, int64, error)
-	GetNextUsefulCouponFromCache(prizeID int) (string, error)
+	ImportCacheCoupon(activityID, prizeID uint, code string) (bool, error)
+	ReSetCacheCoupon(activityID, prizeID uint) (int64, int64, error)
+	GetCacheCouponNum(activityID, prizeID uint) (int64, int64, error)
+	GetNextUsefulCouponFromCache(activityID uint, prizeID int) (string, error)
 }
@@ -0,0 +1,265 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// errDeliveryReplayConflict 发放任务已经不是死信状态，回滚死信的重新发放
+var errDeliveryReplayConflict = errors.New("delivery replay conflict")
+
+// PrizeDelivery 虚拟奖品发放任务表，和中奖记录在同一个事务中写入
+type PrizeDelivery struct {
+	Id             uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	IdempotencyKey string     `gorm:"column:idempotency_key;type:varchar(64);comment:幂等键，由中奖记录ID生成;NOT NULL" json:"idempotency_key"`
+	ResultId       uint       `gorm:"column:result_id;type:int(10) unsigned;default:0;comment:中奖记录ID;NOT NULL" json:"result_id"`
+	ActivityId     uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
+	PrizeId        uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID;NOT NULL" json:"prize_id"`
+	PrizeType      uint       `gorm:"column:prize_type;type:int(10) unsigned;default:0;comment:奖品类型;NOT NULL" json:"prize_type"`
+	UserId         uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:用户ID;NOT NULL" json:"user_id"`
+	UserName       string     `gorm:"column:user_name;type:varchar(50);comment:用户名;NOT NULL" json:"user_name"`
+	PrizeProfile   string     `gorm:"column:prize_profile;type:varchar(255);comment:奖品详情;NOT NULL" json:"prize_profile"`
+	Status         uint       `gorm:"column:status;type:smallint(5) unsigned;default:0;comment:状态，0 待发放，1 成功，2 死信;NOT NULL" json:"status"`
+	RetryNum       uint       `gorm:"column:retry_num;type:int(10) unsigned;default:0;comment:已重试次数;NOT NULL" json:"retry_num"`
+	NextRetryTime  time.Time  `gorm:"column:next_retry_time;type:datetime;comment:下次重试时间;NOT NULL" json:"next_retry_time"`
+	LastError      string     `gorm:"column:last_error;type:varchar(255);comment:最后一次失败原因;NOT NULL" json:"last_error"`
+	SysCreated     *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated     *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (d *PrizeDelivery) TableName() string {
+	return "t_prize_delivery"
+}
+
+// PrizeDeliveryDead 发放死信表，运营处理后可以重新发放
+type PrizeDeliveryDead struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	DeliveryId uint       `gorm:"column:delivery_id;type:int(10) unsigned;default:0;comment:发放任务ID;NOT NULL" json:"delivery_id"`
+	ResultId   uint       `gorm:"column:result_id;type:int(10) unsigned;default:0;comment:中奖记录ID;NOT NULL" json:"result_id"`
+	PrizeType  uint       `gorm:"column:prize_type;type:int(10) unsigned;default:0;comment:奖品类型;NOT NULL" json:"prize_type"`
+	UserId     uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:用户ID;NOT NULL" json:"user_id"`
+	RetryNum   uint       `gorm:"column:retry_num;type:int(10) unsigned;default:0;comment:已重试次数;NOT NULL" json:"retry_num"`
+	LastError  string     `gorm:"column:last_error;type:varchar(255);comment:最后一次失败原因;NOT NULL" json:"last_error"`
+	Status     uint       `gorm:"column:status;type:smallint(5) unsigned;default:0;comment:状态，0 待处理，1 已重新发放;NOT NULL" json:"status"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (d *PrizeDeliveryDead) TableName() string {
+	return "t_prize_delivery_dead"
+}
+
+type PrizeDeliveryRepo interface {
+	Create(ctx context.Context, delivery *PrizeDelivery) error
+	// UpdateAttempt 状态为from并且重试次数为retryNum时更新发放结果，并发重试时只有一个能成功
+	UpdateAttempt(ctx context.Context, delivery *PrizeDelivery, from, retryNum uint) (bool, error)
+	GetRetryList(before time.Time, limit int) ([]*PrizeDelivery, error)
+	Get(id uint) (*PrizeDelivery, error)
+	CreateDead(ctx context.Context, dead *PrizeDeliveryDead) error
+	GetDead(id uint) (*PrizeDeliveryDead, error)
+	GetDeadPage(status *uint, page *PageQuery) ([]*PrizeDeliveryDead, int64, error)
+	// UpdateDeadStatus 条件更新死信状态，返回是否更新成功
+	UpdateDeadStatus(ctx context.Context, id uint, from, to uint) (bool, error)
+}
+
+// PrizeDeliverer 把虚拟奖品发放到用户账户，同一个幂等键重复调用只能发放一次
+type PrizeDeliverer interface {
+	Deliver(ctx context.Context, delivery *PrizeDelivery) error
+}
+
+// PrizeDelivererSet 按奖品类型配置的发放方式，没有配置的奖品类型不需要发放
+type PrizeDelivererSet map[uint]PrizeDeliverer
+
+// DeliveryCase 虚拟奖品发放，首次发放在抽奖流程中完成，失败后由定时任务按退避时间重试
+type DeliveryCase struct {
+	deliveryRepo PrizeDeliveryRepo
+	deliverers   PrizeDelivererSet
+	tm           Transaction
+}
+
+func NewDeliveryCase(dr PrizeDeliveryRepo, ds PrizeDelivererSet, tm Transaction) *DeliveryCase {
+	return &DeliveryCase{
+		deliveryRepo: dr,
+		deliverers:   ds,
+		tm:           tm,
+	}
+}
+
+// NeedDeliver 奖品类型是否配置了发放方式
+func (d *DeliveryCase) NeedDeliver(prizeType uint) bool {
+	_, ok := d.deliverers[prizeType]
+	return ok
+}
+
+// DeliveryKey 由中奖记录ID生成幂等键
+func DeliveryKey(resultID uint) string {
+	return fmt.Sprintf("%s%d", constant.DeliveryKeyPrefix, resultID)
+}
+
+// deliveryBackoff 第retryNum次重试前的等待时间，指数退避
+func deliveryBackoff(retryNum uint) time.Duration {
+	backoff := constant.DeliveryRetryBackoff
+	for i := uint(1); i < retryNum && backoff < constant.DeliveryRetryBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > constant.DeliveryRetryBackoffMax {
+		backoff = constant.DeliveryRetryBackoffMax
+	}
+	return backoff
+}
+
+// CreateDelivery 创建发放任务，需要和中奖记录在同一个事务中调用
+// 下次重试时间设为第一次退避之后，避免定时任务和抽奖流程同时发放
+func (d *DeliveryCase) CreateDelivery(ctx context.Context, result *Result, prizeProfile string) (*PrizeDelivery, error) {
+	delivery := &PrizeDelivery{
+		IdempotencyKey: DeliveryKey(result.Id),
+		ResultId:       result.Id,
+		ActivityId:     result.ActivityId,
+		PrizeId:        result.PrizeId,
+		PrizeType:      result.PrizeType,
+		UserId:         result.UserId,
+		UserName:       result.UserName,
+		PrizeProfile:   prizeProfile,
+		Status:         constant.DeliveryStatusPending,
+		NextRetryTime:  time.Now().Add(deliveryBackoff(1)),
+	}
+	if err := d.deliveryRepo.Create(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("DeliveryCase|CreateDelivery:%v", err)
+	}
+	return delivery, nil
+}
+
+// Deliver 发放一次，失败时记录重试次数和下次重试时间，重试次数用完后进入死信表
+func (d *DeliveryCase) Deliver(ctx context.Context, delivery *PrizeDelivery) {
+	deliverer, ok := d.deliverers[delivery.PrizeType]
+	if !ok {
+		return
+	}
+	retryNum := delivery.RetryNum
+	err := deliverer.Deliver(ctx, delivery)
+	if err == nil {
+		delivery.Status = constant.DeliveryStatusSuccess
+		delivery.LastError = ""
+		if _, err = d.deliveryRepo.UpdateAttempt(ctx, delivery, constant.DeliveryStatusPending, retryNum); err != nil {
+			log.ErrorContextf(ctx, "DeliveryCase|Deliver delivery_id=%d err:%v", delivery.Id, err)
+		}
+		return
+	}
+	log.ErrorContextf(ctx, "DeliveryCase|Deliver delivery_id=%d retry_num=%d err:%v", delivery.Id, retryNum, err)
+	delivery.RetryNum = retryNum + 1
+	delivery.LastError = truncate(err.Error(), 255)
+	delivery.NextRetryTime = time.Now().Add(deliveryBackoff(delivery.RetryNum + 1))
+	if delivery.RetryNum < constant.DeliveryRetryMax {
+		if _, err = d.deliveryRepo.UpdateAttempt(ctx, delivery, constant.DeliveryStatusPending, retryNum); err != nil {
+			log.ErrorContextf(ctx, "DeliveryCase|Deliver delivery_id=%d err:%v", delivery.Id, err)
+		}
+		return
+	}
+	delivery.Status = constant.DeliveryStatusDead
+	err = d.tm.InTx(ctx, func(ctx context.Context) error {
+		ok, err := d.deliveryRepo.UpdateAttempt(ctx, delivery, constant.DeliveryStatusPending, retryNum)
+		if err != nil || !ok {
+			return err
+		}
+		return d.deliveryRepo.CreateDead(ctx, &PrizeDeliveryDead{
+			DeliveryId: delivery.Id,
+			ResultId:   delivery.ResultId,
+			PrizeType:  delivery.PrizeType,
+			UserId:     delivery.UserId,
+			RetryNum:   delivery.RetryNum,
+			LastError:  delivery.LastError,
+			Status:     constant.DeadLetterStatusNew,
+		})
+	})
+	if err != nil {
+		log.ErrorContextf(ctx, "DeliveryCase|Deliver delivery_id=%d dead letter err:%v", delivery.Id, err)
+	}
+}
+
+// RetryDelivery 重试到期的发放任务
+func (d *DeliveryCase) RetryDelivery(ctx context.Context) error {
+	list, err := d.deliveryRepo.GetRetryList(time.Now(), constant.DeliveryTaskLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "DeliveryCase|RetryDelivery:%v", err)
+		return fmt.Errorf("DeliveryCase|RetryDelivery:%v", err)
+	}
+	for _, delivery := range list {
+		d.Deliver(ctx, delivery)
+	}
+	return nil
+}
+
+// GetDeadList 分页查询死信
+func (d *DeliveryCase) GetDeadList(ctx context.Context, status *uint, page *PageQuery) ([]*PrizeDeliveryDead, int64, error) {
+	page.Normalize()
+	list, total, err := d.deliveryRepo.GetDeadPage(status, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "DeliveryCase|GetDeadList:%v", err)
+		return nil, 0, fmt.Errorf("DeliveryCase|GetDeadList:%v", err)
+	}
+	return list, total, nil
+}
+
+// ReplayDead 死信重新发放，发放任务恢复为待发放并清空重试次数，返回不是待处理状态的死信ID
+func (d *DeliveryCase) ReplayDead(ctx context.Context, ids []uint) ([]uint, error) {
+	failIds := make([]uint, 0)
+	for _, id := range ids {
+		dead, err := d.deliveryRepo.GetDead(id)
+		if err != nil {
+			log.ErrorContextf(ctx, "DeliveryCase|ReplayDead:%v", err)
+			return nil, fmt.Errorf("DeliveryCase|ReplayDead:%v", err)
+		}
+		if dead == nil || dead.Status != constant.DeadLetterStatusNew {
+			failIds = append(failIds, id)
+			continue
+		}
+		var replayed bool
+		err = d.tm.InTx(ctx, func(ctx context.Context) error {
+			ok, err := d.deliveryRepo.UpdateDeadStatus(ctx, id, constant.DeadLetterStatusNew,
+				constant.DeadLetterStatusReplayed)
+			if err != nil || !ok {
+				return err
+			}
+			replayed, err = d.deliveryRepo.UpdateAttempt(ctx, &PrizeDelivery{
+				Id:            dead.DeliveryId,
+				Status:        constant.DeliveryStatusPending,
+				NextRetryTime: time.Now(),
+			}, constant.DeliveryStatusDead, dead.RetryNum)
+			if err != nil {
+				return err
+			}
+			// 发放任务没有更新时死信保持待处理，避免两边都不在列表中
+			if !replayed {
+				return errDeliveryReplayConflict
+			}
+			return nil
+		})
+		if errors.Is(err, errDeliveryReplayConflict) {
+			failIds = append(failIds, id)
+			continue
+		}
+		if err != nil {
+			log.ErrorContextf(ctx, "DeliveryCase|ReplayDead:%v", err)
+			return nil, fmt.Errorf("DeliveryCase|ReplayDead:%v", err)
+		}
+		if !replayed {
+			failIds = append(failIds, id)
+		}
+	}
+	return failIds, nil
+}
+
+// truncate 按字符截断，去掉不合法的UTF-8字节，避免写库失败
+func truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, "")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	cases := map[uint]time.Duration{
+		1:  constant.DeliveryRetryBackoff,
+		2:  2 * constant.DeliveryRetryBackoff,
+		3:  4 * constant.DeliveryRetryBackoff,
+		20: constant.DeliveryRetryBackoffMax,
+	}
+	for retryNum, want := range cases {
+		if got := deliveryBackoff(retryNum); got != want {
+			t.Errorf("deliveryBackoff(%d) = %v, want %v", retryNum, got, want)
+		}
+	}
+	if key := DeliveryKey(12); key != constant.DeliveryKeyPrefix+"12" {
+		t.Errorf("DeliveryKey(12) = %s", key)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("发放失败", 2); got != "发放" {
+		t.Errorf("truncate() = %q, want 发放", got)
+	}
+	if got := truncate("ok\xe5\x8f", 10); got != "ok" {
+		t.Errorf("truncate() = %q, want ok", got)
+	}
+}
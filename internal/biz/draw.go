@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// DrawReq 一次抽奖需要在redis中原子执行的操作
+type DrawReq struct {
+	ActivityId uint
+	UserId     uint
+	Ip         string
+	UserDayMax uint
+	IpDayMax   uint
+	PrizeId    uint // 中奖奖品ID，0表示未中奖，只做次数校验和计数
+	UsePool    bool // 限量奖品需要扣减奖品池
+	PopCoupon  bool // 不同编码的优惠券需要弹出一个券码
+	OutboxId   uint // 事务消息ID，扣减成功时在redis中记录待提交标记
+}
+
+// DrawResult 原子抽奖的结果
+type DrawResult struct {
+	Status     int   // constant.DrawOK 等
+	UserNum    int64 // 用户今日抽奖次数
+	IpNum      int64 // IP今日抽奖次数
+	CouponCode string
+}
+
+// DrawRepo 在一次redis调用中完成次数校验、奖品池扣减和券码弹出
+type DrawRepo interface {
+	// Draw 次数达到上限时不计数，奖品池或者券码不足时只计数不扣减
+	Draw(req *DrawReq) (*DrawResult, error)
+	// Compensate 后续发奖失败时按待提交标记归还奖品池数量和券码，抽奖次数不归还，重复调用只归还一次
+	Compensate(req *DrawReq) error
+	// Finish 发奖事务提交后清理待提交标记
+	Finish(req *DrawReq) error
+}
+
+// NewDrawReq 根据活动和选中的奖品构造原子抽奖请求，prize为nil表示未中奖
+func NewDrawReq(activity *Activity, uid uint, ip string, prize *LotteryPrize) *DrawReq {
+	req := &DrawReq{
+		ActivityId: activity.Id,
+		UserId:     uid,
+		Ip:         ip,
+		UserDayMax: activity.UserDayMax,
+		IpDayMax:   activity.IpDayMax,
+	}
+	if prize != nil {
+		req.PrizeId = prize.Id
+		req.UsePool = prize.PrizeNum > 0
+		req.PopCoupon = prize.PrizeType == constant.PrizeTypeCouponDiff
+	}
+	return req
+}
+
+// DrawWithScript 原子抽奖，次数校验、奖品池扣减和券码弹出在一个lua脚本中完成
+func (l *LotteryCase) DrawWithScript(ctx context.Context, req *DrawReq) (*DrawResult, error) {
+	result, err := l.drawRepo.Draw(req)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|DrawWithScript:%v", err)
+		return nil, fmt.Errorf("LotteryCase|DrawWithScript:%v", err)
+	}
+	return result, nil
+}
+
+// UseCoupon 券码已经从缓存弹出，在db中标记为已发放
+func (l *LotteryCase) UseCoupon(ctx context.Context, code string) error {
+	coupon := Coupon{
+		Code:      code,
+		SysStatus: constant.CouponStatusDelete,
+	}
+	if err := l.couponRepo.UpdateByCode(ctx, code, &coupon, "sys_status"); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|UseCoupon:%v", err)
+		return fmt.Errorf("LotteryCase|UseCoupon:%v", err)
+	}
+	return nil
+}
@@ -1,6 +1,10 @@
 package biz
 
-import "time"
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"time"
+)
 
 // DayPrizeWeights 定义一天中24小时内，每个小时的发奖比例权重，100的数组，0-23出现的次数为权重大小
 var DayPrizeWeights = [100]int{
@@ -33,14 +37,17 @@ var DayPrizeWeights = [100]int{
 // ViewPrize 对外返回的数据（区别于存储层的数据）
 type ViewPrize struct {
 	Id           uint      `json:"id"`
+	ActivityId   uint      `json:"activity_id"`
 	Title        string    `json:"title"`
 	Img          string    `json:"img"`
 	PrizeNum     int       `json:"prize_num"`
 	PrizeCode    string    `json:"prize_code"`
+	Weight       uint      `json:"weight"`
+	UserWinMax   uint      `json:"user_win_max"`
 	PrizeTime    uint      `json:"prize_time"`
 	LeftNum      int       `json:"left_num"`
 	PrizeType    uint      `json:"prize_type"`
-	PrizePlan    string    `json:"prize_plan"`
+	PlanProfile  string    `json:"plan_profile"`
 	BeginTime    time.Time `json:"begin_time"`
 	EndTime      time.Time `json:"end_time"`
 	DisplayOrder uint      `json:"display_order"`
@@ -48,8 +55,10 @@ type ViewPrize struct {
 }
 
 type LoginRsp struct {
-	UserID uint   `json:"user_id"`
-	Token  string `json:"token"`
+	UserID   uint   `json:"user_id"`
+	UserName string `json:"user_name"`
+	Token    string `json:"token"`
+	ExpireAt int64  `json:"expire_at"`
 }
 
 // LotteryPrize 中奖奖品信息
@@ -60,6 +69,8 @@ type LotteryPrize struct {
 	LeftNum       int    `json:"-"`
 	PrizeCodeLow  int    `json:"-"`
 	PrizeCodeHigh int    `json:"-"`
+	Weight        uint   `json:"-"`
+	UserWinMax    uint   `json:"-"`
 	Img           string `json:"img"`
 	DisplayOrder  uint   `json:"display_order"`
 	PrizeType     uint   `json:"prize_type"`
@@ -68,13 +79,23 @@ type LotteryPrize struct {
 }
 
 type LotteryUserInfo struct {
-	UserID   uint   `json:"user_id"`
-	UserName string `json:"user_name"`
-	IP       string `json:"ip"`
+	ActivityID uint   `json:"activity_id"`
+	UserID     uint   `json:"user_id"`
+	UserName   string `json:"user_name"`
+	IP         string `json:"ip"`         // 服务端确定的客户端IP
+	ClaimedIP  string `json:"claimed_ip"` // 请求中声明的IP
+	DeviceID   string `json:"device_id"`
+	RiskAction uint   `json:"risk_action"` // 风控评估的动作
+}
+
+// IpMismatch 请求中声明了IP并且和服务端确定的IP不一致，可能是伪造的IP，作为风控信号
+func (u *LotteryUserInfo) IpMismatch() bool {
+	return u.ClaimedIP != "" && utils.NormalizeIp(u.ClaimedIP) != utils.NormalizeIp(u.IP)
 }
 
 type ViewCouponInfo struct {
 	Id         uint      `json:"id"`
+	ActivityId uint      `json:"activity_id"`
 	PrizeId    uint      `json:"prize_id"`
 	Code       string    `json:"code"`
 	SysCreated time.Time `json:"sys_created"`
@@ -86,3 +107,67 @@ type TimePrizeInfo struct {
 	Time string `json:"time"`
 	Num  int    `json:"num"`
 }
+
+// PageQuery 后台分页查询参数
+type PageQuery struct {
+	Page     int `form:"page" json:"page"`
+	PageSize int `form:"page_size" json:"page_size"`
+}
+
+// Normalize 修正非法的分页参数
+func (p *PageQuery) Normalize() {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = constant.DefaultPageSize
+	}
+	if p.PageSize > constant.MaxPageSize {
+		p.PageSize = constant.MaxPageSize
+	}
+}
+
+// Offset 分页偏移量
+func (p *PageQuery) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// ResultFilter 中奖记录筛选条件，零值表示不筛选
+type ResultFilter struct {
+	ActivityId *uint     `form:"activity_id" json:"activity_id"`
+	UserId     uint      `form:"user_id" json:"user_id"`
+	PrizeId    uint      `form:"prize_id" json:"prize_id"`
+	SysStatus  *uint     `form:"sys_status" json:"sys_status"`
+	BeginTime  time.Time `form:"begin_time" time_format:"2006-01-02 15:04:05" json:"begin_time"`
+	EndTime    time.Time `form:"end_time" time_format:"2006-01-02 15:04:05" json:"end_time"`
+}
+
+// FulfillFilter 实物奖品履约筛选条件，零值表示不筛选
+type FulfillFilter struct {
+	ActivityId     *uint     `form:"activity_id" json:"activity_id"`
+	FulfillStatus  uint      `form:"fulfill_status" json:"fulfill_status"`
+	DeadlineBefore time.Time `form:"-" json:"-"` // 领取截止时间早于该时间
+}
+
+// BlackUserFilter 用户黑名单筛选条件，零值表示不筛选
+type BlackUserFilter struct {
+	UserId   uint   `form:"user_id" json:"user_id"`
+	UserName string `form:"user_name" json:"user_name"` // 前缀匹配
+	OnlyLive bool   `form:"only_live" json:"only_live"` // 只查询还在限制期内的
+}
+
+// RiskLogFilter 风控记录筛选条件，零值表示不筛选
+type RiskLogFilter struct {
+	ActivityId *uint     `form:"activity_id" json:"activity_id"`
+	UserId     uint      `form:"user_id" json:"user_id"`
+	Ip         string    `form:"ip" json:"ip"`
+	Action     uint      `form:"action" json:"action"`
+	BeginTime  time.Time `form:"begin_time" time_format:"2006-01-02 15:04:05" json:"begin_time"`
+	EndTime    time.Time `form:"end_time" time_format:"2006-01-02 15:04:05" json:"end_time"`
+}
+
+// BlackIpFilter ip黑名单筛选条件，零值表示不筛选
+type BlackIpFilter struct {
+	Ip       string `form:"ip" json:"ip"`               // 前缀匹配
+	OnlyLive bool   `form:"only_live" json:"only_live"` // 只查询还在限制期内的
+}
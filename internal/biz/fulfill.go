@@ -0,0 +1,235 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrResultInvalid 中奖记录不存在、不属于当前用户或者履约状态不允许当前操作
+var ErrResultInvalid = errors.New("result not exists or status invalid")
+
+// ErrClaimInfoInvalid 收货信息不完整
+var ErrClaimInfoInvalid = errors.New("claim info invalid")
+
+// ClaimInfo 领取实物奖品时填写的收货信息
+type ClaimInfo struct {
+	ResultId     uint   `json:"result_id"`
+	ContactName  string `json:"contact_name"`
+	ContactPhone string `json:"contact_phone"`
+	Address      string `json:"address"`
+}
+
+// ShipInfo 发货信息
+type ShipInfo struct {
+	ResultId   uint   `json:"result_id"`
+	TrackingNo string `json:"tracking_no"`
+}
+
+// FulfillCase 实物奖品履约，状态流转见 constant.FulfillStatusWon 等
+type FulfillCase struct {
+	resultRepo ResultRepo
+	prizeRepo  PrizeRepo
+	tm         Transaction
+}
+
+func NewFulfillCase(rr ResultRepo, pr PrizeRepo, tm Transaction) *FulfillCase {
+	return &FulfillCase{
+		resultRepo: rr,
+		prizeRepo:  pr,
+		tm:         tm,
+	}
+}
+
+// IsEntityPrize 实物奖品需要填写收货信息后发货
+func IsEntityPrize(prizeType uint) bool {
+	return prizeType >= constant.PrizeTypeEntitySmall && prizeType <= constant.PrizeTypeEntityLarge
+}
+
+func (info *ClaimInfo) normalize() error {
+	info.ContactName = strings.TrimSpace(info.ContactName)
+	info.ContactPhone = strings.TrimSpace(info.ContactPhone)
+	info.Address = strings.TrimSpace(info.Address)
+	if info.ResultId == 0 || info.ContactName == "" || info.ContactPhone == "" || info.Address == "" {
+		return ErrClaimInfoInvalid
+	}
+	if utf8.RuneCountInString(info.ContactName) > 50 || len(info.ContactPhone) > 20 ||
+		utf8.RuneCountInString(info.Address) > 255 {
+		return ErrClaimInfoInvalid
+	}
+	return nil
+}
+
+// ClaimPrize 中奖用户在领取期限内填写收货信息
+func (f *FulfillCase) ClaimPrize(ctx context.Context, uid uint, info *ClaimInfo) error {
+	if err := info.normalize(); err != nil {
+		return err
+	}
+	result, err := f.resultRepo.Get(info.ResultId)
+	if err != nil {
+		log.ErrorContextf(ctx, "FulfillCase|ClaimPrize:%v", err)
+		return fmt.Errorf("FulfillCase|ClaimPrize:%v", err)
+	}
+	now := time.Now()
+	if result == nil || result.UserId != uid || result.FulfillStatus != constant.FulfillStatusWon ||
+		(result.ClaimDeadline != nil && result.ClaimDeadline.Before(now)) {
+		return ErrResultInvalid
+	}
+	ok, err := f.resultRepo.UpdateFulfill(ctx, &Result{
+		Id:            info.ResultId,
+		FulfillStatus: constant.FulfillStatusClaimed,
+		ContactName:   info.ContactName,
+		ContactPhone:  info.ContactPhone,
+		Address:       info.Address,
+		ClaimTime:     &now,
+	}, constant.FulfillStatusWon, "contact_name", "contact_phone", "address", "claim_time")
+	if err != nil {
+		log.ErrorContextf(ctx, "FulfillCase|ClaimPrize:%v", err)
+		return fmt.Errorf("FulfillCase|ClaimPrize:%v", err)
+	}
+	if !ok {
+		return ErrResultInvalid
+	}
+	return nil
+}
+
+// GetShipmentList 获取已领取待发货的中奖记录
+func (f *FulfillCase) GetShipmentList(ctx context.Context, activityID *uint) ([]*Result, error) {
+	list, err := f.resultRepo.GetFulfillList(&FulfillFilter{
+		ActivityId:    activityID,
+		FulfillStatus: constant.FulfillStatusClaimed,
+	}, 0)
+	if err != nil {
+		log.ErrorContextf(ctx, "FulfillCase|GetShipmentList:%v", err)
+		return nil, fmt.Errorf("FulfillCase|GetShipmentList:%v", err)
+	}
+	return list, nil
+}
+
+// ShipResults 批量录入物流单号，返回状态不是已领取或者单号为空的中奖记录ID
+func (f *FulfillCase) ShipResults(ctx context.Context, list []*ShipInfo) ([]uint, error) {
+	failIds := make([]uint, 0)
+	now := time.Now()
+	for _, info := range list {
+		trackingNo := strings.TrimSpace(info.TrackingNo)
+		if trackingNo == "" || len(trackingNo) > 64 {
+			failIds = append(failIds, info.ResultId)
+			continue
+		}
+		ok, err := f.resultRepo.UpdateFulfill(ctx, &Result{
+			Id:            info.ResultId,
+			FulfillStatus: constant.FulfillStatusShipped,
+			TrackingNo:    trackingNo,
+			ShipTime:      &now,
+		}, constant.FulfillStatusClaimed, "tracking_no", "ship_time")
+		if err != nil {
+			log.ErrorContextf(ctx, "FulfillCase|ShipResults:%v", err)
+			return nil, fmt.Errorf("FulfillCase|ShipResults:%v", err)
+		}
+		if !ok {
+			failIds = append(failIds, info.ResultId)
+		}
+	}
+	return failIds, nil
+}
+
+// DeliverResults 批量标记签收，返回状态不是已发货的中奖记录ID
+func (f *FulfillCase) DeliverResults(ctx context.Context, ids []uint) ([]uint, error) {
+	failIds := make([]uint, 0)
+	now := time.Now()
+	for _, id := range ids {
+		ok, err := f.resultRepo.UpdateFulfill(ctx, &Result{
+			Id:            id,
+			FulfillStatus: constant.FulfillStatusDelivered,
+			DeliverTime:   &now,
+		}, constant.FulfillStatusShipped, "deliver_time")
+		if err != nil {
+			log.ErrorContextf(ctx, "FulfillCase|DeliverResults:%v", err)
+			return nil, fmt.Errorf("FulfillCase|DeliverResults:%v", err)
+		}
+		if !ok {
+			failIds = append(failIds, id)
+		}
+	}
+	return failIds, nil
+}
+
+// ExpireFulfill 超过领取期限的实物奖品标记为已过期，已过期的奖品回库
+// 有纪录处理失败时继续处理后面的纪录，返回最后一个错误
+func (f *FulfillCase) ExpireFulfill(ctx context.Context) error {
+	list, err := f.resultRepo.GetFulfillList(&FulfillFilter{
+		FulfillStatus:  constant.FulfillStatusWon,
+		DeadlineBefore: time.Now(),
+	}, constant.FulfillTaskLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "FulfillCase|ExpireFulfill:%v", err)
+		return fmt.Errorf("FulfillCase|ExpireFulfill:%v", err)
+	}
+	var expireErr error
+	for _, result := range list {
+		if _, err = f.resultRepo.UpdateFulfill(ctx, &Result{
+			Id:            result.Id,
+			FulfillStatus: constant.FulfillStatusExpired,
+		}, constant.FulfillStatusWon); err != nil {
+			log.ErrorContextf(ctx, "FulfillCase|ExpireFulfill result_id=%d err:%v", result.Id, err)
+			expireErr = fmt.Errorf("FulfillCase|ExpireFulfill result_id=%d:%v", result.Id, err)
+		}
+	}
+	// 包括之前回库失败的记录
+	list, err = f.resultRepo.GetFulfillList(&FulfillFilter{
+		FulfillStatus: constant.FulfillStatusExpired,
+	}, constant.FulfillTaskLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "FulfillCase|ExpireFulfill:%v", err)
+		return fmt.Errorf("FulfillCase|ExpireFulfill:%v", err)
+	}
+	for _, result := range list {
+		if err = f.restock(ctx, result); err != nil {
+			log.ErrorContextf(ctx, "FulfillCase|ExpireFulfill result_id=%d err:%v", result.Id, err)
+			expireErr = fmt.Errorf("FulfillCase|ExpireFulfill result_id=%d:%v", result.Id, err)
+		}
+	}
+	return expireErr
+}
+
+// restock 过期奖品归还到剩余数量和奖品池，和状态更新在一个事务中完成
+func (f *FulfillCase) restock(ctx context.Context, result *Result) error {
+	prize, err := f.prizeRepo.Get(result.PrizeId)
+	if err != nil {
+		return fmt.Errorf("FulfillCase|restock:%v", err)
+	}
+	err = f.tm.InTx(ctx, func(ctx context.Context) error {
+		ok, err := f.resultRepo.UpdateFulfill(ctx, &Result{
+			Id:            result.Id,
+			FulfillStatus: constant.FulfillStatusRestocked,
+		}, constant.FulfillStatusExpired)
+		if err != nil || !ok {
+			return err
+		}
+		// 奖品已删除或者不限量时只更新状态
+		if prize == nil || prize.PrizeNum <= 0 {
+			return nil
+		}
+		if err = f.prizeRepo.IncrLeftNum(ctx, int(prize.Id), "left_num", 1); err != nil {
+			return err
+		}
+		// 奖品池放在最后，失败时回滚数据库
+		key := constant.ActivityCacheKey(prize.ActivityId, constant.PrizePoolCacheKey)
+		_, err = f.prizeRepo.IncrPrizePoolNum(key, prize.Id, 1)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("FulfillCase|restock:%v", err)
+	}
+	if prize != nil {
+		if err = f.prizeRepo.UpdateByCache(prize); err != nil {
+			return fmt.Errorf("FulfillCase|restock:%v", err)
+		}
+	}
+	return nil
+}
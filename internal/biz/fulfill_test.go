@@ -0,0 +1,23 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClaimInfoNormalize(t *testing.T) {
+	info := &ClaimInfo{ResultId: 1, ContactName: " 张三 ", ContactPhone: "13800000000", Address: "北京市"}
+	if err := info.normalize(); err != nil || info.ContactName != "张三" {
+		t.Errorf("got %+v, err %v", info, err)
+	}
+	invalid := []*ClaimInfo{
+		{ContactName: "张三", ContactPhone: "13800000000", Address: "北京市"},
+		{ResultId: 1, ContactName: " ", ContactPhone: "13800000000", Address: "北京市"},
+		{ResultId: 1, ContactName: "张三", ContactPhone: "13800000000", Address: strings.Repeat("北", 256)},
+	}
+	for _, info := range invalid {
+		if err := info.normalize(); err != ErrClaimInfoInvalid {
+			t.Errorf("%+v should be invalid, err %v", info, err)
+		}
+	}
+}
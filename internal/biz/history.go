@@ -0,0 +1,107 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+// RecentWinner 最近中奖用户，用户名已打码，缓存中只保存展示需要的字段
+type RecentWinner struct {
+	UserName  string    `json:"user_name"`
+	PrizeName string    `json:"prize_name"`
+	PrizeType uint      `json:"prize_type"`
+	WinTime   time.Time `json:"win_time"`
+}
+
+// UserLotteryTimes 用户今天的抽奖次数
+type UserLotteryTimes struct {
+	DayMax    uint
+	UsedNum   uint
+	RemainNum uint
+}
+
+// GetUserWinList 分页查询用户的正常中奖纪录，activityID为空时查询所有活动
+func (l *LotteryCase) GetUserWinList(ctx context.Context, uid uint, activityID *uint, page *PageQuery) ([]*Result, int64, error) {
+	page.Normalize()
+	// 不展示已删除和作弊的记录
+	sysStatus := uint(constant.ResultStatusNormal)
+	list, total, err := l.resultRepo.GetPage(&ResultFilter{
+		ActivityId: activityID,
+		UserId:     uid,
+		SysStatus:  &sysStatus,
+	}, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetUserWinList:%v", err)
+		return nil, 0, fmt.Errorf("LotteryCase|GetUserWinList:%v", err)
+	}
+	return list, total, nil
+}
+
+// GetRecentWinners 获取活动最近中奖的用户，优先读缓存，缓存过期后从db加载
+func (l *LotteryCase) GetRecentWinners(ctx context.Context, activityID uint) ([]*RecentWinner, error) {
+	winners, err := l.resultRepo.GetRecentWinnersFromCache(activityID)
+	if err != nil {
+		// 缓存读取失败时直接查db
+		log.ErrorContextf(ctx, "LotteryCase|GetRecentWinners:%v", err)
+	}
+	if winners != nil {
+		return winners, nil
+	}
+	list, err := l.resultRepo.GetRecentList(activityID, constant.RecentWinnerNum)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetRecentWinners:%v", err)
+		return nil, fmt.Errorf("LotteryCase|GetRecentWinners:%v", err)
+	}
+	winners = make([]*RecentWinner, 0, len(list))
+	for _, result := range list {
+		winner := &RecentWinner{
+			UserName:  utils.MaskUserName(result.UserName),
+			PrizeName: result.PrizeName,
+			PrizeType: result.PrizeType,
+		}
+		if result.SysCreated != nil {
+			winner.WinTime = *result.SysCreated
+		}
+		winners = append(winners, winner)
+	}
+	if err = l.resultRepo.SetRecentWinnersCache(activityID, winners); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetRecentWinners:%v", err)
+	}
+	return winners, nil
+}
+
+// GetUserLotteryTimes 获取用户今天已经抽奖和剩余的次数，优先使用缓存中的计数，缓存中没有时从db获取
+func (l *LimitCase) GetUserLotteryTimes(ctx context.Context, activity *Activity, uid uint) (*UserLotteryTimes, error) {
+	usedNum, ok, err := l.lotteryTimesRepo.GetUserDayLotteryNum(activity.Id, uid)
+	if err != nil {
+		log.ErrorContextf(ctx, "LimitCase|GetUserLotteryTimes:%v", err)
+	}
+	if err != nil || !ok {
+		lotteryTimes, err := l.GetUserCurrentLotteryTimes(ctx, activity.Id, uid)
+		if err != nil {
+			return nil, fmt.Errorf("LimitCase|GetUserLotteryTimes:%v", err)
+		}
+		usedNum = 0
+		if lotteryTimes != nil {
+			usedNum = int64(lotteryTimes.Num)
+		}
+	}
+	return newUserLotteryTimes(activity.UserDayMax, usedNum), nil
+}
+
+// newUserLotteryTimes 缓存计数在超过限制时也会递增，已抽奖次数不超过每天的限制
+func newUserLotteryTimes(dayMax uint, usedNum int64) *UserLotteryTimes {
+	times := &UserLotteryTimes{DayMax: dayMax}
+	if usedNum > int64(dayMax) {
+		usedNum = int64(dayMax)
+	}
+	if usedNum > 0 {
+		times.UsedNum = uint(usedNum)
+	}
+	times.RemainNum = dayMax - times.UsedNum
+	return times
+}
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/BitofferHub/lotterysvr/internal/utils"
-	"github.com/BitofferHub/pkg/middlewares/cache"
 	"github.com/BitofferHub/pkg/middlewares/log"
-	"math"
-	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,24 +15,27 @@ type LimitCase struct {
 	lotteryTimesRepo LotteryTimesRepo
 	blackIpRepo      BlackIpRepo
 	blackUserRepo    BlackUserRepo
+	lotteryConf      *LotteryConfig
 	tm               Transaction
+	cidrSet          atomic.Value // *BlackIpCidrSet
+	cidrMu           sync.Mutex
 }
 
-func NewLimitCase(bur BlackUserRepo, bir BlackIpRepo, ltr LotteryTimesRepo, tm Transaction) *LimitCase {
+func NewLimitCase(bur BlackUserRepo, bir BlackIpRepo, ltr LotteryTimesRepo, lc *LotteryConfig,
+	tm Transaction) *LimitCase {
 	return &LimitCase{
 		blackUserRepo:    bur,
 		blackIpRepo:      bir,
 		lotteryTimesRepo: ltr,
+		lotteryConf:      lc,
 		tm:               tm,
 	}
 }
 
-// GetUserCurrentLotteryTimes 获取当天该用户的抽奖次数
-func (l *LimitCase) GetUserCurrentLotteryTimes(ctx context.Context, uid uint) (*LotteryTimes, error) {
-	y, m, d := time.Now().Date()
-	strDay := fmt.Sprintf("%d%02d%02d", y, m, d)
-	day, _ := strconv.Atoi(strDay)
-	lotteryTimes, err := l.lotteryTimesRepo.GetByUserIDAndDay(uid, uint(day))
+// GetUserCurrentLotteryTimes 获取当天该用户的抽奖次数，日期按业务时区计算
+func (l *LimitCase) GetUserCurrentLotteryTimes(ctx context.Context, activityID, uid uint) (*LotteryTimes, error) {
+	day := l.lotteryConf.Limits().Today(time.Now())
+	lotteryTimes, err := l.lotteryTimesRepo.GetByUserIDAndDay(activityID, uid, uint(day))
 	if err != nil {
 		log.ErrorContextf(ctx, "lotteryTimesCase|GetUserCurrentLotteryTimes:%v", err)
 		return nil, err
@@ -42,14 +44,14 @@ func (l *LimitCase) GetUserCurrentLotteryTimes(ctx context.Context, uid uint) (*
 }
 
 // CheckUserDayLotteryTimes 判断当天是否还可以进行抽奖
-func (l *LimitCase) CheckUserDayLotteryTimes(ctx context.Context, uid uint) (bool, error) {
-	userLotteryTimes, err := l.GetUserCurrentLotteryTimes(ctx, uid)
+func (l *LimitCase) CheckUserDayLotteryTimes(ctx context.Context, activity *Activity, uid uint) (bool, error) {
+	userLotteryTimes, err := l.GetUserCurrentLotteryTimes(ctx, activity.Id, uid)
 	if err != nil {
 		return false, fmt.Errorf("checkUserDayLotteryTimes|err:%v", err)
 	}
 	if userLotteryTimes != nil {
 		// 今天的抽奖记录已经达到了抽奖次数限制
-		if userLotteryTimes.Num >= constant.UserPrizeMax {
+		if userLotteryTimes.Num >= activity.UserDayMax {
 			return false, nil
 		} else {
 			userLotteryTimes.Num++
@@ -59,13 +61,12 @@ func (l *LimitCase) CheckUserDayLotteryTimes(ctx context.Context, uid uint) (boo
 		}
 		return true, nil
 	}
-	y, m, d := time.Now().Date()
-	strDay := fmt.Sprintf("%d%02d%02d", y, m, d)
-	day, _ := strconv.Atoi(strDay)
+	day := l.lotteryConf.Limits().Today(time.Now())
 	lotteryTimesInfo := &LotteryTimes{
-		UserId: uid,
-		Day:    uint(day),
-		Num:    1,
+		ActivityId: activity.Id,
+		UserId:     uid,
+		Day:        uint(day),
+		Num:        1,
 	}
 	if err := l.lotteryTimesRepo.Create(lotteryTimesInfo); err != nil {
 		return false, fmt.Errorf("updateLotteryTimes｜create:%v", err)
@@ -73,26 +74,33 @@ func (l *LimitCase) CheckUserDayLotteryTimes(ctx context.Context, uid uint) (boo
 	return true, nil
 }
 
-func (l *LimitCase) CheckUserDayLotteryTimesWithCache(ctx context.Context, uid uint) (bool, error) {
+func (l *LimitCase) CheckUserDayLotteryTimesWithCache(ctx context.Context, activity *Activity, uid uint) (bool, error) {
 	// 通过缓存验证
-	userLotteryNum := l.lotteryTimesRepo.IncrUserDayLotteryNum(uid)
+	userLotteryNum := l.lotteryTimesRepo.IncrUserDayLotteryNum(activity.Id, uid)
 	//log.InfoContextf(ctx, "CheckUserDayLotteryTimesWithCache|userLotteryNum = %d", userLotteryNum)
 	// 缓存验证没通过，直接返回
 	log.Infof("checkUserDayLotteryTimes|uid=%d|userLotteryNum=%d", uid, userLotteryNum)
-	if userLotteryNum > constant.UserPrizeMax {
+	if userLotteryNum > int64(activity.UserDayMax) {
 		return false, nil
 	}
+	return l.SyncUserDayLotteryTimes(ctx, activity, uid, userLotteryNum)
+}
+
+// SyncUserDayLotteryTimes 缓存计数通过之后，在数据库中再做一次验证并记录今日抽奖次数
+// userLotteryNum为缓存中递增后的次数，数据库次数更大时以数据库为准回写缓存
+func (l *LimitCase) SyncUserDayLotteryTimes(ctx context.Context, activity *Activity, uid uint,
+	userLotteryNum int64) (bool, error) {
 	// 通过数据库验证，还要在数据库中做一次验证
-	userLotteryTimes, err := l.GetUserCurrentLotteryTimes(ctx, uid)
+	userLotteryTimes, err := l.GetUserCurrentLotteryTimes(ctx, activity.Id, uid)
 	if err != nil {
 		return false, fmt.Errorf("checkUserDayLotteryTimes|err:%v", err)
 	}
 	if userLotteryTimes != nil {
 		// 数据库验证今天的抽奖记录已经达到了抽奖次数限制，不能在抽奖
-		if userLotteryTimes.Num >= constant.UserPrizeMax {
+		if userLotteryTimes.Num >= activity.UserDayMax {
 			// 缓存数据不可靠，不对，需要更新
 			if int64(userLotteryTimes.Num) > userLotteryNum {
-				if err = l.lotteryTimesRepo.InitUserLuckyNum(uid, int64(userLotteryTimes.Num)); err != nil {
+				if err = l.lotteryTimesRepo.InitUserLuckyNum(activity.Id, uid, int64(userLotteryTimes.Num)); err != nil {
 					return false, fmt.Errorf("LimitCase|CheckUserDayLotteryTimesWithCache:%v", err)
 				}
 			}
@@ -101,7 +109,7 @@ func (l *LimitCase) CheckUserDayLotteryTimesWithCache(ctx context.Context, uid u
 			userLotteryTimes.Num++
 			// 此时次数抽奖次数增加了，需要更新缓存
 			if int64(userLotteryTimes.Num) > userLotteryNum {
-				if err = l.lotteryTimesRepo.InitUserLuckyNum(uid, int64(userLotteryTimes.Num)); err != nil {
+				if err = l.lotteryTimesRepo.InitUserLuckyNum(activity.Id, uid, int64(userLotteryTimes.Num)); err != nil {
 					return false, fmt.Errorf("LimitCase|CheckUserDayLotteryTimesWithCache:%v", err)
 				}
 			}
@@ -112,66 +120,114 @@ func (l *LimitCase) CheckUserDayLotteryTimesWithCache(ctx context.Context, uid u
 		}
 		return true, nil
 	}
-	y, m, d := time.Now().Date()
-	strDay := fmt.Sprintf("%d%02d%02d", y, m, d)
-	day, _ := strconv.Atoi(strDay)
+	day := l.lotteryConf.Limits().Today(time.Now())
 	lotteryTimesInfo := &LotteryTimes{
-		UserId: uid,
-		Day:    uint(day),
-		Num:    1,
+		ActivityId: activity.Id,
+		UserId:     uid,
+		Day:        uint(day),
+		Num:        1,
 	}
 	if err = l.lotteryTimesRepo.Create(lotteryTimesInfo); err != nil {
 		return false, fmt.Errorf("updateLotteryTimes｜create:%v", err)
 	}
-	if err = l.lotteryTimesRepo.InitUserLuckyNum(uid, 1); err != nil {
+	if err = l.lotteryTimesRepo.InitUserLuckyNum(activity.Id, uid, 1); err != nil {
 		return false, fmt.Errorf("LimitCase|CheckUserDayLotteryTimesWithCache:%v", err)
 	}
 	return true, nil
 }
 
-// CheckIPLimit 验证ip抽奖是否受限制
-func (l *LimitCase) CheckIPLimit(ctx context.Context, strIp string) int64 {
-	ip := utils.Ip4toInt(strIp)
-	i := ip % constant.IpFrameSize
-	key := fmt.Sprintf("day_ip_num_%d", i)
-	ret, err := cache.GetRedisCli().HIncrBy(ctx, key, strIp, 1)
+// CheckIPLimit 验证ip抽奖是否受限制，IP和所在网段的今日次数都递增，白名单网段不受限制
+// 计数失败时按受限处理
+func (l *LimitCase) CheckIPLimit(ctx context.Context, activity *Activity, strIp string) bool {
+	limits := l.lotteryConf.Limits()
+	clientIp := limits.ParseClientIp(strIp)
+	if clientIp.Exempt {
+		return true
+	}
+	num, err := l.lotteryTimesRepo.IncrIPDayLotteryNum(activity.Id, clientIp.Ip)
 	if err != nil {
 		log.ErrorContextf(ctx, "CheckIPLimit|Incr:%v", err)
-		return math.MaxInt32
+		return false
+	}
+	if num > int64(activity.IpDayMax) {
+		return false
+	}
+	if clientIp.Prefix == "" {
+		return true
 	}
-	return ret
+	num, err = l.lotteryTimesRepo.IncrIPPrefixDayLotteryNum(activity.Id, clientIp.Prefix)
+	if err != nil {
+		log.ErrorContextf(ctx, "CheckIPLimit|IncrPrefix:%v", err)
+		return false
+	}
+	return num <= int64(limits.IpPrefixLimitMax)
 }
 
+// CheckBlackIP 验证ip是否在黑名单中，先查单个IP的记录，再匹配网段黑名单
+// 返回的黑名单信息在不受限时是单个IP的记录，用于中大奖后更新黑名单时间
 func (l *LimitCase) CheckBlackIP(ctx context.Context, ip string) (bool, *BlackIp, error) {
+	ip = utils.NormalizeIp(ip)
 	info, err := l.blackIpRepo.GetByIP(ip)
 	if err != nil {
 		log.ErrorContextf(ctx, "CheckBlackIP|GetByIP:%v", err)
 		return false, nil, fmt.Errorf("CheckBlackIP|GetByIP:%v", err)
 	}
-	if info == nil || info.Ip == "" {
-		return true, nil, nil
-	}
-	if time.Now().Before(info.BlackTime) {
-		// IP黑名单存在，而且还在黑名单有效期内
-		return false, info, nil
-	}
-	return true, info, nil
+	ok, info := l.checkBlackIP(ctx, ip, info)
+	return ok, info, nil
 }
 
 func (l *LimitCase) CheckBlackIPWithCache(ctx context.Context, ip string) (bool, *BlackIp, error) {
+	ip = utils.NormalizeIp(ip)
 	info, err := l.blackIpRepo.GetByIPWithCache(ip)
 	if err != nil {
 		log.ErrorContextf(ctx, "CheckBlackIP|GetByIP:%v", err)
 		return false, nil, fmt.Errorf("CheckBlackIP|GetByIP:%v", err)
 	}
+	ok, info := l.checkBlackIP(ctx, ip, info)
+	return ok, info, nil
+}
+
+func (l *LimitCase) checkBlackIP(ctx context.Context, ip string, info *BlackIp) (bool, *BlackIp) {
+	now := time.Now()
+	if info != nil && info.Ip != "" && now.Before(info.BlackTime) {
+		// IP黑名单存在，而且还在黑名单有效期内
+		return false, info
+	}
+	if cidr := l.blackIpCidrSet(ctx).Match(ip, now); cidr != nil {
+		return false, cidr
+	}
 	if info == nil || info.Ip == "" {
-		return true, nil, nil
+		return true, nil
 	}
-	if time.Now().Before(info.BlackTime) {
-		// IP黑名单存在，而且还在黑名单有效期内
-		return false, info, nil
+	return true, info
+}
+
+// blackIpCidrSet 内存中的网段黑名单索引，超过刷新间隔后由一个请求重新加载，其他请求继续使用旧的索引
+func (l *LimitCase) blackIpCidrSet(ctx context.Context) *BlackIpCidrSet {
+	set, _ := l.cidrSet.Load().(*BlackIpCidrSet)
+	now := time.Now()
+	if set != nil && now.Sub(set.loadTime) < constant.BlackIpCidrRefreshInterval {
+		return set
 	}
-	return true, info, nil
+	if !l.cidrMu.TryLock() {
+		return set
+	}
+	defer l.cidrMu.Unlock()
+	list, err := l.blackIpRepo.GetCidrListWithCache()
+	if err != nil {
+		log.ErrorContextf(ctx, "blackIpCidrSet|GetCidrListWithCache:%v", err)
+		// 加载失败时继续使用旧的索引，下一个刷新间隔再重试
+		next := NewBlackIpCidrSet(nil, now)
+		if set != nil {
+			*next = *set
+			next.loadTime = now
+		}
+		l.cidrSet.Store(next)
+		return set
+	}
+	set = NewBlackIpCidrSet(list, now)
+	l.cidrSet.Store(set)
+	return set
 }
 
 func (l *LimitCase) CheckBlackUser(ctx context.Context, uid uint) (bool, *BlackUser, error) {
@@ -200,10 +256,25 @@ func (l *LimitCase) CheckBlackUserWithCache(ctx context.Context, uid uint) (bool
 	return true, info, nil
 }
 
-func (l *LimitCase) CronJobResetIPLotteryNums() {
-	l.lotteryTimesRepo.ResetIPLotteryNums()
+// GetUserMissNum 获取用户在活动中连续未中奖的次数
+func (l *LimitCase) GetUserMissNum(ctx context.Context, activityID, uid uint) (int64, error) {
+	num, err := l.lotteryTimesRepo.GetUserMissNum(activityID, uid)
+	if err != nil {
+		log.ErrorContextf(ctx, "LimitCase|GetUserMissNum:%v", err)
+		return 0, fmt.Errorf("LimitCase|GetUserMissNum:%v", err)
+	}
+	return num, nil
 }
 
-func (l *LimitCase) CronJobResetUserLotteryNums() {
-	l.lotteryTimesRepo.ResetUserLotteryNums()
+// UpdateUserMissNum 按抽奖结果更新用户连续未中奖次数，中奖清零，未中奖加一
+func (l *LimitCase) UpdateUserMissNum(ctx context.Context, activityID, uid uint, won bool) {
+	if won {
+		if err := l.lotteryTimesRepo.ResetUserMissNum(activityID, uid); err != nil {
+			log.ErrorContextf(ctx, "LimitCase|UpdateUserMissNum:%v", err)
+		}
+		return
+	}
+	if _, err := l.lotteryTimesRepo.IncrUserMissNum(activityID, uid); err != nil {
+		log.ErrorContextf(ctx, "LimitCase|UpdateUserMissNum:%v", err)
+	}
 }
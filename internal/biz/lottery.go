@@ -4,79 +4,128 @@ import (
 	"context"
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/lock"
 	"github.com/BitofferHub/pkg/middlewares/log"
-	"strconv"
-	"strings"
 	"time"
 )
 
 type LotteryCase struct {
-	prizeRepo     PrizeRepo
-	couponRepo    CouponRepo
-	blackUserRepo BlackUserRepo
-	blackIpRepo   BlackIpRepo
-	resultRepo    ResultRepo
-	tm            Transaction
+	prizeRepo      PrizeRepo
+	couponRepo     CouponRepo
+	blackUserRepo  BlackUserRepo
+	blackIpRepo    BlackIpRepo
+	resultRepo     ResultRepo
+	activityRepo   ActivityRepo
+	winCapRepo     WinCapRepo
+	drawRepo       DrawRepo
+	drawOutboxRepo DrawOutboxRepo
+	resultSink     ResultSink
+	deliveryCase   *DeliveryCase
+	lotteryConf    *LotteryConfig
+	tm             Transaction
 }
 
 func NewLotteryCase(pr PrizeRepo, cr CouponRepo, bur BlackUserRepo,
-	bir BlackIpRepo, result ResultRepo, tm Transaction) *LotteryCase {
+	bir BlackIpRepo, result ResultRepo, ar ActivityRepo, wcr WinCapRepo, dr DrawRepo, dor DrawOutboxRepo, rs ResultSink, dc *DeliveryCase, lc *LotteryConfig, tm Transaction) *LotteryCase {
 	return &LotteryCase{
-		prizeRepo:     pr,
-		couponRepo:    cr,
-		blackUserRepo: bur,
-		blackIpRepo:   bir,
-		resultRepo:    result,
-		tm:            tm,
+		prizeRepo:      pr,
+		couponRepo:     cr,
+		blackUserRepo:  bur,
+		blackIpRepo:    bir,
+		resultRepo:     result,
+		activityRepo:   ar,
+		winCapRepo:     wcr,
+		drawRepo:       dr,
+		drawOutboxRepo: dor,
+		resultSink:     rs,
+		deliveryCase:   dc,
+		lotteryConf:    lc,
+		tm:             tm,
 	}
 }
 
-func (l *LotteryCase) GetPrize(ctx context.Context, prizeCode int) (*LotteryPrize, error) {
-	var prize *LotteryPrize
-	lotteryPrizeList, err := l.GetAllUsefulPrizes(ctx)
+// GetActivity 获取抽奖活动，活动不存在或者不在有效期内返回nil
+func (l *LotteryCase) GetActivity(ctx context.Context, activityID uint) (*Activity, error) {
+	if activityID == constant.DefaultActivityID {
+		return DefaultActivity(l.lotteryConf.Limits()), nil
+	}
+	activity, err := l.activityRepo.GetWithCache(activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetActivity:%v", err)
+		return nil, fmt.Errorf("LotteryCase|GetActivity:%v", err)
+	}
+	if activity == nil || !activity.IsActive(time.Now()) {
+		return nil, nil
+	}
+	activity.FillDefault(l.lotteryConf.Limits())
+	return activity, nil
+}
+
+// GetPrize 按活动配置的策略选出中奖奖品，同时返回本次抽奖编码
+func (l *LotteryCase) GetPrize(ctx context.Context, activity *Activity) (*LotteryPrize, int, error) {
+	lotteryPrizeList, err := l.GetAllUsefulPrizes(ctx, activity)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|ToLotteryPrize:%v", err)
-		return nil, err
+		return nil, 0, err
 	}
-	for _, lotteryPrize := range lotteryPrizeList {
-		if lotteryPrize.PrizeCodeLow <= prizeCode &&
-			lotteryPrize.PrizeCodeHigh >= prizeCode {
-			// 中奖编码区间满足条件，说明可以中奖
-			if lotteryPrize.PrizeType < constant.PrizeTypeEntitySmall { // 如果非实物奖直接发，实物奖需要看是不是在黑名单外
-				prize = lotteryPrize
-				break
-			}
-		}
+	// 实物奖品不在V1发放，但要参与选奖，否则概率权重策略下其他奖品的中奖概率会变大，抽中实物奖品按未中奖处理
+	prize, prizeCode := NewPrizeSelector(activity).Select(ctx, activity, lotteryPrizeList)
+	if prize != nil && prize.PrizeType >= constant.PrizeTypeEntitySmall {
+		prize = nil
 	}
-	return prize, nil
+	return prize, prizeCode, nil
 }
 
-// GetPrizeWithCache 获取中奖的奖品类型
-func (l *LotteryCase) GetPrizeWithCache(ctx context.Context, prizeCode int) (*LotteryPrize, error) {
-	var prize *LotteryPrize
-	lotteryPrizeList, err := l.GetAllUsefulPrizesWithCache(ctx)
+// GetPrizeWithCache 按活动配置的策略选出中奖奖品，同时返回本次抽奖编码
+func (l *LotteryCase) GetPrizeWithCache(ctx context.Context, activity *Activity) (*LotteryPrize, int, error) {
+	lotteryPrizeList, err := l.GetAllUsefulPrizesWithCache(ctx, activity)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|ToLotteryPrize:%v", err)
-		return nil, err
+		return nil, 0, err
+	}
+	prize, prizeCode := NewPrizeSelector(activity).Select(ctx, activity, lotteryPrizeList)
+	return prize, prizeCode, nil
+}
+
+// GetPityPrizeWithCache 保底抽奖，从类型不低于活动保底类型、并且库存和奖品池都有剩余的奖品中随机选一个
+// 没有可发放的保底奖品时返回nil，中奖编码使用奖品编码区间的下限
+func (l *LotteryCase) GetPityPrizeWithCache(ctx context.Context, activity *Activity) (*LotteryPrize, int, error) {
+	lotteryPrizeList, err := l.GetAllUsefulPrizesWithCache(ctx, activity)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetPityPrizeWithCache:%v", err)
+		return nil, 0, err
 	}
+	candidates := make([]*LotteryPrize, 0, len(lotteryPrizeList))
 	for _, lotteryPrize := range lotteryPrizeList {
-		if lotteryPrize.PrizeCodeLow <= prizeCode &&
-			lotteryPrize.PrizeCodeHigh >= prizeCode {
-			// 中奖编码区间满足条件，说明可以中奖
-			// if lotteryPrize.PrizeType < constant.PrizeTypeEntitySmall { //如果非实物奖直接发，实物奖需要看是不是在黑名单外
-			prize = lotteryPrize
-			break
-			// }
+		if lotteryPrize.PrizeType < activity.PityPrizeType || lotteryPrize.PrizeNum < 0 {
+			continue
+		}
+		if lotteryPrize.PrizeNum > 0 {
+			if lotteryPrize.LeftNum <= 0 {
+				continue
+			}
+			num, err := l.GetPrizeNumWithPool(ctx, activity.Id, lotteryPrize.Id)
+			if err != nil {
+				return nil, 0, fmt.Errorf("LotteryCase|GetPityPrizeWithCache:%v", err)
+			}
+			if num <= 0 {
+				continue
+			}
 		}
+		candidates = append(candidates, lotteryPrize)
 	}
-	return prize, nil
+	if len(candidates) == 0 {
+		return nil, 0, nil
+	}
+	prize := candidates[utils.Random(len(candidates))]
+	return prize, prize.PrizeCodeLow, nil
 }
 
 // GiveOutPrize 发奖，奖品数量减1
 func (l *LotteryCase) GiveOutPrize(ctx context.Context, prizeID int) (bool, error) {
 	// 该类奖品的库存数量减1
-	ok, err := l.prizeRepo.DecrLeftNum(prizeID, 1)
+	ok, err := l.prizeRepo.DecrLeftNum(ctx, prizeID, 1)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GiveOutPrize err:%v", err)
 		return false, fmt.Errorf("LotteryCase|GiveOutPrize:%v", err)
@@ -88,9 +137,9 @@ func (l *LotteryCase) GiveOutPrize(ctx context.Context, prizeID int) (bool, erro
 }
 
 // GiveOutPrizeWithCache 发奖，奖品数量减1,并且同步更新缓存
-func (l *LotteryCase) GiveOutPrizeWithCache(ctx context.Context, prizeID int) (bool, error) {
+func (l *LotteryCase) GiveOutPrizeWithCache(ctx context.Context, activityID uint, prizeID int) (bool, error) {
 	// 该类奖品的库存数量减1
-	ok, err := l.prizeRepo.DecrLeftNum(prizeID, 1)
+	ok, err := l.prizeRepo.DecrLeftNum(ctx, prizeID, 1)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GiveOutPrize err:%v", err)
 		return false, fmt.Errorf("LotteryCase|GiveOutPrize:%v", err)
@@ -99,7 +148,7 @@ func (l *LotteryCase) GiveOutPrizeWithCache(ctx context.Context, prizeID int) (b
 		return false, nil
 	}
 	// 扣减库存成功
-	if err = l.prizeRepo.UpdateByCache(&Prize{Id: uint(prizeID)}); err != nil {
+	if err = l.prizeRepo.UpdateByCache(&Prize{Id: uint(prizeID), ActivityId: activityID}); err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GiveOutPrize|UpdateByCache err:%v", err)
 		return false, fmt.Errorf("LotteryCase|GiveOutPrize|UpdateByCache:%v", err)
 	}
@@ -107,8 +156,8 @@ func (l *LotteryCase) GiveOutPrizeWithCache(ctx context.Context, prizeID int) (b
 	return true, nil
 }
 
-func (l *LotteryCase) GiveOutPrizeWithPool(ctx context.Context, prizeID int) (bool, error) {
-	cnt, err := l.prizeRepo.DecrLeftNumByPool(prizeID)
+func (l *LotteryCase) GiveOutPrizeWithPool(ctx context.Context, activityID uint, prizeID int) (bool, error) {
+	cnt, err := l.prizeRepo.DecrLeftNumByPool(activityID, prizeID)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GiveOutPrizeWithPool err:%v", err)
 	}
@@ -121,8 +170,8 @@ func (l *LotteryCase) GiveOutPrizeWithPool(ctx context.Context, prizeID int) (bo
 }
 
 // GetAllUsefulPrizes 获取所有可用奖品
-func (l *LotteryCase) GetAllUsefulPrizes(ctx context.Context) ([]*LotteryPrize, error) {
-	list, err := l.prizeRepo.GetAllUsefulPrizeList()
+func (l *LotteryCase) GetAllUsefulPrizes(ctx context.Context, activity *Activity) ([]*LotteryPrize, error) {
+	list, err := l.prizeRepo.GetAllUsefulPrizeList(activity.Id)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GetAllUsefulPrizes:%v", err)
 		return nil, fmt.Errorf("LotteryCase|GetAllUsefulPrizes:%v", err)
@@ -130,38 +179,20 @@ func (l *LotteryCase) GetAllUsefulPrizes(ctx context.Context) ([]*LotteryPrize,
 	if len(list) == 0 {
 		return nil, nil
 	}
+	selector := NewPrizeSelector(activity)
 	lotteryPrizeList := make([]*LotteryPrize, 0)
 	for _, prize := range list {
-		codes := strings.Split(prize.PrizeCode, "-")
-		if len(codes) == 2 {
-			// 设置了获奖编码范围 a-b 才可以进行抽奖
-			codeA := codes[0]
-			codeB := codes[1]
-			low, err1 := strconv.Atoi(codeA)
-			high, err2 := strconv.Atoi(codeB)
-			if err1 == nil && err2 == nil && high >= low && low >= 0 && high < constant.PrizeCodeMax {
-				lotteryPrize := &LotteryPrize{
-					Id:            prize.Id,
-					Title:         prize.Title,
-					PrizeNum:      prize.PrizeNum,
-					LeftNum:       prize.LeftNum,
-					PrizeCodeLow:  low,
-					PrizeCodeHigh: high,
-					Img:           prize.Img,
-					DisplayOrder:  prize.DisplayOrder,
-					PrizeType:     prize.PrizeType,
-					PrizeProfile:  prize.PrizeProfile,
-				}
-				lotteryPrizeList = append(lotteryPrizeList, lotteryPrize)
-			}
+		// 奖品配置对活动的选择策略有效才可以进行抽奖
+		if lotteryPrize, ok := selector.Prepare(activity, prize); ok {
+			lotteryPrizeList = append(lotteryPrizeList, lotteryPrize)
 		}
 	}
 	return lotteryPrizeList, nil
 }
 
-func (l *LotteryCase) GetAllUsefulPrizesWithCache(ctx context.Context) ([]*LotteryPrize, error) {
+func (l *LotteryCase) GetAllUsefulPrizesWithCache(ctx context.Context, activity *Activity) ([]*LotteryPrize, error) {
 	// 筛选出符合条件的奖品列表
-	list, err := l.prizeRepo.GetAllUsefulPrizeListWithCache()
+	list, err := l.prizeRepo.GetAllUsefulPrizeListWithCache(activity.Id)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GetAllUsefulPrizes:%v", err)
 		return nil, fmt.Errorf("LotteryCase|GetAllUsefulPrizes:%v", err)
@@ -170,30 +201,12 @@ func (l *LotteryCase) GetAllUsefulPrizesWithCache(ctx context.Context) ([]*Lotte
 		return nil, nil
 	}
 	// 对db的prize做一个类型转换，转化为LotteryPrize
+	selector := NewPrizeSelector(activity)
 	lotteryPrizeList := make([]*LotteryPrize, 0)
 	for _, prize := range list {
-		codes := strings.Split(prize.PrizeCode, "-")
-		if len(codes) == 2 {
-			// 设置了获奖编码范围 a-b 才可以进行抽奖
-			codeA := codes[0]
-			codeB := codes[1]
-			low, err1 := strconv.Atoi(codeA)
-			high, err2 := strconv.Atoi(codeB)
-			if err1 == nil && err2 == nil && high >= low && low >= 0 && high < constant.PrizeCodeMax {
-				lotteryPrize := &LotteryPrize{
-					Id:            prize.Id,
-					Title:         prize.Title,
-					PrizeNum:      prize.PrizeNum,
-					LeftNum:       prize.LeftNum,
-					PrizeCodeLow:  low,
-					PrizeCodeHigh: high,
-					Img:           prize.Img,
-					DisplayOrder:  prize.DisplayOrder,
-					PrizeType:     prize.PrizeType,
-					PrizeProfile:  prize.PrizeProfile,
-				}
-				lotteryPrizeList = append(lotteryPrizeList, lotteryPrize)
-			}
+		// 奖品配置对活动的选择策略有效才可以进行抽奖
+		if lotteryPrize, ok := selector.Prepare(activity, prize); ok {
+			lotteryPrizeList = append(lotteryPrizeList, lotteryPrize)
 		}
 	}
 	return lotteryPrizeList, nil
@@ -231,8 +244,8 @@ func (l *LotteryCase) PrizeCouponDiff(ctx context.Context, prizeID int) (string,
 }
 
 // PrizeCouponDiffWithCache 带缓存的优惠券发奖，从缓存中拿出一个优惠券,要用缓存的话，需要项目启动的时候将优惠券导入到缓存
-func (l *LotteryCase) PrizeCouponDiffWithCache(ctx context.Context, prizeID int) (string, error) {
-	code, err := l.couponRepo.GetNextUsefulCouponFromCache(prizeID)
+func (l *LotteryCase) PrizeCouponDiffWithCache(ctx context.Context, activityID uint, prizeID int) (string, error) {
+	code, err := l.couponRepo.GetNextUsefulCouponFromCache(activityID, prizeID)
 	if err != nil {
 		return "", fmt.Errorf("LotteryCase|PrizeCouponDiffByCache:%v", err)
 	}
@@ -244,7 +257,7 @@ func (l *LotteryCase) PrizeCouponDiffWithCache(ctx context.Context, prizeID int)
 		Code:      code,
 		SysStatus: 2,
 	}
-	if err = l.couponRepo.UpdateByCode(code, &coupon, "sys_status"); err != nil {
+	if err = l.couponRepo.UpdateByCode(ctx, code, &coupon, "sys_status"); err != nil {
 		return "", fmt.Errorf("LotteryCase|PrizeCouponDiffByCache:%v", err)
 	}
 	return code, nil
@@ -253,50 +266,51 @@ func (l *LotteryCase) PrizeCouponDiffWithCache(ctx context.Context, prizeID int)
 func (l *LotteryCase) PrizeLargeBlackLimit(ctx context.Context, blackUser *BlackUser,
 	blackIp *BlackIp, lotteryUserInfo *LotteryUserInfo) error {
 	now := time.Now()
-	blackTime := constant.DefaultBlackTime
+	blackTime := l.lotteryConf.Limits().DefaultBlackTime
 	// 用户黑明单限制
 	if blackUser == nil || blackUser.UserId <= 0 {
 		blackUserInfo := &BlackUser{
 			UserId:    lotteryUserInfo.UserID,
 			UserName:  lotteryUserInfo.UserName,
-			BlackTime: now.Add(time.Second * time.Duration(blackTime)),
+			BlackTime: now.Add(blackTime),
 			// SysCreated: time.Time{},
 			// SysUpdated: time.Time{},
 			SysIp: lotteryUserInfo.IP,
 		}
-		if err := l.blackUserRepo.Create(blackUserInfo); err != nil {
+		if err := l.blackUserRepo.Create(ctx, blackUserInfo); err != nil {
 			log.ErrorContextf(ctx, "LotteryCase|PrizeLargeBlackLimit:%v", err)
 			return fmt.Errorf("LotteryCase|PrizeLargeBlackLimit:%v", err)
 		}
 	} else {
 		blackUserInfo := &BlackUser{
 			UserId:    lotteryUserInfo.UserID,
-			BlackTime: now.Add(time.Second * time.Duration(blackTime)),
+			BlackTime: now.Add(blackTime),
 		}
-		if err := l.blackUserRepo.Update(lotteryUserInfo.UserID, blackUserInfo, "black_time"); err != nil {
+		if err := l.blackUserRepo.Update(ctx, lotteryUserInfo.UserID, blackUserInfo, "black_time"); err != nil {
 			log.ErrorContextf(ctx, "LotteryCase|PrizeLargeBlackLimit:%v", err)
 			return fmt.Errorf("LotteryCase|PrizeLargeBlackLimit:%v", err)
 		}
 	}
-	// ip黑明但限制
+	// ip黑明但限制，按规范化的IP记录，同一个IPv6地址的不同写法对应同一条记录
+	ip := utils.NormalizeIp(lotteryUserInfo.IP)
 	if blackIp == nil || blackIp.Ip == "" {
 		blackIPInfo := &BlackIp{
-			Ip:        lotteryUserInfo.IP,
-			BlackTime: now.Add(time.Second * time.Duration(blackTime)),
+			Ip:        ip,
+			BlackTime: now.Add(blackTime),
 			// SysCreated: time.Time{},
 			// SysUpdated: time.Time{},
 		}
-		if err := l.blackIpRepo.Create(blackIPInfo); err != nil {
+		if err := l.blackIpRepo.Create(ctx, blackIPInfo); err != nil {
 			log.ErrorContextf(ctx, "LotteryCase|PrizeLargeBlackLimit:%v", err)
 			return fmt.Errorf("LotteryCase|PrizeLargeBlackLimit:%v", err)
 		}
 	} else {
 		blackIPInfo := &BlackIp{
-			Ip:        lotteryUserInfo.IP,
-			BlackTime: now.Add(time.Second * time.Duration(blackTime)),
+			Ip:        ip,
+			BlackTime: now.Add(blackTime),
 			// SysUpdated: time.Time{},
 		}
-		if err := l.blackIpRepo.Update(lotteryUserInfo.IP, blackIPInfo, "black_time"); err != nil {
+		if err := l.blackIpRepo.Update(ctx, ip, blackIPInfo, "black_time"); err != nil {
 			log.ErrorContextf(ctx, "LotteryCase|PrizeLargeBlackLimit:%v", err)
 			return fmt.Errorf("LotteryCase|PrizeLargeBlackLimit:%v", err)
 		}
@@ -304,9 +318,9 @@ func (l *LotteryCase) PrizeLargeBlackLimit(ctx context.Context, blackUser *Black
 	return nil
 }
 
-func (l *LotteryCase) GetPrizeNumWithPool(ctx context.Context, prizeID uint) (int, error) {
+func (l *LotteryCase) GetPrizeNumWithPool(ctx context.Context, activityID, prizeID uint) (int, error) {
 
-	num, err := l.prizeRepo.GetPrizePoolNum(prizeID)
+	num, err := l.prizeRepo.GetPrizePoolNum(activityID, prizeID)
 	if err != nil {
 		log.ErrorContextf(ctx, "LotteryCase|GetPrizeNumWithPool err: %v", err)
 		return 0, fmt.Errorf("LotteryCase|GetPrizeNumWithPool:%v", err)
@@ -314,23 +328,66 @@ func (l *LotteryCase) GetPrizeNumWithPool(ctx context.Context, prizeID uint) (in
 	return num, nil
 }
 
-func (l *LotteryCase) LotteryResult(ctx context.Context, prize *LotteryPrize, uid uint, userName, ip string, prizeCode int) error {
+// LotteryResult 记录中奖纪录，按配置同步或者异步写入
+func (l *LotteryCase) LotteryResult(ctx context.Context, prize *LotteryPrize, userInfo *LotteryUserInfo, prizeCode int) error {
+	delivery, err := l.writeResult(ctx, prize, userInfo, prizeCode)
+	if err != nil {
+		return err
+	}
+	// 中奖纪录已经写入，首次发放失败时由定时任务重试
+	if delivery != nil {
+		l.deliveryCase.Deliver(ctx, delivery)
+	}
+	return nil
+}
+
+// writeResult 写入中奖纪录，需要发放的虚拟奖品同时写入发放任务并返回
+func (l *LotteryCase) writeResult(ctx context.Context, prize *LotteryPrize, userInfo *LotteryUserInfo, prizeCode int) (*PrizeDelivery, error) {
 	result := Result{
-		PrizeId:   prize.Id,
-		PrizeName: prize.Title,
-		PrizeType: prize.PrizeType,
-		UserId:    uid,
-		UserName:  userName,
-		PrizeCode: uint(prizeCode),
-		PrizeData: prize.PrizeProfile,
+		ActivityId: userInfo.ActivityID,
+		PrizeId:    prize.Id,
+		PrizeName:  prize.Title,
+		PrizeType:  prize.PrizeType,
+		UserId:     userInfo.UserID,
+		UserName:   userInfo.UserName,
+		PrizeCode:  uint(prizeCode),
+		PrizeData:  prize.PrizeProfile,
 		// SysCreated: time.Now(),
-		SysIp:     ip,
-		SysStatus: 1,
+		SysIp:     userInfo.IP,
+		ClaimedIp: userInfo.ClaimedIP,
+		SysStatus: constant.ResultStatusNormal,
+	}
+	// 风控降级的用户中奖，标记为作弊等待人工复核
+	if userInfo.RiskAction != constant.RiskActionAllow {
+		result.SysStatus = constant.ResultStatusCheat
+	}
+	// 实物奖品需要在领取期限内填写收货信息
+	if IsEntityPrize(prize.PrizeType) {
+		deadline := time.Now().Add(constant.ClaimExpireTime)
+		result.FulfillStatus = constant.FulfillStatusWon
+		result.ClaimDeadline = &deadline
 	}
 
-	if err := l.resultRepo.Create(&result); err != nil {
+	if !l.deliveryCase.NeedDeliver(prize.PrizeType) {
+		if err := l.resultSink.Write(ctx, &result); err != nil {
+			log.ErrorContextf(ctx, "resultService|LotteryResult:%v", err)
+			return nil, fmt.Errorf("resultService|LotteryResult:%v", err)
+		}
+		return nil, nil
+	}
+	// 发放任务的幂等键由中奖纪录ID生成，中奖纪录不走异步写入
+	var delivery *PrizeDelivery
+	err := l.tm.InTx(ctx, func(ctx context.Context) error {
+		if err := l.resultRepo.Create(ctx, &result); err != nil {
+			return err
+		}
+		var err error
+		delivery, err = l.deliveryCase.CreateDelivery(ctx, &result, prize.PrizeProfile)
+		return err
+	})
+	if err != nil {
 		log.ErrorContextf(ctx, "resultService|LotteryResult:%v", err)
-		return fmt.Errorf("resultService|LotteryResult:%v", err)
+		return nil, fmt.Errorf("resultService|LotteryResult:%v", err)
 	}
-	return nil
+	return delivery, nil
 }
@@ -0,0 +1,148 @@
+package biz
+
+import (
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"net/netip"
+	"sync/atomic"
+	"time"
+)
+
+// LotteryLimits 抽奖限制，由配置文件的 lottery 部分生成
+type LotteryLimits struct {
+	UserPrizeMax     uint
+	IpLimitMax       uint
+	IpFrameSize      uint
+	UserFrameSize    uint
+	DefaultBlackTime time.Duration
+	PrizeCodeMax     uint
+	DayPrizeWeights  [100]int
+	PlanProfiles     map[string]*PlanProfile
+	Location         *time.Location // 业务时区
+	IpV4PrefixBits   int            // IP网段限制的IPv4前缀长度
+	IpV6PrefixBits   int            // IP网段限制的IPv6前缀长度
+	IpPrefixLimitMax uint           // 同一个网段每天最多抽奖次数，0不限制
+	IpAllowlist      []netip.Prefix // 不受IP每日次数限制的网段
+	Risk             *RiskRules     // 风控规则，nil表示不启用
+	DefaultActivity  *Activity      // 默认活动的选奖策略、保底和中奖上限，nil使用内置默认值
+}
+
+// DefaultLotteryLimits 没有配置时使用的默认限制
+func DefaultLotteryLimits() *LotteryLimits {
+	return &LotteryLimits{
+		UserPrizeMax:     constant.UserPrizeMax,
+		IpLimitMax:       constant.IpLimitMax,
+		IpFrameSize:      constant.IpFrameSize,
+		UserFrameSize:    constant.UserFrameSize,
+		DefaultBlackTime: constant.DefaultBlackTime * time.Second,
+		PrizeCodeMax:     constant.PrizeCodeMax,
+		DayPrizeWeights:  DayPrizeWeights,
+		Location:         defaultLocation(),
+		IpV4PrefixBits:   constant.IpV4PrefixBits,
+		IpV6PrefixBits:   constant.IpV6PrefixBits,
+	}
+}
+
+// defaultLocation 默认业务时区，时区数据不可用时使用固定的UTC+8
+func defaultLocation() *time.Location {
+	loc, err := time.LoadLocation(constant.DefaultTimezone)
+	if err != nil {
+		return time.FixedZone("CST", 8*3600)
+	}
+	return loc
+}
+
+// Today 业务时区的日期，如20220625
+func (l *LotteryLimits) Today(now time.Time) int {
+	y, m, d := now.In(l.Location).Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// DayNumTTL 每日次数缓存的过期时间，业务时区的当天结束后再保留DayNumCacheGrace
+// key中带有日期，跨天后使用新的key，旧的key到期自动删除，不需要定时任务重置
+func (l *LotteryLimits) DayNumTTL(now time.Time) time.Duration {
+	y, m, d := now.In(l.Location).Date()
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, l.Location)
+	return end.Sub(now) + constant.DayNumCacheGrace
+}
+
+// ClientIp 按IP限制规则解析的客户端IP
+type ClientIp struct {
+	Ip     string // 规范化的IP，无法解析时为原始字符串
+	Prefix string // 所在网段，如1.2.3.0/24，无法解析或者不限制网段时为空
+	Exempt bool   // 在白名单网段中，不受IP每日次数限制
+}
+
+// ParseClientIp 解析客户端IP，IPv4和IPv6按各自的前缀长度归到网段
+func (l *LotteryLimits) ParseClientIp(ip string) *ClientIp {
+	addr, err := utils.ParseIp(ip)
+	if err != nil {
+		return &ClientIp{Ip: ip}
+	}
+	c := &ClientIp{Ip: addr.String()}
+	for _, p := range l.IpAllowlist {
+		if p.Contains(addr) {
+			c.Exempt = true
+			return c
+		}
+	}
+	if l.IpPrefixLimitMax == 0 {
+		return c
+	}
+	bits := l.IpV6PrefixBits
+	if addr.Is4() {
+		bits = l.IpV4PrefixBits
+	}
+	if prefix, err := addr.Prefix(bits); err == nil {
+		c.Prefix = prefix.String()
+	}
+	return c
+}
+
+// ParseHourWeights 24小时的发奖权重转换为100的数组，0-23出现的次数为权重大小
+func ParseHourWeights(hourWeights []uint32) ([100]int, error) {
+	var dayWeights [100]int
+	if len(hourWeights) != 24 {
+		return dayWeights, fmt.Errorf("hour_weights must have 24 items, got %d", len(hourWeights))
+	}
+	i := 0
+	for h, w := range hourWeights {
+		if i+int(w) > len(dayWeights) {
+			return dayWeights, fmt.Errorf("sum of hour_weights must be 100")
+		}
+		for n := 0; n < int(w); n++ {
+			dayWeights[i] = h
+			i++
+		}
+	}
+	if i != len(dayWeights) {
+		return dayWeights, fmt.Errorf("sum of hour_weights must be 100, got %d", i)
+	}
+	return dayWeights, nil
+}
+
+// LotteryConfig 当前生效的抽奖限制，配置文件修改后通过 Update 替换
+type LotteryConfig struct {
+	limits atomic.Value
+}
+
+func NewLotteryConfig(limits *LotteryLimits) *LotteryConfig {
+	c := &LotteryConfig{}
+	c.limits.Store(limits)
+	return c
+}
+
+// Limits 获取当前的限制，返回值只读
+func (c *LotteryConfig) Limits() *LotteryLimits {
+	return c.limits.Load().(*LotteryLimits)
+}
+
+// Update 替换当前的限制，缓存分片数量修改后key会变化，只在启动时生效
+func (c *LotteryConfig) Update(limits *LotteryLimits) {
+	current := c.Limits()
+	next := *limits
+	next.IpFrameSize = current.IpFrameSize
+	next.UserFrameSize = current.UserFrameSize
+	c.limits.Store(&next)
+}
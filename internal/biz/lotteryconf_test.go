@@ -0,0 +1,85 @@
+package biz
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestParseHourWeights(t *testing.T) {
+	weights, err := ParseHourWeights([]uint32{3, 3, 3, 3, 3, 3, 3, 3, 7, 3, 3, 3, 3, 3, 7, 7, 7, 7, 3, 3, 7, 7, 3, 3})
+	if err != nil || weights != DayPrizeWeights {
+		t.Errorf("got %v, err %v", weights, err)
+	}
+	if _, err = ParseHourWeights([]uint32{100}); err == nil {
+		t.Error("less than 24 hours should be invalid")
+	}
+	if _, err = ParseHourWeights(make([]uint32, 24)); err == nil {
+		t.Error("sum less than 100 should be invalid")
+	}
+}
+
+func TestLotteryConfigUpdate(t *testing.T) {
+	c := NewLotteryConfig(DefaultLotteryLimits())
+	next := DefaultLotteryLimits()
+	next.UserPrizeMax = 5
+	next.IpFrameSize = 8
+	next.UserFrameSize = 16
+	c.Update(next)
+	limits := c.Limits()
+	if limits.UserPrizeMax != 5 || limits.IpFrameSize != DefaultLotteryLimits().IpFrameSize ||
+		limits.UserFrameSize != DefaultLotteryLimits().UserFrameSize {
+		t.Errorf("got %+v", limits)
+	}
+}
+
+func TestLotteryLimitsToday(t *testing.T) {
+	limits := DefaultLotteryLimits()
+	// UTC 16:30 已经是北京时间第二天 00:30
+	now := time.Date(2022, 6, 24, 16, 30, 0, 0, time.UTC)
+	if day := limits.Today(now); day != 20220625 {
+		t.Errorf("got %d", day)
+	}
+	if ttl := limits.DayNumTTL(now); ttl != 23*time.Hour+30*time.Minute+time.Hour {
+		t.Errorf("got %v", ttl)
+	}
+	limits.Location = time.UTC
+	if day := limits.Today(now); day != 20220624 {
+		t.Errorf("got %d", day)
+	}
+}
+
+func TestParseClientIp(t *testing.T) {
+	limits := DefaultLotteryLimits()
+	limits.IpPrefixLimitMax = 100
+	limits.IpAllowlist = []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")}
+	cases := map[string]ClientIp{
+		"192.168.1.20":         {Ip: "192.168.1.20", Prefix: "192.168.1.0/24"},
+		"::ffff:192.168.1.20":  {Ip: "192.168.1.20", Prefix: "192.168.1.0/24"},
+		"2001:DB8:0:1:2:3:4:5": {Ip: "2001:db8:0:1:2:3:4:5", Prefix: "2001:db8:0:1::/64"},
+		"203.0.113.9":          {Ip: "203.0.113.9", Exempt: true},
+		"unknown":              {Ip: "unknown"},
+	}
+	for ip, want := range cases {
+		if got := limits.ParseClientIp(ip); *got != want {
+			t.Errorf("ParseClientIp(%s) = %+v, want %+v", ip, *got, want)
+		}
+	}
+	limits.IpPrefixLimitMax = 0
+	if got := limits.ParseClientIp("192.168.1.20"); got.Prefix != "" {
+		t.Errorf("prefix should be empty without prefix limit, got %+v", got)
+	}
+}
+
+func TestDefaultActivityOverride(t *testing.T) {
+	limits := DefaultLotteryLimits()
+	if activity := DefaultActivity(limits); activity.PityThreshold != 0 || activity.SelectStrategy != 0 {
+		t.Errorf("built-in default activity should not set pity or strategy, got %+v", activity)
+	}
+	limits.DefaultActivity = &Activity{SelectStrategy: 1, NoPrizeWeight: 100, PityThreshold: 10, UserWinMax: 3}
+	activity := DefaultActivity(limits)
+	if activity.Id != 0 || activity.SelectStrategy != 1 || activity.NoPrizeWeight != 100 ||
+		activity.PityThreshold != 10 || activity.UserWinMax != 3 || activity.UserDayMax != limits.UserPrizeMax {
+		t.Errorf("default activity should use configured settings, got %+v", activity)
+	}
+}
@@ -5,6 +5,7 @@ import "time"
 // LotteryTimes 用户每日抽奖次数表
 type LotteryTimes struct {
 	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
 	UserId     uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:用户ID;NOT NULL" json:"user_id"`
 	Day        uint       `gorm:"column:day;type:int(10) unsigned;default:0;comment:日期，如：20220625;NOT NULL" json:"day"`
 	Num        uint       `gorm:"column:num;type:int(10) unsigned;default:0;comment:次数;NOT NULL" json:"num"`
@@ -18,15 +19,22 @@ func (l *LotteryTimes) TableName() string {
 
 type LotteryTimesRepo interface {
 	Get(id uint) (*LotteryTimes, error)
-	GetByUserIDAndDay(uid uint, day uint) (*LotteryTimes, error)
+	GetByUserIDAndDay(activityID, uid uint, day uint) (*LotteryTimes, error)
 	GetAll() ([]*LotteryTimes, error)
 	CountAll() (int64, error)
 	Create(lotteryTimes *LotteryTimes) error
 	Delete(id uint) error
 	DeleteAll() error
 	Update(lotteryTimes *LotteryTimes, cols ...string) error
-	IncrUserDayLotteryNum(uid uint) int64
-	InitUserLuckyNum(uid uint, num int64) error
-	ResetIPLotteryNums()
-	ResetUserLotteryNums()
+	// IncrUserDayLotteryNum 用户今日抽奖次数递增，计数按业务日期区分并在第二天过期
+	IncrUserDayLotteryNum(activityID, uid uint) int64
+	IncrIPDayLotteryNum(activityID uint, ip string) (int64, error)
+	// IncrIPPrefixDayLotteryNum 网段今日抽奖次数递增，prefix为ClientIp.Prefix
+	IncrIPPrefixDayLotteryNum(activityID uint, prefix string) (int64, error)
+	// GetUserDayLotteryNum 获取缓存的用户今天抽奖次数，缓存中没有记录时返回false
+	GetUserDayLotteryNum(activityID, uid uint) (int64, bool, error)
+	InitUserLuckyNum(activityID, uid uint, num int64) error
+	GetUserMissNum(activityID, uid uint) (int64, error)
+	IncrUserMissNum(activityID, uid uint) (int64, error)
+	ResetUserMissNum(activityID, uid uint) error
 }
@@ -0,0 +1,224 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+// DrawOutbox 抽奖事务消息表，记录redis中已经扣减的奖品池和券码
+// 与发奖的db事务一起提交，进程在抽奖中途退出时由定时任务按状态修复redis
+type DrawOutbox struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
+	UserId     uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:用户ID;NOT NULL" json:"user_id"`
+	PrizeId    uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID;NOT NULL" json:"prize_id"`
+	UsePool    bool       `gorm:"column:use_pool;type:tinyint(1);default:0;comment:是否扣减了奖品池;NOT NULL" json:"use_pool"`
+	PopCoupon  bool       `gorm:"column:pop_coupon;type:tinyint(1);default:0;comment:是否弹出了券码;NOT NULL" json:"pop_coupon"`
+	Status     uint       `gorm:"column:status;type:smallint(5) unsigned;default:0;comment:状态，0 待提交，1 已提交，2 已回滚，3 完成;NOT NULL" json:"status"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (o *DrawOutbox) TableName() string {
+	return "t_draw_outbox"
+}
+
+type DrawOutboxRepo interface {
+	Create(ctx context.Context, outbox *DrawOutbox) error
+	// UpdateStatus 只有当前状态为from时才修改为to，返回是否修改成功
+	UpdateStatus(ctx context.Context, id uint, from, to uint) (bool, error)
+	// GetStaleList 获取状态为status并且超过before未更新的事务消息
+	GetStaleList(status uint, before time.Time, limit int) ([]*DrawOutbox, error)
+}
+
+// errDrawPrizeNotEnough 数据库库存不足，回滚发奖事务
+var errDrawPrizeNotEnough = errors.New("prize not enough")
+
+// errDrawOutboxExpired 事务消息已经被定时任务回滚，不能再提交
+var errDrawOutboxExpired = errors.New("draw outbox expired")
+
+// DrawCommit 发奖事务需要写入db的内容
+type DrawCommit struct {
+	Prize      *LotteryPrize
+	PrizeCode  int
+	CouponCode string
+	UserInfo   *LotteryUserInfo
+	BlackUser  *BlackUser
+	BlackIp    *BlackIp
+}
+
+// PrepareDraw 原子抽奖需要扣减奖品池或者弹出券码时，先写入待提交的事务消息
+func (l *LotteryCase) PrepareDraw(ctx context.Context, req *DrawReq) error {
+	if !req.UsePool && !req.PopCoupon {
+		return nil
+	}
+	outbox := &DrawOutbox{
+		ActivityId: req.ActivityId,
+		UserId:     req.UserId,
+		PrizeId:    req.PrizeId,
+		UsePool:    req.UsePool,
+		PopCoupon:  req.PopCoupon,
+		Status:     constant.OutboxStatusPending,
+	}
+	if err := l.drawOutboxRepo.Create(ctx, outbox); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|PrepareDraw:%v", err)
+		return fmt.Errorf("LotteryCase|PrepareDraw:%v", err)
+	}
+	req.OutboxId = outbox.Id
+	return nil
+}
+
+// CommitDraw 在一个事务中扣减库存、发放券码、记录中奖纪录、更新黑名单并提交事务消息
+// 数据库库存不足时回滚并返回false
+func (l *LotteryCase) CommitDraw(ctx context.Context, req *DrawReq, commit *DrawCommit) (bool, error) {
+	prize := commit.Prize
+	// 需要发放的虚拟奖品在事务中写入中奖纪录和发放任务，提交后再发放
+	syncResult := !l.resultSink.Async() || l.deliveryCase.NeedDeliver(prize.PrizeType)
+	var delivery *PrizeDelivery
+	err := l.tm.InTx(ctx, func(ctx context.Context) error {
+		if prize.PrizeNum > 0 {
+			ok, err := l.GiveOutPrize(ctx, int(prize.Id))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errDrawPrizeNotEnough
+			}
+		}
+		if prize.PrizeType == constant.PrizeTypeCouponDiff {
+			if err := l.UseCoupon(ctx, commit.CouponCode); err != nil {
+				return err
+			}
+		}
+		if syncResult {
+			var err error
+			if delivery, err = l.writeResult(ctx, prize, commit.UserInfo, commit.PrizeCode); err != nil {
+				return err
+			}
+		}
+		if prize.PrizeType == constant.PrizeTypeEntityLarge {
+			if err := l.PrizeLargeBlackLimit(ctx, commit.BlackUser, commit.BlackIp, commit.UserInfo); err != nil {
+				return err
+			}
+		}
+		if req.OutboxId == 0 {
+			return nil
+		}
+		ok, err := l.drawOutboxRepo.UpdateStatus(ctx, req.OutboxId,
+			constant.OutboxStatusPending, constant.OutboxStatusCommitted)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errDrawOutboxExpired
+		}
+		return nil
+	})
+	if errors.Is(err, errDrawPrizeNotEnough) {
+		return false, nil
+	}
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|CommitDraw:%v", err)
+		return false, fmt.Errorf("LotteryCase|CommitDraw:%v", err)
+	}
+	l.finishDraw(ctx, req)
+	// 异步写入中奖纪录不参与事务，写入失败时已经落盘，不影响发奖结果
+	if !syncResult {
+		l.LotteryResult(ctx, prize, commit.UserInfo, commit.PrizeCode)
+	}
+	if delivery != nil {
+		l.deliveryCase.Deliver(ctx, delivery)
+	}
+	return true, nil
+}
+
+// AbortDraw 发奖失败，回滚事务消息并归还奖品池数量和券码
+func (l *LotteryCase) AbortDraw(ctx context.Context, req *DrawReq) {
+	if req.OutboxId == 0 {
+		return
+	}
+	ok, err := l.drawOutboxRepo.UpdateStatus(ctx, req.OutboxId,
+		constant.OutboxStatusPending, constant.OutboxStatusAborted)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|AbortDraw outbox=%d err:%v", req.OutboxId, err)
+		return
+	}
+	// 事务消息已经被提交或者回滚，不能重复归还
+	if !ok {
+		return
+	}
+	l.compensateDraw(ctx, req)
+}
+
+// compensateDraw 归还奖品池数量和券码，成功后事务消息标记完成，失败时由定时任务重试
+func (l *LotteryCase) compensateDraw(ctx context.Context, req *DrawReq) {
+	if err := l.drawRepo.Compensate(req); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|compensateDraw req=%+v err:%v", req, err)
+		return
+	}
+	if _, err := l.drawOutboxRepo.UpdateStatus(ctx, req.OutboxId,
+		constant.OutboxStatusAborted, constant.OutboxStatusDone); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|compensateDraw outbox=%d err:%v", req.OutboxId, err)
+	}
+}
+
+// finishDraw 事务已经提交，清理redis中的待提交标记，失败时由定时任务重试
+func (l *LotteryCase) finishDraw(ctx context.Context, req *DrawReq) {
+	if req.OutboxId == 0 {
+		return
+	}
+	if err := l.drawRepo.Finish(req); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|finishDraw req=%+v err:%v", req, err)
+		return
+	}
+	if _, err := l.drawOutboxRepo.UpdateStatus(ctx, req.OutboxId,
+		constant.OutboxStatusCommitted, constant.OutboxStatusDone); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|finishDraw outbox=%d err:%v", req.OutboxId, err)
+	}
+}
+
+// RelayDrawOutbox 修复超时未处理的事务消息
+// 超时未提交的回滚并归还redis扣减，已回滚的重试归还，已提交的清理待提交标记
+func (l *LotteryCase) RelayDrawOutbox(ctx context.Context) error {
+	before := time.Now().Add(-constant.OutboxRelayTimeout)
+	list, err := l.drawOutboxRepo.GetStaleList(constant.OutboxStatusPending, before, constant.OutboxRelayLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|RelayDrawOutbox:%v", err)
+		return fmt.Errorf("LotteryCase|RelayDrawOutbox:%v", err)
+	}
+	for _, outbox := range list {
+		l.AbortDraw(ctx, outbox.drawReq())
+	}
+	list, err = l.drawOutboxRepo.GetStaleList(constant.OutboxStatusAborted, before, constant.OutboxRelayLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|RelayDrawOutbox:%v", err)
+		return fmt.Errorf("LotteryCase|RelayDrawOutbox:%v", err)
+	}
+	for _, outbox := range list {
+		l.compensateDraw(ctx, outbox.drawReq())
+	}
+	list, err = l.drawOutboxRepo.GetStaleList(constant.OutboxStatusCommitted, before, constant.OutboxRelayLimit)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|RelayDrawOutbox:%v", err)
+		return fmt.Errorf("LotteryCase|RelayDrawOutbox:%v", err)
+	}
+	for _, outbox := range list {
+		l.finishDraw(ctx, outbox.drawReq())
+	}
+	return nil
+}
+
+func (o *DrawOutbox) drawReq() *DrawReq {
+	return &DrawReq{
+		ActivityId: o.ActivityId,
+		UserId:     o.UserId,
+		PrizeId:    o.PrizeId,
+		UsePool:    o.UsePool,
+		PopCoupon:  o.PopCoupon,
+		OutboxId:   o.Id,
+	}
+}
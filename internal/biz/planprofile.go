@@ -0,0 +1,209 @@
+package biz
+
+import (
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"time"
+)
+
+// BurstWindow 集中发奖时段，Start为从0点开始的分钟数
+type BurstWindow struct {
+	Start   int `json:"start"`
+	Minutes int `json:"minutes"`
+	Percent int `json:"percent"`
+}
+
+// PlanProfile 发奖计划的分布曲线：每小时的发奖权重，周日到周六每天的发奖倍数，集中发奖时段
+type PlanProfile struct {
+	HourWeights        [24]int
+	WeekdayMultipliers [7]float64
+	Bursts             []BurstWindow
+}
+
+// NewPlanProfile 由100的发奖权重数组生成分布曲线，每天的倍数都为1
+func NewPlanProfile(dayWeights [100]int) *PlanProfile {
+	profile := &PlanProfile{}
+	for _, h := range dayWeights {
+		profile.HourWeights[h]++
+	}
+	for i := range profile.WeekdayMultipliers {
+		profile.WeekdayMultipliers[i] = 1
+	}
+	return profile
+}
+
+// ParseBurstWindow 解析集中发奖时段，start格式为15:04，时段不能跨天
+func ParseBurstWindow(start string, duration time.Duration, percent uint32) (BurstWindow, error) {
+	t, err := time.Parse("15:04", start)
+	if err != nil {
+		return BurstWindow{}, fmt.Errorf("burst start %q must be formatted as 15:04", start)
+	}
+	burst := BurstWindow{
+		Start:   t.Hour()*60 + t.Minute(),
+		Minutes: int(duration / time.Minute),
+		Percent: int(percent),
+	}
+	if burst.Minutes <= 0 {
+		return burst, fmt.Errorf("burst %s duration must be at least 1m", start)
+	}
+	if burst.Start+burst.Minutes > 24*60 {
+		return burst, fmt.Errorf("burst %s lasting %v crosses midnight", start, duration)
+	}
+	if burst.Percent <= 0 || burst.Percent > 100 {
+		return burst, fmt.Errorf("burst %s percent must be within (0, 100]", start)
+	}
+	return burst, nil
+}
+
+// Validate 校验分布曲线，集中发奖的百分比总和不超过100
+func (p *PlanProfile) Validate() error {
+	hourTotal := 0
+	for _, w := range p.HourWeights {
+		hourTotal += w
+	}
+	if hourTotal <= 0 {
+		return fmt.Errorf("sum of hour_weights must be greater than 0")
+	}
+	dayTotal := 0.0
+	for _, m := range p.WeekdayMultipliers {
+		if m < 0 {
+			return fmt.Errorf("weekday_multipliers must not be negative")
+		}
+		dayTotal += m
+	}
+	if dayTotal <= 0 {
+		return fmt.Errorf("sum of weekday_multipliers must be greater than 0")
+	}
+	percent := 0
+	for _, burst := range p.Bursts {
+		percent += burst.Percent
+	}
+	if percent > 100 {
+		return fmt.Errorf("sum of burst percent must not exceed 100, got %d", percent)
+	}
+	return nil
+}
+
+// GetPlanProfile 按名称获取分布曲线，名称为空时使用default，没有配置default时由hour_weights生成
+func (l *LotteryLimits) GetPlanProfile(name string) (*PlanProfile, bool) {
+	if name == "" {
+		name = constant.DefaultPlanProfile
+	}
+	if profile, ok := l.PlanProfiles[name]; ok {
+		return profile, true
+	}
+	if name == constant.DefaultPlanProfile {
+		return NewPlanProfile(l.DayPrizeWeights), true
+	}
+	return nil, false
+}
+
+// PrizePlanPreview 发奖计划预览，和ResetPrizePlan的计算方式一致但不保存
+type PrizePlanPreview struct {
+	PrizeId    uint             `json:"prize_id"`
+	Profile    string           `json:"profile"`
+	PrizeNum   int              `json:"prize_num"`
+	PrizeBegin time.Time        `json:"prize_begin"`
+	PrizeEnd   time.Time        `json:"prize_end"`
+	PlanList   []*TimePrizeInfo `json:"plan_list"`
+}
+
+// newPrizePlan 按分布曲线计算从now开始days天内每分钟要发出的奖品数，小时和星期按now所在的时区计算
+// 调用方传入业务时区的时间，每天的奖品数按当天开始时是星期几的倍数分配
+func newPrizePlan(now time.Time, days, num int, profile *PlanProfile) []*PrizePlan {
+	start := now.Truncate(time.Minute)
+	dayWeights := make([]int, days)
+	for day := range dayWeights {
+		weekday := start.AddDate(0, 0, day).Weekday()
+		dayWeights[day] = int(profile.WeekdayMultipliers[weekday]*100 + 0.5)
+	}
+	result := make([]*PrizePlan, 0)
+	for day, dayNum := range splitByWeights(num, dayWeights) {
+		if dayNum <= 0 {
+			continue
+		}
+		dayPlan := prizePlanOneDay(dayNum, profile)
+		dayStart := start.Add(time.Duration(day) * 24 * time.Hour)
+		for m := 0; m < 24*60; m++ {
+			t := dayStart.Add(time.Duration(m) * time.Minute)
+			n := dayPlan[t.Hour()][t.Minute()]
+			if n <= 0 {
+				continue
+			}
+			result = append(result, &PrizePlan{
+				ReleaseAt: t,
+				Num:       n,
+			})
+		}
+	}
+	return result
+}
+
+// toTimePrizeInfo 发奖计划转换为[时间:数量]二元组的数组
+func toTimePrizeInfo(planList []*PrizePlan) []*TimePrizeInfo {
+	result := make([]*TimePrizeInfo, 0, len(planList))
+	for _, plan := range planList {
+		result = append(result, &TimePrizeInfo{
+			Time: plan.ReleaseAt.Format(constant.SysTimeFormat),
+			Num:  plan.Num,
+		})
+	}
+	return result
+}
+
+// prizePlanOneDay 计算一天内具体到每小时每分钟应该发出的奖品，[hour][minute]num
+// 先按百分比分出集中发奖时段的奖品，剩下的按小时权重分配，每个小时内的每一分钟概率一样
+func prizePlanOneDay(num int, profile *PlanProfile) [24][60]int {
+	var plan [24][60]int
+	left := num
+	for _, burst := range profile.Bursts {
+		burstNum := num * burst.Percent / 100
+		left -= burstNum
+		for i, n := range splitByWeights(burstNum, make([]int, burst.Minutes)) {
+			m := burst.Start + i
+			plan[m/60][m%60] += n
+		}
+	}
+	for h, hourNum := range splitByWeights(left, profile.HourWeights[:]) {
+		for m, n := range splitByWeights(hourNum, make([]int, 60)) {
+			plan[h][m] += n
+		}
+	}
+	return plan
+}
+
+// splitByWeights 按权重拆分num，先按比例取整，剩下的按权重随机分配，权重都为0时平均分配
+func splitByWeights(num int, weights []int) []int {
+	result := make([]int, len(weights))
+	if num <= 0 || len(weights) == 0 {
+		return result
+	}
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		weights = make([]int, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = len(weights)
+	}
+	left := num
+	for i, w := range weights {
+		result[i] = num * w / total
+		left -= result[i]
+	}
+	for ; left > 0; left-- {
+		r := utils.Random(total)
+		for i, w := range weights {
+			if r < w {
+				result[i]++
+				break
+			}
+			r -= w
+		}
+	}
+	return result
+}
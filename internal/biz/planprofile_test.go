@@ -0,0 +1,91 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func sumPlan(list []*PrizePlan) int {
+	total := 0
+	for _, info := range list {
+		total += info.Num
+	}
+	return total
+}
+
+func TestNewPrizePlan(t *testing.T) {
+	profile := NewPlanProfile(DayPrizeWeights)
+	now := time.Now()
+	for _, num := range []int{1, 7, 99, 1000, 100000} {
+		list := newPrizePlan(now, 3, num, profile)
+		if total := sumPlan(list); total != num {
+			t.Errorf("num %d got %d", num, total)
+		}
+		for i := 1; i < len(list); i++ {
+			if !list[i].ReleaseAt.After(list[i-1].ReleaseAt) {
+				t.Fatalf("plan not sorted at %d: %v %v", i, list[i-1].ReleaseAt, list[i].ReleaseAt)
+			}
+		}
+	}
+}
+
+func TestNewPrizePlanBurst(t *testing.T) {
+	burst, err := ParseBurstWindow("12:00", 10*time.Minute, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := NewPlanProfile(DayPrizeWeights)
+	profile.Bursts = []BurstWindow{burst}
+	list := newPrizePlan(time.Now(), 1, 1000, profile)
+	burstNum := 0
+	for _, info := range list {
+		tm := info.ReleaseAt
+		if tm.Hour() == 12 && tm.Minute() < 10 {
+			burstNum += info.Num
+		}
+	}
+	// 集中发奖时段之外按小时权重分配的奖品也可能落在12:00-12:10
+	if burstNum < 500 {
+		t.Errorf("burst got %d, want at least 500", burstNum)
+	}
+	if _, err = ParseBurstWindow("23:55", 10*time.Minute, 50); err == nil {
+		t.Error("burst crossing midnight should be invalid")
+	}
+}
+
+func TestNewPrizePlanWeekday(t *testing.T) {
+	profile := NewPlanProfile(DayPrizeWeights)
+	profile.WeekdayMultipliers = [7]float64{}
+	profile.WeekdayMultipliers[time.Saturday] = 1
+	start := time.Now().In(DefaultLotteryLimits().Location)
+	list := newPrizePlan(start, 7, 700, profile)
+	for _, info := range list {
+		tm := info.ReleaseAt
+		// 每天的奖品分布在当天开始后的24小时内
+		day := int(tm.Sub(start.Truncate(time.Minute)) / (24 * time.Hour))
+		if start.AddDate(0, 0, day).Weekday() != time.Saturday {
+			t.Fatalf("%v belongs to day %d which is not saturday", tm, day)
+		}
+	}
+	if total := sumPlan(list); total != 700 {
+		t.Errorf("got %d", total)
+	}
+}
+
+func TestPlanProfileValidate(t *testing.T) {
+	profile := NewPlanProfile(DayPrizeWeights)
+	if err := profile.Validate(); err != nil {
+		t.Error(err)
+	}
+	profile.Bursts = []BurstWindow{{Start: 720, Minutes: 10, Percent: 60}, {Start: 1200, Minutes: 10, Percent: 60}}
+	if err := profile.Validate(); err == nil {
+		t.Error("burst percent over 100 should be invalid")
+	}
+	limits := DefaultLotteryLimits()
+	if _, ok := limits.GetPlanProfile(""); !ok {
+		t.Error("default profile should exist")
+	}
+	if _, ok := limits.GetPlanProfile("flash"); ok {
+		t.Error("flash profile is not configured")
+	}
+}
@@ -0,0 +1,133 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+// PrizePoolLedger 奖品池放入记录，按发奖计划ID唯一，每个计划只会放入奖品池一次
+type PrizePoolLedger struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	PlanId     uint       `gorm:"column:plan_id;type:int(10) unsigned;default:0;comment:发奖计划ID，每个计划只放入一次;NOT NULL" json:"plan_id"`
+	PrizeId    uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID;NOT NULL" json:"prize_id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
+	ReleaseAt  time.Time  `gorm:"column:release_at;type:datetime;comment:计划时间;NOT NULL" json:"release_at"`
+	Num        int        `gorm:"column:num;type:int(11);default:0;comment:奖品数量;NOT NULL" json:"num"`
+	Applied    uint       `gorm:"column:applied;type:tinyint(3) unsigned;default:0;comment:是否已加到奖品池，0 否，1 是;NOT NULL" json:"applied"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (l *PrizePoolLedger) TableName() string {
+	return "t_prize_pool_ledger"
+}
+
+type PrizePoolLedgerRepo interface {
+	// CreateIgnore 写入放入记录，发奖计划已经写过的跳过
+	// 同一分钟内重新生成的计划和已放入的计划时间相同，所以按计划ID去重
+	CreateIgnore(ctx context.Context, list []*PrizePoolLedger) error
+	GetPendingList(limit int) ([]*PrizePoolLedger, error)
+	// ApplyToPool 把记录的数量加到奖品池并标记为已生效，奖品池按记录ID去重，重复调用只会加一次
+	ApplyToPool(ledger *PrizePoolLedger) error
+	// SumNum 奖品计划时间在since之后的放入数量，以及其中已经加到奖品池的数量
+	SumNum(prizeID uint, since time.Time) (int, int, error)
+}
+
+// PrizeReconcile 奖品数量对账，三个差值都为0表示数量一致
+// PoolDiff = 奖品池 + 本周期发出 - 本周期已加到奖品池，PlanDiff = 本周期放入 + 待放入 - 奖品数量，LeftDiff = 剩余数量 + 全部发出 - 奖品数量
+// 没有发奖周期的奖品不走计划，PoolDiff = 奖品池 - 剩余数量，PlanDiff为0
+type PrizeReconcile struct {
+	PrizeId       uint   `json:"prize_id"`
+	ActivityId    uint   `json:"activity_id"`
+	Title         string `json:"title"`
+	PrizeNum      int    `json:"prize_num"`
+	LeftNum       int    `json:"left_num"`
+	PoolNum       int    `json:"pool_num"`
+	PendingNum    int    `json:"pending_num"`
+	ReleasedNum   int    `json:"released_num"`
+	AppliedNum    int    `json:"applied_num"`
+	IssuedNum     int    `json:"issued_num"`
+	PlanIssuedNum int    `json:"plan_issued_num"`
+	PoolDiff      int    `json:"pool_diff"`
+	PlanDiff      int    `json:"plan_diff"`
+	LeftDiff      int    `json:"left_diff"`
+	Ok            bool   `json:"ok"`
+}
+
+// newPrizeReconcile 计算对账差值
+func newPrizeReconcile(prize *Prize, poolNum, pendingNum, releasedNum, appliedNum, issuedNum, planIssuedNum int) *PrizeReconcile {
+	r := &PrizeReconcile{
+		PrizeId:       prize.Id,
+		ActivityId:    prize.ActivityId,
+		Title:         prize.Title,
+		PrizeNum:      prize.PrizeNum,
+		LeftNum:       prize.LeftNum,
+		PoolNum:       poolNum,
+		PendingNum:    pendingNum,
+		ReleasedNum:   releasedNum,
+		AppliedNum:    appliedNum,
+		IssuedNum:     issuedNum,
+		PlanIssuedNum: planIssuedNum,
+	}
+	if prize.PrizeTime > 0 {
+		r.PoolDiff = poolNum + planIssuedNum - appliedNum
+		r.PlanDiff = releasedNum + pendingNum - prize.PrizeNum
+	} else {
+		r.PoolDiff = poolNum - prize.LeftNum
+	}
+	r.LeftDiff = prize.LeftNum + issuedNum - prize.PrizeNum
+	r.Ok = r.PoolDiff == 0 && r.PlanDiff == 0 && r.LeftDiff == 0
+	return r
+}
+
+// ReconcilePrizePool 限量奖品的奖品池、发出数量、剩余数量和奖品数量对账，activityID为nil时对账全部活动
+// 中奖纪录异步写入时，还在队列中的纪录不计入发出数量
+func (a *AdminCase) ReconcilePrizePool(ctx context.Context, activityID *uint) ([]*PrizeReconcile, error) {
+	prizeList, err := a.prizeRepo.GetAll()
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|GetAll err:%v", err)
+		return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+	}
+	poolMap := make(map[uint]map[uint]int)
+	list := make([]*PrizeReconcile, 0)
+	for _, prize := range prizeList {
+		if prize.PrizeNum <= 0 || (activityID != nil && prize.ActivityId != *activityID) {
+			continue
+		}
+		pool, ok := poolMap[prize.ActivityId]
+		if !ok {
+			if pool, err = a.prizeRepo.GetPrizePoolNums(prize.ActivityId); err != nil {
+				log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|GetPrizePoolNums err:%v", err)
+				return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+			}
+			poolMap[prize.ActivityId] = pool
+		}
+		// 计划的第一个时间点是重置时间所在的分钟
+		since := prize.PrizeBegin.Truncate(time.Minute)
+		pendingNum, err := a.prizePlanRepo.SumPending(prize.Id)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|SumPending err:%v", err)
+			return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+		}
+		releasedNum, appliedNum, err := a.poolLedgerRepo.SumNum(prize.Id, since)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|SumNum err:%v", err)
+			return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+		}
+		issuedNum, err := a.resultRepo.CountIssued(prize.Id, time.Time{})
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|CountIssued err:%v", err)
+			return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+		}
+		planIssuedNum, err := a.resultRepo.CountIssued(prize.Id, since)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ReconcilePrizePool|CountIssued err:%v", err)
+			return nil, fmt.Errorf("adminCase|ReconcilePrizePool:%v", err)
+		}
+		list = append(list, newPrizeReconcile(prize, pool[prize.Id], pendingNum, releasedNum, appliedNum,
+			int(issuedNum), int(planIssuedNum)))
+	}
+	return list, nil
+}
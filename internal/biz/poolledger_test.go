@@ -0,0 +1,22 @@
+package biz
+
+import "testing"
+
+func TestNewPrizeReconcile(t *testing.T) {
+	prize := &Prize{Id: 1, PrizeNum: 100, LeftNum: 70, PrizeTime: 7}
+	// 放入50个，40个已经加到奖品池，本周期发出30个，奖品池剩10个
+	r := newPrizeReconcile(prize, 10, 50, 50, 40, 30, 30)
+	if !r.Ok {
+		t.Errorf("should be ok, got %+v", r)
+	}
+	// 奖品池重复放入了5个
+	r = newPrizeReconcile(prize, 15, 50, 50, 40, 30, 30)
+	if r.Ok || r.PoolDiff != 5 {
+		t.Errorf("pool diff should be 5, got %+v", r)
+	}
+	prize = &Prize{Id: 2, PrizeNum: 100, LeftNum: 70}
+	r = newPrizeReconcile(prize, 70, 0, 0, 0, 30, 30)
+	if !r.Ok {
+		t.Errorf("prize without plan should be ok, got %+v", r)
+	}
+}
@@ -0,0 +1,40 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errPlanReleased 发奖计划已经被其他实例放入奖品池，回滚这次放入
+var errPlanReleased = errors.New("prize plan released")
+
+// PrizePlan 发奖计划表，每一行是一个时间点要放入奖品池的奖品数量
+type PrizePlan struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	PrizeId    uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID;NOT NULL" json:"prize_id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
+	ReleaseAt  time.Time  `gorm:"column:release_at;type:datetime;comment:放入奖品池的时间;NOT NULL" json:"release_at"`
+	Num        int        `gorm:"column:num;type:int(11);default:0;comment:奖品数量;NOT NULL" json:"num"`
+	Released   uint       `gorm:"column:released;type:tinyint(3) unsigned;default:0;comment:是否已放入奖品池，0 否，1 是;NOT NULL" json:"released"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (p *PrizePlan) TableName() string {
+	return "t_prize_plan"
+}
+
+type PrizePlanRepo interface {
+	// Replace 删除奖品原有的发奖计划，写入新的计划
+	Replace(ctx context.Context, prizeID uint, list []*PrizePlan) error
+	Clear(ctx context.Context, prizeID uint) error
+	// GetPlannedPrizeIds 有发奖计划的奖品ID，包括已经全部放入奖品池的
+	GetPlannedPrizeIds() (map[uint]bool, error)
+	// GetDueList 获取奖品到期还没有放入奖品池的计划
+	GetDueList(prizeID uint, now time.Time) ([]*PrizePlan, error)
+	// MarkReleased 把还没有放入奖品池的计划标记为已放入，返回更新的行数
+	MarkReleased(ctx context.Context, ids []uint) (int64, error)
+	// SumPending 奖品还没有放入奖品池的数量
+	SumPending(prizeID uint) (int, error)
+}
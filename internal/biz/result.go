@@ -1,10 +1,14 @@
 package biz
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Result 抽奖记录表
 type Result struct {
 	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID，0表示默认活动;NOT NULL" json:"activity_id"`
 	PrizeId    uint       `gorm:"column:prize_id;type:int(10) unsigned;default:0;comment:奖品ID，关联lt_prize表;NOT NULL" json:"prize_id"`
 	PrizeName  string     `gorm:"column:prize_name;type:varchar(255);comment:奖品名称;NOT NULL" json:"prize_name"`
 	PrizeType  uint       `gorm:"column:prize_type;type:int(10) unsigned;default:0;comment:奖品类型，同lt_prize. gtype;NOT NULL" json:"prize_type"`
@@ -13,8 +17,19 @@ type Result struct {
 	PrizeCode  uint       `gorm:"column:prize_code;type:int(10) unsigned;default:0;comment:抽奖编号（4位的随机数）;NOT NULL" json:"prize_code"`
 	PrizeData  string     `gorm:"column:prize_data;type:varchar(255);comment:获奖信息;NOT NULL" json:"prize_data"`
 	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间;NOT NULL" json:"sys_created"`
-	SysIp      string     `gorm:"column:sys_ip;type:varchar(50);comment:用户抽奖的IP;NOT NULL" json:"sys_ip"`
+	SysIp      string     `gorm:"column:sys_ip;type:varchar(50);comment:用户抽奖的IP，根据连接地址和可信代理确定;NOT NULL" json:"sys_ip"`
+	ClaimedIp  string     `gorm:"column:claimed_ip;type:varchar(50);comment:请求中声明的IP;NOT NULL" json:"claimed_ip"`
 	SysStatus  uint       `gorm:"column:sys_status;type:smallint(5) unsigned;default:0;comment:状态，0 正常，1删除，2作弊;NOT NULL" json:"sys_status"`
+	// 实物奖品履约信息
+	FulfillStatus uint       `gorm:"column:fulfill_status;type:smallint(5) unsigned;default:0;comment:履约状态，0 无需履约，1 待领取，2 已领取，3 已发货，4 已签收，5 已过期，6 已回库;NOT NULL" json:"fulfill_status"`
+	ClaimDeadline *time.Time `gorm:"column:claim_deadline;type:datetime;default null;comment:领取截止时间" json:"claim_deadline"`
+	ContactName   string     `gorm:"column:contact_name;type:varchar(50);comment:收货人;NOT NULL" json:"contact_name"`
+	ContactPhone  string     `gorm:"column:contact_phone;type:varchar(20);comment:收货人电话;NOT NULL" json:"contact_phone"`
+	Address       string     `gorm:"column:address;type:varchar(255);comment:收货地址;NOT NULL" json:"address"`
+	TrackingNo    string     `gorm:"column:tracking_no;type:varchar(64);comment:物流单号;NOT NULL" json:"tracking_no"`
+	ClaimTime     *time.Time `gorm:"column:claim_time;type:datetime;default null;comment:领取时间" json:"claim_time"`
+	ShipTime      *time.Time `gorm:"column:ship_time;type:datetime;default null;comment:发货时间" json:"ship_time"`
+	DeliverTime   *time.Time `gorm:"column:deliver_time;type:datetime;default null;comment:签收时间" json:"deliver_time"`
 }
 
 func (r *Result) TableName() string {
@@ -24,10 +39,31 @@ func (r *Result) TableName() string {
 type ResultRepo interface {
 	Get(id uint) (*Result, error)
 	GetAll() ([]*Result, error)
+	GetPage(filter *ResultFilter, page *PageQuery) ([]*Result, int64, error)
 	CountAll() (int64, error)
-	Create(result *Result) error
+	Create(ctx context.Context, result *Result) error
 	Delete(id uint) error
 	DeleteAll() error
 	Update(result *Result, cols ...string) error
 	GetFromCache(id uint) (*Result, error)
+	CountUserWin(activityID, uid uint, dayBegin time.Time) (*UserWinCount, error)
+	// UpdateFulfill 履约状态为from时更新result中的履约状态和cols字段，返回是否更新成功
+	UpdateFulfill(ctx context.Context, result *Result, from uint, cols ...string) (bool, error)
+	// GetFulfillList 按履约状态查询中奖记录
+	GetFulfillList(filter *FulfillFilter, limit int) ([]*Result, error)
+	// GetRecentList 获取活动最近的正常中奖记录
+	GetRecentList(activityID uint, limit int) ([]*Result, error)
+	// GetRecentWinnersFromCache 缓存不存在时返回nil
+	GetRecentWinnersFromCache(activityID uint) ([]*RecentWinner, error)
+	SetRecentWinnersCache(activityID uint, list []*RecentWinner) error
+	// CountIssued 统计奖品在since之后发出的数量，已回库的不算
+	CountIssued(prizeID uint, since time.Time) (int64, error)
+}
+
+// ResultSink 中奖纪录的写入方式，conf.Data.ResultSink 选择同步写入或者异步批量写入
+type ResultSink interface {
+	// Write 写入中奖纪录，同步写入时在ctx中的事务里完成
+	Write(ctx context.Context, result *Result) error
+	// Async 异步写入不参与发奖事务，需要在事务提交之后调用Write
+	Async() bool
 }
@@ -0,0 +1,238 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// VelocityRule 时间窗口内按用户、IP或设备统计的抽奖次数上限
+type VelocityRule struct {
+	Name      string
+	Dimension string
+	Window    time.Duration
+	Max       uint
+	Action    uint
+}
+
+// RiskRules 风控规则，由配置文件的 lottery.risk 部分生成，没有配置时不启用
+type RiskRules struct {
+	Enabled          bool
+	Velocity         []*VelocityRule
+	SharedIpWindow   time.Duration
+	SharedIpMaxUsers uint // 0表示不检查
+	SharedIpAction   uint
+	NewAccountMinAge time.Duration // 0表示不检查
+	NewAccountAction uint
+	IpMismatchAction uint // RiskActionAllow表示不检查
+	Downgrade        bool
+	DowngradeRate    float64
+}
+
+// ParseRiskAction 配置中的动作名称，只能是challenge或deny
+func ParseRiskAction(action string) (uint, error) {
+	switch action {
+	case "challenge":
+		return constant.RiskActionChallenge, nil
+	case "deny":
+		return constant.RiskActionDeny, nil
+	}
+	return 0, fmt.Errorf("action %q must be challenge or deny", action)
+}
+
+// RiskSignals 一次抽奖采集到的风控数据
+type RiskSignals struct {
+	Velocity   map[string]int64 // 规则名称 -> 时间窗口内的抽奖次数，包括本次
+	IpUsers    int64            // 时间窗口内同一个IP参与的用户数，包括本用户
+	AccountAge time.Duration    // 注册时长，小于0表示未知
+	IpMismatch bool
+}
+
+// RiskDecision 风控结果，Action取命中规则中最严重的动作
+type RiskDecision struct {
+	Action    uint     `json:"action"`
+	Reasons   []string `json:"reasons"`
+	Downgrade bool     `json:"downgrade"` // 不拒绝，降低中奖概率
+}
+
+func (d *RiskDecision) hit(action uint, reason string) {
+	if action > d.Action {
+		d.Action = action
+	}
+	d.Reasons = append(d.Reasons, reason)
+}
+
+// Decide 根据采集到的数据判断风控动作
+func (r *RiskRules) Decide(s *RiskSignals) *RiskDecision {
+	d := &RiskDecision{Action: constant.RiskActionAllow}
+	for _, rule := range r.Velocity {
+		if num := s.Velocity[rule.Name]; num > int64(rule.Max) {
+			d.hit(rule.Action, fmt.Sprintf("velocity:%s=%d", rule.Name, num))
+		}
+	}
+	if r.SharedIpMaxUsers > 0 && s.IpUsers > int64(r.SharedIpMaxUsers) {
+		d.hit(r.SharedIpAction, fmt.Sprintf("shared_ip=%d", s.IpUsers))
+	}
+	if r.NewAccountMinAge > 0 && s.AccountAge >= 0 && s.AccountAge < r.NewAccountMinAge {
+		d.hit(r.NewAccountAction, fmt.Sprintf("new_account=%s", s.AccountAge.Truncate(time.Second)))
+	}
+	if r.IpMismatchAction != constant.RiskActionAllow && s.IpMismatch {
+		d.hit(r.IpMismatchAction, "ip_mismatch")
+	}
+	d.Downgrade = d.Action == constant.RiskActionChallenge && r.Downgrade
+	return d
+}
+
+// RiskLog 风控记录表，只记录放行以外的结果
+type RiskLog struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	ActivityId uint       `gorm:"column:activity_id;type:int(10) unsigned;default:0;comment:活动ID;NOT NULL" json:"activity_id"`
+	UserId     uint       `gorm:"column:user_id;type:int(10) unsigned;default:0;comment:用户ID;NOT NULL" json:"user_id"`
+	Ip         string     `gorm:"column:ip;type:varchar(50);default:'';comment:服务端确定的IP;NOT NULL" json:"ip"`
+	ClaimedIp  string     `gorm:"column:claimed_ip;type:varchar(50);default:'';comment:请求中声明的IP;NOT NULL" json:"claimed_ip"`
+	DeviceId   string     `gorm:"column:device_id;type:varchar(64);default:'';comment:设备ID;NOT NULL" json:"device_id"`
+	Action     uint       `gorm:"column:action;type:tinyint(3) unsigned;default:0;comment:动作，1 需要验证，2 拒绝;NOT NULL" json:"action"`
+	Downgrade  bool       `gorm:"column:downgrade;type:tinyint(1);default:0;comment:是否降级为降低中奖概率;NOT NULL" json:"downgrade"`
+	Reasons    string     `gorm:"column:reasons;type:varchar(255);default:'';comment:命中原因;NOT NULL" json:"reasons"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+}
+
+func (r *RiskLog) TableName() string {
+	return "t_risk_log"
+}
+
+type RiskRepo interface {
+	// IncrVelocity 按固定时间窗口计数，返回递增后的次数
+	IncrVelocity(rule, value string, window time.Duration, now time.Time) (int64, error)
+	// AddIpUser 记录时间窗口内参与抽奖的用户，返回该IP的用户数
+	AddIpUser(ip string, uid uint, window time.Duration, now time.Time) (int64, error)
+	CreateLog(riskLog *RiskLog) error
+	GetLogPage(filter *RiskLogFilter, page *PageQuery) ([]*RiskLog, int64, error)
+}
+
+type RiskCase struct {
+	riskRepo    RiskRepo
+	userRepo    UserRepo
+	lotteryConf *LotteryConfig
+}
+
+func NewRiskCase(rr RiskRepo, ur UserRepo, lc *LotteryConfig) *RiskCase {
+	return &RiskCase{
+		riskRepo:    rr,
+		userRepo:    ur,
+		lotteryConf: lc,
+	}
+}
+
+// Evaluate 抽奖前的风控评估，采集数据失败时放行，不影响正常抽奖
+// 结果写入userInfo.RiskAction，降级的用户中奖后中奖纪录标记为作弊
+func (c *RiskCase) Evaluate(ctx context.Context, userInfo *LotteryUserInfo) *RiskDecision {
+	rules := c.lotteryConf.Limits().Risk
+	if rules == nil || !rules.Enabled {
+		return &RiskDecision{Action: constant.RiskActionAllow}
+	}
+	signals, err := c.collectSignals(rules, userInfo)
+	if err != nil {
+		log.ErrorContextf(ctx, "RiskCase|Evaluate|collectSignals user_id=%d err:%v", userInfo.UserID, err)
+		return &RiskDecision{Action: constant.RiskActionAllow}
+	}
+	d := rules.Decide(signals)
+	userInfo.RiskAction = d.Action
+	if d.Action == constant.RiskActionAllow {
+		return d
+	}
+	log.InfoContextf(ctx, "RiskCase|Evaluate user_id=%d ip=%s action=%d downgrade=%v reasons=%v",
+		userInfo.UserID, userInfo.IP, d.Action, d.Downgrade, d.Reasons)
+	reasons := strings.Join(d.Reasons, ",")
+	if utf8.RuneCountInString(reasons) > constant.RiskReasonsMaxLen {
+		reasons = string([]rune(reasons)[:constant.RiskReasonsMaxLen])
+	}
+	riskLog := &RiskLog{
+		ActivityId: userInfo.ActivityID,
+		UserId:     userInfo.UserID,
+		Ip:         userInfo.IP,
+		ClaimedIp:  userInfo.ClaimedIP,
+		DeviceId:   userInfo.DeviceID,
+		Action:     d.Action,
+		Downgrade:  d.Downgrade,
+		Reasons:    reasons,
+	}
+	if err = c.riskRepo.CreateLog(riskLog); err != nil {
+		log.ErrorContextf(ctx, "RiskCase|Evaluate|CreateLog err:%v", err)
+	}
+	return d
+}
+
+// collectSignals 递增各规则的计数并采集用户数据
+func (c *RiskCase) collectSignals(rules *RiskRules, userInfo *LotteryUserInfo) (*RiskSignals, error) {
+	now := time.Now()
+	s := &RiskSignals{
+		Velocity:   make(map[string]int64, len(rules.Velocity)),
+		AccountAge: -1,
+		IpMismatch: userInfo.IpMismatch(),
+	}
+	for _, rule := range rules.Velocity {
+		var value string
+		switch rule.Dimension {
+		case constant.RiskDimensionUser:
+			value = fmt.Sprint(userInfo.UserID)
+		case constant.RiskDimensionIp:
+			value = userInfo.IP
+		case constant.RiskDimensionDevice:
+			value = userInfo.DeviceID
+		}
+		if value == "" {
+			continue
+		}
+		num, err := c.riskRepo.IncrVelocity(rule.Name, value, rule.Window, now)
+		if err != nil {
+			return nil, err
+		}
+		s.Velocity[rule.Name] = num
+	}
+	if rules.SharedIpMaxUsers > 0 && userInfo.IP != "" {
+		num, err := c.riskRepo.AddIpUser(userInfo.IP, userInfo.UserID, rules.SharedIpWindow, now)
+		if err != nil {
+			return nil, err
+		}
+		s.IpUsers = num
+	}
+	if rules.NewAccountMinAge > 0 {
+		user, err := c.userRepo.Get(userInfo.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil && user.SysCreated != nil && user.SysCreated.Year() > 1000 {
+			s.AccountAge = now.Sub(*user.SysCreated)
+		}
+	}
+	return s, nil
+}
+
+// KeepPrize 降级的用户按DowngradeRate的概率保留中奖结果，其他用户不受影响
+func (c *RiskCase) KeepPrize(d *RiskDecision) bool {
+	if d == nil || !d.Downgrade {
+		return true
+	}
+	rules := c.lotteryConf.Limits().Risk
+	if rules == nil {
+		return true
+	}
+	return rand.Float64() < rules.DowngradeRate
+}
+
+// GetRiskLogList 分页查询风控记录
+func (c *RiskCase) GetRiskLogList(ctx context.Context, filter *RiskLogFilter, page *PageQuery) ([]*RiskLog, int64, error) {
+	page.Normalize()
+	list, total, err := c.riskRepo.GetLogPage(filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "RiskCase|GetRiskLogList:%v", err)
+		return nil, 0, fmt.Errorf("RiskCase|GetRiskLogList:%v", err)
+	}
+	return list, total, nil
+}
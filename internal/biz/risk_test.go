@@ -0,0 +1,49 @@
+package biz
+
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"testing"
+	"time"
+)
+
+func TestRiskRulesDecide(t *testing.T) {
+	rules := &RiskRules{
+		Enabled: true,
+		Velocity: []*VelocityRule{
+			{Name: "user_minute", Dimension: constant.RiskDimensionUser, Window: time.Minute, Max: 10,
+				Action: constant.RiskActionChallenge},
+			{Name: "ip_minute", Dimension: constant.RiskDimensionIp, Window: time.Minute, Max: 100,
+				Action: constant.RiskActionDeny},
+		},
+		SharedIpWindow:   time.Hour,
+		SharedIpMaxUsers: 50,
+		SharedIpAction:   constant.RiskActionChallenge,
+		NewAccountMinAge: 24 * time.Hour,
+		NewAccountAction: constant.RiskActionChallenge,
+		Downgrade:        true,
+		DowngradeRate:    0.1,
+	}
+	cases := []struct {
+		name      string
+		signals   *RiskSignals
+		action    uint
+		reasons   int
+		downgrade bool
+	}{
+		{"allow", &RiskSignals{Velocity: map[string]int64{"user_minute": 10}, IpUsers: 50, AccountAge: -1},
+			constant.RiskActionAllow, 0, false},
+		{"challenge", &RiskSignals{Velocity: map[string]int64{"user_minute": 11}, AccountAge: 48 * time.Hour},
+			constant.RiskActionChallenge, 1, true},
+		{"worst wins", &RiskSignals{Velocity: map[string]int64{"user_minute": 11, "ip_minute": 101}, IpUsers: 51},
+			constant.RiskActionDeny, 4, false},
+		{"new account", &RiskSignals{AccountAge: time.Hour}, constant.RiskActionChallenge, 1, true},
+		{"ip mismatch not checked", &RiskSignals{AccountAge: -1, IpMismatch: true}, constant.RiskActionAllow, 0, false},
+	}
+	for _, c := range cases {
+		d := rules.Decide(c.signals)
+		if d.Action != c.action || len(d.Reasons) != c.reasons || d.Downgrade != c.downgrade {
+			t.Errorf("%s: Decide = %+v, want action %d with %d reasons, downgrade %v",
+				c.name, d, c.action, c.reasons, c.downgrade)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package biz
+
+import (
+	"context"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"strconv"
+	"strings"
+)
+
+// PrizeSelector 中奖奖品选择策略，每个活动按 Activity.SelectStrategy 选择一种实现
+type PrizeSelector interface {
+	// Prepare 将奖品转换为可参与抽奖的奖品，奖品配置对当前策略无效时返回false
+	Prepare(activity *Activity, prize *Prize) (*LotteryPrize, bool)
+	// Select 从可参与抽奖的奖品中选出中奖奖品，未中奖返回nil，同时返回本次抽奖编码
+	Select(ctx context.Context, activity *Activity, prizeList []*LotteryPrize) (*LotteryPrize, int)
+}
+
+// NewPrizeSelector 根据活动配置获取奖品选择策略，未知策略使用中奖编码区间策略
+func NewPrizeSelector(activity *Activity) PrizeSelector {
+	switch activity.SelectStrategy {
+	case constant.PrizeSelectorWeight:
+		return weightSelector{}
+	default:
+		return rangeSelector{}
+	}
+}
+
+// rangeSelector 中奖编码区间策略，在 [0, PrizeCodeMax) 中随机一个编码，落在奖品的 low-high 区间内即中奖
+type rangeSelector struct{}
+
+func (rangeSelector) Prepare(activity *Activity, prize *Prize) (*LotteryPrize, bool) {
+	low, high, ok := ParsePrizeCode(prize.PrizeCode, int(activity.PrizeCodeMax))
+	if !ok {
+		return nil, false
+	}
+	lotteryPrize := newLotteryPrize(prize)
+	lotteryPrize.PrizeCodeLow = low
+	lotteryPrize.PrizeCodeHigh = high
+	return lotteryPrize, true
+}
+
+func (rangeSelector) Select(ctx context.Context, activity *Activity, prizeList []*LotteryPrize) (*LotteryPrize, int) {
+	prizeCode := utils.Random(int(activity.PrizeCodeMax))
+	for _, lotteryPrize := range prizeList {
+		if lotteryPrize.PrizeCodeLow <= prizeCode &&
+			lotteryPrize.PrizeCodeHigh >= prizeCode {
+			// 中奖编码区间满足条件，说明可以中奖
+			return lotteryPrize, prizeCode
+		}
+	}
+	return nil, prizeCode
+}
+
+// weightSelector 概率权重策略，在 [0, 奖品权重之和 + 不中奖权重) 中随机一个编码，按权重累加的区间确定中奖奖品
+type weightSelector struct{}
+
+func (weightSelector) Prepare(activity *Activity, prize *Prize) (*LotteryPrize, bool) {
+	if prize.Weight == 0 {
+		return nil, false
+	}
+	lotteryPrize := newLotteryPrize(prize)
+	lotteryPrize.Weight = prize.Weight
+	return lotteryPrize, true
+}
+
+func (weightSelector) Select(ctx context.Context, activity *Activity, prizeList []*LotteryPrize) (*LotteryPrize, int) {
+	total := int(activity.NoPrizeWeight)
+	for _, lotteryPrize := range prizeList {
+		total += int(lotteryPrize.Weight)
+	}
+	if total <= 0 {
+		return nil, 0
+	}
+	if total > constant.WeightTotalMax {
+		log.ErrorContextf(ctx, "weightSelector|activity_id=%d weight total %d exceeds %d",
+			activity.Id, total, constant.WeightTotalMax)
+		return nil, 0
+	}
+	prizeCode := utils.Random(total)
+	// 奖品区间依次为 [0, w1), [w1, w1+w2)...，剩余部分为不中奖区间
+	upper := 0
+	for _, lotteryPrize := range prizeList {
+		upper += int(lotteryPrize.Weight)
+		if prizeCode < upper {
+			return lotteryPrize, prizeCode
+		}
+	}
+	return nil, prizeCode
+}
+
+// ParsePrizeCode 解析奖品的中奖编码区间 "low-high"，区间需要在 [0, codeMax) 之内
+func ParsePrizeCode(prizeCode string, codeMax int) (int, int, bool) {
+	codes := strings.Split(prizeCode, "-")
+	if len(codes) != 2 {
+		return 0, 0, false
+	}
+	low, err1 := strconv.Atoi(codes[0])
+	high, err2 := strconv.Atoi(codes[1])
+	if err1 != nil || err2 != nil || high < low || low < 0 || high >= codeMax {
+		return 0, 0, false
+	}
+	return low, high, true
+}
+
+func newLotteryPrize(prize *Prize) *LotteryPrize {
+	return &LotteryPrize{
+		Id:           prize.Id,
+		Title:        prize.Title,
+		PrizeNum:     prize.PrizeNum,
+		LeftNum:      prize.LeftNum,
+		Img:          prize.Img,
+		DisplayOrder: prize.DisplayOrder,
+		PrizeType:    prize.PrizeType,
+		UserWinMax:   prize.UserWinMax,
+		PrizeProfile: prize.PrizeProfile,
+	}
+}
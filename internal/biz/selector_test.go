@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"context"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"testing"
+)
+
+func TestRangeSelector(t *testing.T) {
+	activity := DefaultActivity(DefaultLotteryLimits())
+	selector := NewPrizeSelector(activity)
+	if _, ok := selector.Prepare(activity, &Prize{Id: 1, PrizeCode: "0-10000"}); ok {
+		t.Errorf("prize code out of range should be ignored")
+	}
+	prize, ok := selector.Prepare(activity, &Prize{Id: 1, PrizeCode: "0-9999"})
+	if !ok {
+		t.Fatalf("prize code 0-9999 should be valid")
+	}
+	got, code := selector.Select(context.Background(), activity, []*LotteryPrize{prize})
+	if got == nil || got.Id != 1 || code < 0 || code >= constant.PrizeCodeMax {
+		t.Errorf("select got %v, code %d", got, code)
+	}
+}
+
+func TestWeightSelector(t *testing.T) {
+	activity := &Activity{SelectStrategy: constant.PrizeSelectorWeight, NoPrizeWeight: 0}
+	selector := NewPrizeSelector(activity)
+	if _, ok := selector.Prepare(activity, &Prize{Id: 1}); ok {
+		t.Errorf("prize without weight should be ignored")
+	}
+	prize1, _ := selector.Prepare(activity, &Prize{Id: 1, Weight: 1})
+	prize2, _ := selector.Prepare(activity, &Prize{Id: 2, Weight: 3})
+	counts := make(map[uint]int)
+	for i := 0; i < 4000; i++ {
+		got, _ := selector.Select(context.Background(), activity, []*LotteryPrize{prize1, prize2})
+		if got == nil {
+			t.Fatalf("no prize weight is 0, should always win")
+		}
+		counts[got.Id]++
+	}
+	if counts[2] < 2*counts[1] {
+		t.Errorf("weight 1:3 got counts %v", counts)
+	}
+}
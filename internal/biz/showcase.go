@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"sort"
+	"time"
+)
+
+// ShowcasePrize 前端奖品展示信息，剩余数量来自缓存，是近似值
+type ShowcasePrize struct {
+	Id           uint      `json:"id"`
+	Title        string    `json:"title"`
+	Img          string    `json:"img"`
+	PrizeType    uint      `json:"prize_type"`
+	DisplayOrder uint      `json:"display_order"`
+	PrizeNum     int       `json:"prize_num"`
+	LeftNum      int       `json:"left_num"`
+	PoolNum      int       `json:"pool_num"`
+	BeginTime    time.Time `json:"begin_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// GetShowcase 获取活动当前可抽的奖品，按展示顺序排列，展示顺序相同时按奖品ID排列
+func (l *LotteryCase) GetShowcase(ctx context.Context, activityID uint) ([]*ShowcasePrize, error) {
+	list, err := l.prizeRepo.GetAllUsefulPrizeListWithCache(activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetShowcase:%v", err)
+		return nil, fmt.Errorf("LotteryCase|GetShowcase:%v", err)
+	}
+	poolNums, err := l.prizeRepo.GetPrizePoolNums(activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|GetShowcase:%v", err)
+		return nil, fmt.Errorf("LotteryCase|GetShowcase:%v", err)
+	}
+	return newShowcase(list, poolNums), nil
+}
+
+func newShowcase(list []*Prize, poolNums map[uint]int) []*ShowcasePrize {
+	showcase := make([]*ShowcasePrize, 0, len(list))
+	for _, prize := range list {
+		showcase = append(showcase, &ShowcasePrize{
+			Id:           prize.Id,
+			Title:        prize.Title,
+			Img:          prize.Img,
+			PrizeType:    prize.PrizeType,
+			DisplayOrder: prize.DisplayOrder,
+			PrizeNum:     prize.PrizeNum,
+			LeftNum:      prize.LeftNum,
+			PoolNum:      poolNums[prize.Id],
+			BeginTime:    prize.BeginTime,
+			EndTime:      prize.EndTime,
+		})
+	}
+	sort.Slice(showcase, func(i, j int) bool {
+		if showcase[i].DisplayOrder != showcase[j].DisplayOrder {
+			return showcase[i].DisplayOrder < showcase[j].DisplayOrder
+		}
+		return showcase[i].Id < showcase[j].Id
+	})
+	return showcase
+}
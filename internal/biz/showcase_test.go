@@ -0,0 +1,22 @@
+package biz
+
+import "testing"
+
+func TestNewShowcase(t *testing.T) {
+	list := []*Prize{
+		{Id: 3, DisplayOrder: 2, LeftNum: 5},
+		{Id: 2, DisplayOrder: 1, LeftNum: 7},
+		{Id: 1, DisplayOrder: 2, LeftNum: 9},
+	}
+	showcase := newShowcase(list, map[uint]int{1: 4, 3: 2})
+	wantIds := []uint{2, 1, 3}
+	wantPool := []int{0, 4, 2}
+	if len(showcase) != len(wantIds) {
+		t.Fatalf("len(showcase) = %d, want %d", len(showcase), len(wantIds))
+	}
+	for i, prize := range showcase {
+		if prize.Id != wantIds[i] || prize.PoolNum != wantPool[i] {
+			t.Errorf("showcase[%d] = %+v, want id %d pool %d", i, prize, wantIds[i], wantPool[i])
+		}
+	}
+}
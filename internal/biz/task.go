@@ -0,0 +1,330 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	cronpkg "github.com/robfig/cron/v3"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrTaskNotFound 定时任务没有注册
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrTaskRunning 定时任务正在执行，可能在其他实例上
+var ErrTaskRunning = errors.New("task is running")
+
+// ErrTaskStopped 服务正在退出，不再接受手动触发
+var ErrTaskStopped = errors.New("task scheduler stopped")
+
+// TaskJob 定时任务方法，返回的错误记录到执行记录中
+type TaskJob func(ctx context.Context) error
+
+// Task 注册的定时任务，Type为cron时Schedule是cron表达式，为interval时Schedule是间隔时间
+type Task struct {
+	Name     string
+	Type     string
+	Schedule string
+	LockTTL  time.Duration
+	job      TaskJob
+	cron     cronpkg.Schedule
+	interval time.Duration
+}
+
+// Next 下一次执行时间，interval按间隔对齐，多个实例在同一时间点抢锁，cron按now的时区计算
+func (t *Task) Next(now time.Time) time.Time {
+	if t.cron != nil {
+		return t.cron.Next(now)
+	}
+	return now.Truncate(t.interval).Add(t.interval)
+}
+
+// TaskRun 定时任务执行记录
+type TaskRun struct {
+	Id          uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	TaskName    string     `gorm:"column:task_name;type:varchar(50);default:'';comment:任务名称;NOT NULL" json:"task_name"`
+	TriggerType uint       `gorm:"column:trigger_type;type:tinyint(3) unsigned;default:1;comment:触发方式，1 按计划，2 手动;NOT NULL" json:"trigger_type"`
+	Instance    string     `gorm:"column:instance;type:varchar(100);default:'';comment:执行的实例;NOT NULL" json:"instance"`
+	Status      uint       `gorm:"column:status;type:tinyint(3) unsigned;default:1;comment:状态，1 执行中，2 成功，3 失败;NOT NULL" json:"status"`
+	Error       string     `gorm:"column:error;type:varchar(1024);default:'';comment:错误信息;NOT NULL" json:"error"`
+	StartTime   time.Time  `gorm:"column:start_time;type:datetime;comment:开始时间;NOT NULL" json:"start_time"`
+	EndTime     *time.Time `gorm:"column:end_time;type:datetime;default null;comment:结束时间" json:"end_time"`
+	SysCreated  *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:创建时间" json:"sys_created"`
+	SysUpdated  *time.Time `gorm:"autoUpdateTime;column:sys_updated;type:datetime;default null;comment:修改时间" json:"sys_updated"`
+}
+
+func (r *TaskRun) TableName() string {
+	return "t_task_run"
+}
+
+type TaskRepo interface {
+	CreateRun(run *TaskRun) error
+	// FinishRun 更新执行记录的状态、错误信息和结束时间
+	FinishRun(run *TaskRun) error
+	// GetRunPage 按开始时间倒序分页查询执行记录，name为空时查询全部任务
+	GetRunPage(name string, page *PageQuery) ([]*TaskRun, int64, error)
+	GetLastRun(name string) (*TaskRun, error)
+	SetPaused(name string, paused bool) error
+	GetPausedNames() (map[string]bool, error)
+	// AcquireSlot 抢占任务某个执行时间点，同一时间点只有一个实例能抢到，到期前不释放
+	AcquireSlot(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error)
+	// Lock 抢占任务的执行锁，执行期间自动续期，返回false表示其他实例正在执行
+	Lock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error)
+}
+
+// TaskInfo 后台查看的定时任务信息
+type TaskInfo struct {
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	Schedule string    `json:"schedule"`
+	LockTTL  string    `json:"lock_ttl"`
+	Paused   bool      `json:"paused"`
+	NextTime time.Time `json:"next_time"`
+	LastRun  *TaskRun  `json:"last_run"`
+}
+
+type TaskCase struct {
+	taskRepo    TaskRepo
+	lotteryConf *LotteryConfig
+	instance    string
+	tasks       map[string]*Task
+	names       []string
+	mu          sync.Mutex
+	stopped     bool
+	manual      sync.WaitGroup // 正在执行的手动触发任务
+}
+
+func NewTaskCase(tr TaskRepo, lc *LotteryConfig) *TaskCase {
+	instance, _ := os.Hostname()
+	return &TaskCase{
+		taskRepo:    tr,
+		lotteryConf: lc,
+		instance:    fmt.Sprintf("%s:%d", instance, os.Getpid()),
+		tasks:       make(map[string]*Task),
+	}
+}
+
+// Now 业务时区的当前时间，cron表达式按业务时区计算，和按日期统计的次数同一天切换
+func (c *TaskCase) Now() time.Time {
+	return time.Now().In(c.lotteryConf.Limits().Location)
+}
+
+// Register 注册定时任务，只在启动时调用
+func (c *TaskCase) Register(name, typ, schedule string, lockTTL time.Duration, job TaskJob) error {
+	if _, ok := c.tasks[name]; ok {
+		return fmt.Errorf("task %s registered twice", name)
+	}
+	if lockTTL < time.Second {
+		lockTTL = constant.TaskLockTTL
+	}
+	task := &Task{
+		Name:     name,
+		Type:     typ,
+		Schedule: schedule,
+		LockTTL:  lockTTL,
+		job:      job,
+	}
+	switch typ {
+	case constant.TaskTypeCron:
+		s, err := cronpkg.ParseStandard(schedule)
+		if err != nil {
+			return fmt.Errorf("task %s cron schedule %q invalid: %v", name, schedule, err)
+		}
+		task.cron = s
+	case constant.TaskTypeInterval:
+		d, err := time.ParseDuration(schedule)
+		if err != nil || d < time.Second {
+			return fmt.Errorf("task %s interval %q must be a duration of at least 1s", name, schedule)
+		}
+		task.interval = d
+	default:
+		return fmt.Errorf("task %s type %q must be cron or interval", name, typ)
+	}
+	c.tasks[name] = task
+	c.names = append(c.names, name)
+	return nil
+}
+
+// GetTasks 按注册顺序返回所有定时任务
+func (c *TaskCase) GetTasks() []*Task {
+	tasks := make([]*Task, 0, len(c.names))
+	for _, name := range c.names {
+		tasks = append(tasks, c.tasks[name])
+	}
+	return tasks
+}
+
+// GetTaskList 定时任务列表，包括暂停状态、下一次执行时间和最近一次执行记录
+func (c *TaskCase) GetTaskList(ctx context.Context) ([]*TaskInfo, error) {
+	paused, err := c.taskRepo.GetPausedNames()
+	if err != nil {
+		log.ErrorContextf(ctx, "TaskCase|GetTaskList|GetPausedNames err:%v", err)
+		return nil, fmt.Errorf("TaskCase|GetTaskList:%v", err)
+	}
+	now := c.Now()
+	list := make([]*TaskInfo, 0, len(c.names))
+	for _, task := range c.GetTasks() {
+		lastRun, err := c.taskRepo.GetLastRun(task.Name)
+		if err != nil {
+			log.ErrorContextf(ctx, "TaskCase|GetTaskList|GetLastRun err:%v", err)
+			return nil, fmt.Errorf("TaskCase|GetTaskList:%v", err)
+		}
+		list = append(list, &TaskInfo{
+			Name:     task.Name,
+			Type:     task.Type,
+			Schedule: task.Schedule,
+			LockTTL:  task.LockTTL.String(),
+			Paused:   paused[task.Name],
+			NextTime: task.Next(now),
+			LastRun:  lastRun,
+		})
+	}
+	return list, nil
+}
+
+// GetTaskRunList 分页查询执行记录
+func (c *TaskCase) GetTaskRunList(ctx context.Context, name string, page *PageQuery) ([]*TaskRun, int64, error) {
+	page.Normalize()
+	list, total, err := c.taskRepo.GetRunPage(name, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "TaskCase|GetTaskRunList:%v", err)
+		return nil, 0, fmt.Errorf("TaskCase|GetTaskRunList:%v", err)
+	}
+	return list, total, nil
+}
+
+// PauseTask 暂停或恢复定时任务，暂停对所有实例生效，暂停后仍然可以手动触发
+func (c *TaskCase) PauseTask(ctx context.Context, name string, paused bool) error {
+	if _, ok := c.tasks[name]; !ok {
+		return ErrTaskNotFound
+	}
+	if err := c.taskRepo.SetPaused(name, paused); err != nil {
+		log.ErrorContextf(ctx, "TaskCase|PauseTask:%v", err)
+		return fmt.Errorf("TaskCase|PauseTask:%v", err)
+	}
+	return nil
+}
+
+// TriggerTask 手动触发定时任务，抢到执行锁后在后台执行，其他实例正在执行时返回ErrTaskRunning
+func (c *TaskCase) TriggerTask(ctx context.Context, name string) error {
+	task, ok := c.tasks[name]
+	if !ok {
+		return ErrTaskNotFound
+	}
+	// 退出时StopTrigger等待计数归零，停止后不能再增加
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return ErrTaskStopped
+	}
+	c.manual.Add(1)
+	c.mu.Unlock()
+	unlock, ok, err := c.taskRepo.Lock(context.Background(), task.Name, task.LockTTL)
+	if err != nil {
+		c.manual.Done()
+		log.ErrorContextf(ctx, "TaskCase|TriggerTask|Lock err:%v", err)
+		return fmt.Errorf("TaskCase|TriggerTask:%v", err)
+	}
+	if !ok {
+		c.manual.Done()
+		return ErrTaskRunning
+	}
+	go func() {
+		defer c.manual.Done()
+		defer unlock()
+		c.execute(context.Background(), task, constant.TaskTriggerManual)
+	}()
+	return nil
+}
+
+// StopTrigger 停止接受手动触发，等待正在执行的手动任务写完执行记录并释放锁
+func (c *TaskCase) StopTrigger() {
+	c.mu.Lock()
+	c.stopped = true
+	c.mu.Unlock()
+	c.manual.Wait()
+}
+
+// RunScheduled 按计划执行任务，任务暂停、时间点被其他实例抢到或者上一次还在执行时跳过
+func (c *TaskCase) RunScheduled(ctx context.Context, task *Task, slot time.Time) {
+	paused, err := c.taskRepo.GetPausedNames()
+	if err != nil {
+		log.Errorf("TaskCase|RunScheduled task=%s GetPausedNames err:%v", task.Name, err)
+		return
+	}
+	if paused[task.Name] {
+		return
+	}
+	ok, err := c.taskRepo.AcquireSlot(ctx, task.Name, slot, task.LockTTL)
+	if err != nil {
+		log.Errorf("TaskCase|RunScheduled task=%s AcquireSlot err:%v", task.Name, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	unlock, ok, err := c.taskRepo.Lock(ctx, task.Name, task.LockTTL)
+	if err != nil {
+		log.Errorf("TaskCase|RunScheduled task=%s Lock err:%v", task.Name, err)
+		return
+	}
+	if !ok {
+		log.Infof("TaskCase|RunScheduled task=%s is still running, skip %s", task.Name,
+			slot.Format(constant.SysTimeFormat))
+		return
+	}
+	defer unlock()
+	c.execute(ctx, task, constant.TaskTriggerSchedule)
+}
+
+// execute 执行任务并写执行记录，写记录失败不影响任务执行
+func (c *TaskCase) execute(ctx context.Context, task *Task, trigger uint) {
+	run := &TaskRun{
+		TaskName:    task.Name,
+		TriggerType: trigger,
+		Instance:    c.instance,
+		Status:      constant.TaskRunStatusRunning,
+		StartTime:   time.Now(),
+	}
+	if err := c.taskRepo.CreateRun(run); err != nil {
+		log.Errorf("TaskCase|execute task=%s CreateRun err:%v", task.Name, err)
+	}
+	err := runTaskJob(ctx, task.job)
+	end := time.Now()
+	run.EndTime = &end
+	run.Status = constant.TaskRunStatusSuccess
+	if err != nil {
+		log.Errorf("TaskCase|execute task=%s err:%v", task.Name, err)
+		run.Status = constant.TaskRunStatusFailed
+		run.Error = truncateRunError(err.Error())
+	}
+	if run.Id == 0 {
+		return
+	}
+	if err = c.taskRepo.FinishRun(run); err != nil {
+		log.Errorf("TaskCase|execute task=%s FinishRun err:%v", task.Name, err)
+	}
+}
+
+// runTaskJob 执行任务方法，panic转换为错误
+func runTaskJob(ctx context.Context, job TaskJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job(ctx)
+}
+
+// truncateRunError 按字符截断错误信息
+func truncateRunError(msg string) string {
+	if utf8.RuneCountInString(msg) <= constant.TaskRunErrorMaxLen {
+		return msg
+	}
+	return string([]rune(msg)[:constant.TaskRunErrorMaxLen])
+}
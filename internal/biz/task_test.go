@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"testing"
+	"time"
+)
+
+func TestRegisterTask(t *testing.T) {
+	c := NewTaskCase(nil, NewLotteryConfig(DefaultLotteryLimits()))
+	job := func(ctx context.Context) error { return nil }
+	if err := c.Register("fill", constant.TaskTypeInterval, "1m", 0, job); err != nil {
+		t.Fatalf("register interval err:%v", err)
+	}
+	if err := c.Register("reset", constant.TaskTypeCron, "0 0 * * *", 0, job); err != nil {
+		t.Fatalf("register cron err:%v", err)
+	}
+	if err := c.Register("fill", constant.TaskTypeInterval, "1m", 0, job); err == nil {
+		t.Errorf("duplicated task should fail")
+	}
+	if err := c.Register("bad", constant.TaskTypeCron, "every day", 0, job); err == nil {
+		t.Errorf("invalid cron should fail")
+	}
+	if err := c.Register("once", "once", "5s", 0, job); err == nil {
+		t.Errorf("unknown type should fail")
+	}
+	tasks := c.GetTasks()
+	if len(tasks) != 2 || tasks[0].Name != "fill" || tasks[0].LockTTL != constant.TaskLockTTL {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+	now := time.Date(2024, 5, 1, 10, 20, 30, 0, time.Local)
+	if next := tasks[0].Next(now); !next.Equal(time.Date(2024, 5, 1, 10, 21, 0, 0, time.Local)) {
+		t.Errorf("interval next should align to minute, got %v", next)
+	}
+	if next := tasks[1].Next(now); !next.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("cron next should be midnight, got %v", next)
+	}
+}
+
+func TestCronUsesBusinessTimezone(t *testing.T) {
+	limits := DefaultLotteryLimits()
+	limits.Location = time.FixedZone("UTC+8", 8*3600)
+	c := NewTaskCase(nil, NewLotteryConfig(limits))
+	job := func(ctx context.Context) error { return nil }
+	if err := c.Register("reset", constant.TaskTypeCron, "0 0 * * *", 0, job); err != nil {
+		t.Fatalf("register cron err:%v", err)
+	}
+	// 业务时区的午夜是UTC的16点
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).In(c.Now().Location())
+	want := time.Date(2024, 5, 1, 16, 0, 0, 0, time.UTC)
+	if next := c.GetTasks()[0].Next(now); !next.Equal(want) {
+		t.Errorf("cron next should be business midnight %v, got %v", want, next.UTC())
+	}
+}
+
+func TestRunTaskJobRecoverPanic(t *testing.T) {
+	err := runTaskJob(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+	if err == nil || err.Error() != "panic: boom" {
+		t.Errorf("panic should be returned as error, got %v", err)
+	}
+}
+
+// stubTaskRepo 只实现手动触发用到的方法
+type stubTaskRepo struct {
+	TaskRepo
+	finished bool
+}
+
+func (r *stubTaskRepo) Lock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error) {
+	return func() {}, true, nil
+}
+
+func (r *stubTaskRepo) CreateRun(run *TaskRun) error {
+	run.Id = 1
+	return nil
+}
+
+func (r *stubTaskRepo) FinishRun(run *TaskRun) error {
+	r.finished = true
+	return nil
+}
+
+func TestStopTriggerWaitsManualRun(t *testing.T) {
+	repo := &stubTaskRepo{}
+	c := NewTaskCase(repo, NewLotteryConfig(DefaultLotteryLimits()))
+	started := make(chan struct{})
+	job := func(ctx context.Context) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+	if err := c.Register("fill", constant.TaskTypeInterval, "1m", 0, job); err != nil {
+		t.Fatalf("register err:%v", err)
+	}
+	if err := c.TriggerTask(context.Background(), "fill"); err != nil {
+		t.Fatalf("trigger err:%v", err)
+	}
+	<-started
+	c.StopTrigger()
+	if !repo.finished {
+		t.Errorf("StopTrigger returned before the manual run finished")
+	}
+	if err := c.TriggerTask(context.Background(), "fill"); err != ErrTaskStopped {
+		t.Errorf("trigger after stop should fail with ErrTaskStopped, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+)
+
+// User 用户表
+type User struct {
+	Id         uint       `gorm:"column:id;type:int(10) unsigned;primary_key;AUTO_INCREMENT" json:"id"`
+	UserName   string     `gorm:"column:user_name;type:varchar(50);comment:用户名;NOT NULL" json:"user_name"`
+	PassWord   string     `gorm:"column:pass_word;type:varchar(255);comment:用户密码;NOT NULL" json:"-"`
+	Signature  string     `gorm:"column:signature;type:varchar(255);comment:登录用户签名;NOT NULL" json:"signature"`
+	Role       int        `gorm:"column:role;type:tinyint(3) unsigned;default:0;comment:角色，0 普通用户，1 只读，2 运营，3 管理员;NOT NULL" json:"role"`
+	SysCreated *time.Time `gorm:"autoCreateTime;column:sys_created;type:datetime;default null;comment:注册时间" json:"sys_created"`
+}
+
+func (u *User) TableName() string {
+	return "t_user"
+}
+
+type UserRepo interface {
+	Get(id uint) (*User, error)
+	GetByUserName(userName string) (*User, error)
+	Create(user *User) error
+	DenyToken(tokenID string, expire time.Duration) error
+	IsTokenDenied(tokenID string) (bool, error)
+}
+
+type UserCase struct {
+	userRepo UserRepo
+}
+
+func NewUserCase(ur UserRepo) *UserCase {
+	return &UserCase{
+		userRepo: ur,
+	}
+}
+
+// Register 注册用户，注册成功直接返回登录token
+func (u *UserCase) Register(ctx context.Context, userName, password string) (*LoginRsp, error) {
+	if userName == "" || password == "" {
+		return nil, fmt.Errorf("UserCase|Register invalid user_name or password")
+	}
+	user, err := u.userRepo.GetByUserName(userName)
+	if err != nil {
+		log.ErrorContextf(ctx, "UserCase|Register|GetByUserName:%v", err)
+		return nil, fmt.Errorf("UserCase|Register:%v", err)
+	}
+	if user != nil {
+		return nil, fmt.Errorf("UserCase|Register user_name %s already exists", userName)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("UserCase|Register|GenerateFromPassword:%v", err)
+	}
+	user = &User{
+		UserName: userName,
+		PassWord: string(hash),
+	}
+	if err = u.userRepo.Create(user); err != nil {
+		log.ErrorContextf(ctx, "UserCase|Register|Create:%v", err)
+		return nil, fmt.Errorf("UserCase|Register:%v", err)
+	}
+	return u.issueToken(user.Id, user.UserName)
+}
+
+// Login 校验用户名密码并签发token，用户不存在或者密码错误返回nil
+func (u *UserCase) Login(ctx context.Context, userName, password string) (*LoginRsp, error) {
+	user, err := u.userRepo.GetByUserName(userName)
+	if err != nil {
+		log.ErrorContextf(ctx, "UserCase|Login|GetByUserName:%v", err)
+		return nil, fmt.Errorf("UserCase|Login:%v", err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(user.PassWord), []byte(password)); err != nil {
+		return nil, nil
+	}
+	return u.issueToken(user.Id, user.UserName)
+}
+
+// ParseToken 解析token，token无效或者已经被吊销返回错误
+func (u *UserCase) ParseToken(ctx context.Context, token string) (*utils.JWTClaims, error) {
+	if token == "" {
+		return nil, fmt.Errorf("UserCase|ParseToken token is empty")
+	}
+	claims, err := utils.ParseJwtToken(token, constant.SecretKey)
+	if err != nil || claims == nil {
+		return nil, fmt.Errorf("UserCase|ParseToken:%v", err)
+	}
+	if claims.StandardClaims.Issuer != constant.Issuer {
+		return nil, fmt.Errorf("UserCase|ParseToken invalid issuer %s", claims.StandardClaims.Issuer)
+	}
+	denied, err := u.userRepo.IsTokenDenied(claims.StandardClaims.Id)
+	if err != nil {
+		log.ErrorContextf(ctx, "UserCase|ParseToken|IsTokenDenied:%v", err)
+		return nil, fmt.Errorf("UserCase|ParseToken:%v", err)
+	}
+	if denied {
+		return nil, fmt.Errorf("UserCase|ParseToken token has been revoked")
+	}
+	return claims, nil
+}
+
+// Refresh 用有效的token换取新的token，旧token同时吊销
+func (u *UserCase) Refresh(ctx context.Context, token string) (*LoginRsp, error) {
+	claims, err := u.ParseToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("UserCase|Refresh:%v", err)
+	}
+	if err = u.revoke(claims); err != nil {
+		log.ErrorContextf(ctx, "UserCase|Refresh|revoke:%v", err)
+		return nil, fmt.Errorf("UserCase|Refresh:%v", err)
+	}
+	return u.issueToken(claims.UserID, claims.UserName)
+}
+
+// Logout 吊销token
+func (u *UserCase) Logout(ctx context.Context, token string) error {
+	claims, err := u.ParseToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("UserCase|Logout:%v", err)
+	}
+	if err = u.revoke(claims); err != nil {
+		log.ErrorContextf(ctx, "UserCase|Logout|revoke:%v", err)
+		return fmt.Errorf("UserCase|Logout:%v", err)
+	}
+	return nil
+}
+
+// CheckRole 校验用户是否拥有指定角色，角色实时从db读取，修改后立即生效
+func (u *UserCase) CheckRole(ctx context.Context, uid uint, role int) (bool, error) {
+	user, err := u.userRepo.Get(uid)
+	if err != nil {
+		log.ErrorContextf(ctx, "UserCase|CheckRole|Get:%v", err)
+		return false, fmt.Errorf("UserCase|CheckRole:%v", err)
+	}
+	if user == nil {
+		return false, nil
+	}
+	return user.Role >= role, nil
+}
+
+// revoke 将token加入黑名单，黑名单有效期和token剩余有效期一致
+func (u *UserCase) revoke(claims *utils.JWTClaims) error {
+	expire := time.Until(time.Unix(claims.StandardClaims.ExpiresAt, 0))
+	if expire <= 0 {
+		return nil
+	}
+	return u.userRepo.DenyToken(claims.StandardClaims.Id, expire)
+}
+
+func (u *UserCase) issueToken(userID uint, userName string) (*LoginRsp, error) {
+	token, err := utils.GenerateJwtToken(constant.SecretKey, constant.Issuer, userID, userName)
+	if err != nil {
+		return nil, fmt.Errorf("UserCase|issueToken:%v", err)
+	}
+	return &LoginRsp{
+		UserID:   userID,
+		UserName: userName,
+		Token:    token,
+		ExpireAt: time.Now().Add(constant.TokenExpireDuration).Unix(),
+	}, nil
+}
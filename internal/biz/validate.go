@@ -0,0 +1,232 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+// PrizeIssue 奖品配置问题，Field为出问题的字段
+type PrizeIssue struct {
+	PrizeId    uint             `json:"prize_id"`
+	ActivityId uint             `json:"activity_id"`
+	Title      string           `json:"title"`
+	Field      string           `json:"field"`
+	Code       constant.ErrCode `json:"code"`
+	Msg        string           `json:"msg"`
+}
+
+// PrizeValidationError 奖品配置校验不通过，包含全部问题
+type PrizeValidationError struct {
+	Issues []*PrizeIssue
+}
+
+func (e *PrizeValidationError) Error() string {
+	if len(e.Issues) == 0 {
+		return "prize config invalid"
+	}
+	issue := e.Issues[0]
+	return fmt.Sprintf("prize config invalid, %d issues, first: prize %q field %s: %s",
+		len(e.Issues), issue.Title, issue.Field, issue.Msg)
+}
+
+func newPrizeIssue(prize *Prize, field string, code constant.ErrCode, msg string) *PrizeIssue {
+	if msg == "" {
+		msg = constant.GetErrMsg(code)
+	}
+	return &PrizeIssue{
+		PrizeId:    prize.Id,
+		ActivityId: prize.ActivityId,
+		Title:      prize.Title,
+		Field:      field,
+		Code:       code,
+		Msg:        msg,
+	}
+}
+
+// checkPrize 校验单个奖品的配置，活动为nil表示奖品所属活动不存在
+func checkPrize(activity *Activity, prize *Prize) []*PrizeIssue {
+	issues := make([]*PrizeIssue, 0)
+	if activity == nil {
+		return append(issues, newPrizeIssue(prize, "activity_id", constant.ErrPrizeActivityInvalid, ""))
+	}
+	if prize.PrizeType > constant.PrizeTypeEntityLarge {
+		issues = append(issues, newPrizeIssue(prize, "prize_type", constant.ErrPrizeTypeInvalid, ""))
+	}
+	switch activity.SelectStrategy {
+	case constant.PrizeSelectorWeight:
+		if prize.Weight == 0 {
+			issues = append(issues, newPrizeIssue(prize, "weight", constant.ErrPrizeWeightInvalid, ""))
+		}
+	default:
+		if _, _, ok := ParsePrizeCode(prize.PrizeCode, int(activity.PrizeCodeMax)); !ok {
+			issues = append(issues, newPrizeIssue(prize, "prize_code", constant.ErrPrizeCodeInvalid,
+				fmt.Sprintf("prize_code %q must be low-high within [0, %d)", prize.PrizeCode, activity.PrizeCodeMax)))
+		}
+	}
+	if !prize.EndTime.After(prize.BeginTime) {
+		issues = append(issues, newPrizeIssue(prize, "end_time", constant.ErrPrizeTimeInvalid, ""))
+	} else if time.Duration(prize.PrizeTime)*24*time.Hour > prize.EndTime.Sub(prize.BeginTime) {
+		issues = append(issues, newPrizeIssue(prize, "prize_time", constant.ErrPrizeTimeTooLong, ""))
+	}
+	return issues
+}
+
+// checkPlanProfile 校验奖品引用的发奖计划分布曲线已经配置
+func checkPlanProfile(limits *LotteryLimits, prize *Prize) []*PrizeIssue {
+	if _, ok := limits.GetPlanProfile(prize.PlanProfile); ok {
+		return nil
+	}
+	return []*PrizeIssue{newPrizeIssue(prize, "plan_profile", constant.ErrPlanProfileInvalid,
+		fmt.Sprintf("plan_profile %q not configured", prize.PlanProfile))}
+}
+
+// checkPrizeConflicts 校验同一个活动内奖品之间的冲突，只报告incoming中奖品的问题
+// 编码区间只有在两个奖品的有效期有交集时才算重叠
+func checkPrizeConflicts(activity *Activity, existing, incoming []*Prize) []*PrizeIssue {
+	issues := make([]*PrizeIssue, 0)
+	if activity == nil {
+		return issues
+	}
+	if activity.SelectStrategy == constant.PrizeSelectorWeight {
+		total := int(activity.NoPrizeWeight)
+		for _, prize := range append(existing, incoming...) {
+			total += int(prize.Weight)
+		}
+		if total > constant.WeightTotalMax {
+			for _, prize := range incoming {
+				issues = append(issues, newPrizeIssue(prize, "weight", constant.ErrPrizeWeightOverflow,
+					fmt.Sprintf("weight total %d of activity %d exceeds %d", total, activity.Id, constant.WeightTotalMax)))
+			}
+		}
+		return issues
+	}
+	codeMax := int(activity.PrizeCodeMax)
+	checked := append([]*Prize{}, existing...)
+	for _, prize := range incoming {
+		low, high, ok := ParsePrizeCode(prize.PrizeCode, codeMax)
+		if ok {
+			for _, other := range checked {
+				otherLow, otherHigh, otherOk := ParsePrizeCode(other.PrizeCode, codeMax)
+				if !otherOk || low > otherHigh || otherLow > high {
+					continue
+				}
+				if !prize.BeginTime.Before(other.EndTime) || !other.BeginTime.Before(prize.EndTime) {
+					continue
+				}
+				issues = append(issues, newPrizeIssue(prize, "prize_code", constant.ErrPrizeCodeOverlap,
+					fmt.Sprintf("prize_code %s overlaps with prize %d %q prize_code %s",
+						prize.PrizeCode, other.Id, other.Title, other.PrizeCode)))
+			}
+		}
+		checked = append(checked, prize)
+	}
+	return issues
+}
+
+// isLivePrize 奖品是否会参与抽奖：状态正常且未过期
+func isLivePrize(prize *Prize, now time.Time) bool {
+	return prize.SysStatus == constant.PrizeStatusNormal && prize.EndTime.After(now)
+}
+
+// getPrizeActivity 获取奖品所属活动的配置，活动不存在返回nil
+func (a *AdminCase) getPrizeActivity(activityID uint) (*Activity, error) {
+	if activityID == constant.DefaultActivityID {
+		return DefaultActivity(a.lotteryConf.Limits()), nil
+	}
+	activity, err := a.activityRepo.Get(activityID)
+	if err != nil || activity == nil {
+		return nil, err
+	}
+	activity.FillDefault(a.lotteryConf.Limits())
+	return activity, nil
+}
+
+// ValidatePrizes 新增或者修改奖品之前校验配置，包括和同一个活动内其他有效奖品的冲突
+func (a *AdminCase) ValidatePrizes(ctx context.Context, prizeList []*Prize) error {
+	now := time.Now()
+	incomingMap := make(map[uint][]*Prize)
+	activityIDs := make([]uint, 0)
+	for _, prize := range prizeList {
+		if _, ok := incomingMap[prize.ActivityId]; !ok {
+			activityIDs = append(activityIDs, prize.ActivityId)
+		}
+		incomingMap[prize.ActivityId] = append(incomingMap[prize.ActivityId], prize)
+	}
+	issues := make([]*PrizeIssue, 0)
+	for _, activityID := range activityIDs {
+		incoming := incomingMap[activityID]
+		activity, err := a.getPrizeActivity(activityID)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ValidatePrizes|getPrizeActivity:%v", err)
+			return fmt.Errorf("adminCase|ValidatePrizes:%v", err)
+		}
+		for _, prize := range incoming {
+			issues = append(issues, checkPrize(activity, prize)...)
+			issues = append(issues, checkPlanProfile(a.lotteryConf.Limits(), prize)...)
+		}
+		if activity == nil {
+			continue
+		}
+		list, err := a.prizeRepo.GetAllByActivity(activityID)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|ValidatePrizes|GetAllByActivity:%v", err)
+			return fmt.Errorf("adminCase|ValidatePrizes:%v", err)
+		}
+		// 修改奖品时，奖品自身的旧配置不参与冲突校验
+		updating := make(map[uint]bool)
+		for _, prize := range incoming {
+			if prize.Id > 0 {
+				updating[prize.Id] = true
+			}
+		}
+		existing := make([]*Prize, 0, len(list))
+		for _, prize := range list {
+			if isLivePrize(prize, now) && !updating[prize.Id] {
+				existing = append(existing, prize)
+			}
+		}
+		issues = append(issues, checkPrizeConflicts(activity, existing, incoming)...)
+	}
+	if len(issues) > 0 {
+		return &PrizeValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// LintPrizes 检查全部有效奖品的配置和奖品之间的冲突，只报告问题不做修改
+func (a *AdminCase) LintPrizes(ctx context.Context) ([]*PrizeIssue, error) {
+	list, err := a.prizeRepo.GetAll()
+	if err != nil {
+		log.ErrorContextf(ctx, "adminCase|LintPrizes|GetAll:%v", err)
+		return nil, fmt.Errorf("adminCase|LintPrizes:%v", err)
+	}
+	now := time.Now()
+	liveMap := make(map[uint][]*Prize)
+	activityIDs := make([]uint, 0)
+	for _, prize := range list {
+		if !isLivePrize(prize, now) {
+			continue
+		}
+		if _, ok := liveMap[prize.ActivityId]; !ok {
+			activityIDs = append(activityIDs, prize.ActivityId)
+		}
+		liveMap[prize.ActivityId] = append(liveMap[prize.ActivityId], prize)
+	}
+	issues := make([]*PrizeIssue, 0)
+	for _, activityID := range activityIDs {
+		activity, err := a.getPrizeActivity(activityID)
+		if err != nil {
+			log.ErrorContextf(ctx, "adminCase|LintPrizes|getPrizeActivity:%v", err)
+			return nil, fmt.Errorf("adminCase|LintPrizes:%v", err)
+		}
+		for _, prize := range liveMap[activityID] {
+			issues = append(issues, checkPrize(activity, prize)...)
+			issues = append(issues, checkPlanProfile(a.lotteryConf.Limits(), prize)...)
+		}
+		issues = append(issues, checkPrizeConflicts(activity, nil, liveMap[activityID])...)
+	}
+	return issues, nil
+}
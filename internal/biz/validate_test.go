@@ -0,0 +1,49 @@
+package biz
+
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"testing"
+	"time"
+)
+
+func TestCheckPrize(t *testing.T) {
+	activity := DefaultActivity(DefaultLotteryLimits())
+	now := time.Now()
+	prize := &Prize{Title: "bad", PrizeCode: "5-1", PrizeType: 9, PrizeTime: 3,
+		BeginTime: now, EndTime: now.Add(24 * time.Hour)}
+	codes := make(map[constant.ErrCode]bool)
+	for _, issue := range checkPrize(activity, prize) {
+		codes[issue.Code] = true
+	}
+	for _, code := range []constant.ErrCode{constant.ErrPrizeCodeInvalid, constant.ErrPrizeTypeInvalid,
+		constant.ErrPrizeTimeTooLong} {
+		if !codes[code] {
+			t.Errorf("want issue code %d, got %v", code, codes)
+		}
+	}
+	if issues := checkPrize(nil, prize); len(issues) != 1 || issues[0].Code != constant.ErrPrizeActivityInvalid {
+		t.Errorf("missing activity should be reported, got %v", issues)
+	}
+}
+
+func TestCheckPrizeConflicts(t *testing.T) {
+	activity := DefaultActivity(DefaultLotteryLimits())
+	now := time.Now()
+	existing := []*Prize{{Id: 1, PrizeCode: "0-99", BeginTime: now, EndTime: now.Add(time.Hour)}}
+	overlap := &Prize{PrizeCode: "50-150", BeginTime: now, EndTime: now.Add(time.Hour)}
+	if issues := checkPrizeConflicts(activity, existing, []*Prize{overlap}); len(issues) != 1 ||
+		issues[0].Code != constant.ErrPrizeCodeOverlap {
+		t.Errorf("overlapping prize code should be reported, got %v", issues)
+	}
+	// 有效期没有交集的奖品可以复用编码区间
+	later := &Prize{PrizeCode: "50-150", BeginTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}
+	if issues := checkPrizeConflicts(activity, existing, []*Prize{later}); len(issues) != 0 {
+		t.Errorf("disjoint time window should not overlap, got %v", issues)
+	}
+
+	weightActivity := &Activity{SelectStrategy: constant.PrizeSelectorWeight, NoPrizeWeight: constant.WeightTotalMax}
+	if issues := checkPrizeConflicts(weightActivity, nil, []*Prize{{Weight: 1}}); len(issues) != 1 ||
+		issues[0].Code != constant.ErrPrizeWeightOverflow {
+		t.Errorf("weight overflow should be reported, got %v", issues)
+	}
+}
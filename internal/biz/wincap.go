@@ -0,0 +1,129 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WinCap 一次中奖需要校验的用户中奖次数上限，上限为0表示不限制
+type WinCap struct {
+	PrizeId    uint
+	PrizeType  uint
+	PrizeMax   uint // 每个用户最多中该奖品的次数
+	TotalMax   uint // 每个用户在活动中最多中奖次数
+	TypeDayMax uint // 每个用户每天最多中该类型奖品的次数
+	Day        int  // 日期，如：20220625
+}
+
+// Enabled 是否配置了任意一种上限
+func (c *WinCap) Enabled() bool {
+	return c.PrizeMax > 0 || c.TotalMax > 0 || c.TypeDayMax > 0
+}
+
+// UserWinCount 用户在活动中的中奖次数，从中奖记录统计，用于初始化缓存中的计数
+type UserWinCount struct {
+	Total   int64
+	Prize   map[uint]int64 // 奖品ID -> 中奖次数
+	TypeDay map[uint]int64 // 奖品类型 -> 当天中奖次数
+}
+
+// WinCapRepo 用户中奖次数计数，校验上限和计数递增在redis中原子完成
+type WinCapRepo interface {
+	// IncrUserWin 未达到上限时中奖次数加一并返回true，达到上限返回false，计数未初始化返回 ErrWinCountNotLoaded
+	IncrUserWin(activityID, uid uint, winCap *WinCap) (bool, error)
+	// DecrUserWin 发奖失败时归还中奖次数
+	DecrUserWin(activityID, uid uint, winCap *WinCap) error
+	// InitUserWin 用中奖记录的统计初始化计数，已经存在的计数不会被覆盖
+	InitUserWin(activityID, uid uint, day int, count *UserWinCount) error
+}
+
+// ErrWinCountNotLoaded 缓存中没有用户的中奖次数，需要从中奖记录初始化
+var ErrWinCountNotLoaded = errors.New("user win count not loaded")
+
+// ParseTypeDayWinMax 解析每天每种奖品类型的中奖上限配置，格式 类型:次数,类型:次数
+func ParseTypeDayWinMax(str string) (map[uint]uint, error) {
+	typeMax := make(map[uint]uint)
+	if strings.TrimSpace(str) == "" {
+		return typeMax, nil
+	}
+	for _, item := range strings.Split(str, ",") {
+		pair := strings.Split(strings.TrimSpace(item), ":")
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("type_day_win_max item %q should be type:max", item)
+		}
+		prizeType, err1 := strconv.ParseUint(strings.TrimSpace(pair[0]), 10, 32)
+		max, err2 := strconv.ParseUint(strings.TrimSpace(pair[1]), 10, 32)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("type_day_win_max item %q should be type:max", item)
+		}
+		typeMax[uint(prizeType)] = uint(max)
+	}
+	return typeMax, nil
+}
+
+// newWinCap 根据活动和奖品配置获取本次中奖需要校验的上限
+func newWinCap(activity *Activity, prize *LotteryPrize, now time.Time) *WinCap {
+	y, m, d := now.Date()
+	winCap := &WinCap{
+		PrizeId:   prize.Id,
+		PrizeType: prize.PrizeType,
+		PrizeMax:  prize.UserWinMax,
+		TotalMax:  activity.UserWinMax,
+		Day:       y*10000 + int(m)*100 + d,
+	}
+	// 配置格式在活动写入时已经校验，这里忽略错误
+	typeMax, _ := ParseTypeDayWinMax(activity.TypeDayWinMax)
+	winCap.TypeDayMax = typeMax[prize.PrizeType]
+	return winCap
+}
+
+// AcquireWinCap 校验用户中奖次数上限并占用一次中奖次数，达到上限返回false
+// 没有配置上限时不计数，返回的WinCap为nil
+func (l *LotteryCase) AcquireWinCap(ctx context.Context, activity *Activity, uid uint,
+	prize *LotteryPrize) (bool, *WinCap, error) {
+	// 当天的中奖次数按业务时区的日期统计
+	now := time.Now().In(l.lotteryConf.Limits().Location)
+	winCap := newWinCap(activity, prize, now)
+	if !winCap.Enabled() {
+		return true, nil, nil
+	}
+	ok, err := l.winCapRepo.IncrUserWin(activity.Id, uid, winCap)
+	if errors.Is(err, ErrWinCountNotLoaded) {
+		// 缓存过期或者丢失，用中奖记录重新统计后再校验
+		y, m, d := now.Date()
+		var count *UserWinCount
+		count, err = l.resultRepo.CountUserWin(activity.Id, uid, time.Date(y, m, d, 0, 0, 0, 0, now.Location()))
+		if err != nil {
+			log.ErrorContextf(ctx, "LotteryCase|AcquireWinCap|CountUserWin:%v", err)
+			return false, nil, fmt.Errorf("LotteryCase|AcquireWinCap:%v", err)
+		}
+		if err = l.winCapRepo.InitUserWin(activity.Id, uid, winCap.Day, count); err != nil {
+			log.ErrorContextf(ctx, "LotteryCase|AcquireWinCap|InitUserWin:%v", err)
+			return false, nil, fmt.Errorf("LotteryCase|AcquireWinCap:%v", err)
+		}
+		ok, err = l.winCapRepo.IncrUserWin(activity.Id, uid, winCap)
+	}
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|AcquireWinCap|IncrUserWin:%v", err)
+		return false, nil, fmt.Errorf("LotteryCase|AcquireWinCap:%v", err)
+	}
+	if !ok {
+		return false, nil, nil
+	}
+	return true, winCap, nil
+}
+
+// ReleaseWinCap 占用中奖次数之后发奖失败，归还中奖次数
+func (l *LotteryCase) ReleaseWinCap(ctx context.Context, activityID, uid uint, winCap *WinCap) {
+	if winCap == nil {
+		return
+	}
+	if err := l.winCapRepo.DecrUserWin(activityID, uid, winCap); err != nil {
+		log.ErrorContextf(ctx, "LotteryCase|ReleaseWinCap:%v", err)
+	}
+}
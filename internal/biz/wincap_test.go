@@ -0,0 +1,33 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTypeDayWinMax(t *testing.T) {
+	typeMax, err := ParseTypeDayWinMax("5:1, 4:2")
+	if err != nil || typeMax[5] != 1 || typeMax[4] != 2 || len(typeMax) != 2 {
+		t.Errorf("got %v, err %v", typeMax, err)
+	}
+	if typeMax, err = ParseTypeDayWinMax(""); err != nil || len(typeMax) != 0 {
+		t.Errorf("empty config should be no limit, got %v, err %v", typeMax, err)
+	}
+	for _, str := range []string{"5", "5:a", "5:1:2"} {
+		if _, err = ParseTypeDayWinMax(str); err == nil {
+			t.Errorf("%q should be invalid", str)
+		}
+	}
+}
+
+func TestNewWinCap(t *testing.T) {
+	activity := &Activity{UserWinMax: 3, TypeDayWinMax: "5:1"}
+	now := time.Date(2022, 6, 25, 10, 0, 0, 0, time.Local)
+	winCap := newWinCap(activity, &LotteryPrize{Id: 7, PrizeType: 5, UserWinMax: 2}, now)
+	if winCap.PrizeMax != 2 || winCap.TotalMax != 3 || winCap.TypeDayMax != 1 || winCap.Day != 20220625 {
+		t.Errorf("got %+v", winCap)
+	}
+	if newWinCap(&Activity{}, &LotteryPrize{Id: 7}, now).Enabled() {
+		t.Errorf("no cap configured should be disabled")
+	}
+}
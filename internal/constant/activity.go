@@ -0,0 +1,29 @@
+package constant
+
+import "fmt"
+
+// 活动状态
+const (
+	ActivityStatusNormal = 1 // 正常
+	ActivityStatusDelete = 2 // 删除
+)
+
+const (
+	DefaultActivityID = 0 // 默认活动，兼容未区分活动的历史数据
+)
+
+const (
+	ActivityCacheKeyPrefix      = "activity_"
+	ActivityInfoCacheKeyPrefix  = "activity_info_"
+	ActivityInfoCacheTime       = 86400
+	IpLotteryDayNumPrefix       = "day_ip_num_"        // 后接业务日期和分片，如 day_ip_num_20220625_0
+	IpPrefixLotteryDayNumPrefix = "day_ip_prefix_num_" // 网段的每日抽奖次数，后接业务日期和分片
+)
+
+// ActivityCacheKey 按活动划分缓存key的命名空间，默认活动沿用原有的key
+func ActivityCacheKey(activityID uint, key string) string {
+	if activityID == DefaultActivityID {
+		return key
+	}
+	return fmt.Sprintf("%s%d_%s", ActivityCacheKeyPrefix, activityID, key)
+}
@@ -0,0 +1,22 @@
+package constant
+
+// 用户角色，数值越大权限越高，高权限角色拥有低权限角色的全部权限
+const (
+	RoleUser     = 0 // 普通用户，无管理后台权限
+	RoleViewer   = 1 // 只读，可以查看后台数据
+	RoleOperator = 2 // 运营，可以新增、修改奖品和活动，导入优惠券
+	RoleAdmin    = 3 // 管理员，可以清空数据
+)
+
+const (
+	// SysIP 操作人IP在context中的key
+	SysIP = "Sys-IP"
+	// AuditPayloadMaxLen 审计日志记录的请求内容最大长度
+	AuditPayloadMaxLen = 4096
+)
+
+// 后台分页
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
@@ -21,6 +21,98 @@ const (
 	PrizeStatusDelete = 2 // 删除
 )
 
+// DefaultPlanProfile 奖品没有指定发奖计划分布曲线时使用的名称
+const DefaultPlanProfile = "default"
+
+const (
+	PrizePlanBatchSize  = 500 // 发奖计划每批写入的行数
+	PoolLedgerTaskLimit = 500 // 定时任务每次加到奖品池的放入记录数
+)
+
+// 优惠券状态
+const (
+	CouponStatusNormal = 1 // 正常
+	CouponStatusDelete = 2 // 作废或已发放
+)
+
+// 中奖纪录状态，作弊沿用 Result.SysStatus 注释约定的2，sql/lottery.sql 中的“2-删除，3-作弊”没有代码使用
+// 正常纪录一直写入1，和展示、统计的查询条件一致
+const (
+	ResultStatusNormal = 1 // 正常
+	ResultStatusCheat  = 2 // 作弊，风控可疑的用户中奖，需要人工复核
+)
+
+// 实物奖品履约状态，待领取 → 已领取 → 已发货 → 已签收，待领取超时 → 已过期 → 已回库
+const (
+	FulfillStatusNone      = 0 // 虚拟奖品，无需履约
+	FulfillStatusWon       = 1 // 待领取
+	FulfillStatusClaimed   = 2 // 已填写收货信息，待发货
+	FulfillStatusShipped   = 3 // 已发货
+	FulfillStatusDelivered = 4 // 已签收
+	FulfillStatusExpired   = 5 // 超时未领取
+	FulfillStatusRestocked = 6 // 已回库
+)
+
+// 虚拟奖品发放状态
+const (
+	DeliveryStatusPending = 0 // 待发放，失败后按退避时间重试
+	DeliveryStatusSuccess = 1 // 发放成功
+	DeliveryStatusDead    = 2 // 重试次数用完，进入死信表
+)
+
+// 死信状态
+const (
+	DeadLetterStatusNew      = 0 // 待处理
+	DeadLetterStatusReplayed = 1 // 已重新发放
+)
+
+// 虚拟奖品发放方式
+const (
+	DelivererWebhook = "webhook"
+	DelivererGrpc    = "grpc"
+)
+
+const (
+	DeliveryKeyPrefix       = "lottery_result_" // 幂等键前缀，后接中奖记录ID
+	DeliveryRetryMax        = 8
+	DeliveryRetryBackoff    = 30 * time.Second // 第n次重试间隔为 DeliveryRetryBackoff * 2^(n-1)
+	DeliveryRetryBackoffMax = time.Hour
+	DeliveryTaskLimit       = 100
+	DeliverTimeout          = 2 * time.Second
+)
+
+const (
+	ClaimExpireTime  = 7 * 24 * time.Hour // 实物奖品领取期限
+	FulfillTaskLimit = 100                // 定时任务每次处理的过期纪录数
+)
+
+// 定时任务调度方式
+const (
+	TaskTypeCron     = "cron"     // cron表达式，支持@every、@daily等描述符
+	TaskTypeInterval = "interval" // 固定间隔，执行时间按间隔对齐，多个实例算出的时间一致
+)
+
+// 定时任务执行状态
+const (
+	TaskRunStatusRunning = 1 // 执行中
+	TaskRunStatusSuccess = 2 // 执行成功
+	TaskRunStatusFailed  = 3 // 执行失败或panic
+)
+
+// 定时任务触发方式
+const (
+	TaskTriggerSchedule = 1 // 按调度计划执行
+	TaskTriggerManual   = 2 // 后台手动触发
+)
+
+const (
+	TaskLockTTL        = time.Minute // 没有配置lock_ttl时任务锁的过期时间，执行中由看门狗续期
+	TaskRunErrorMaxLen = 1024        // 执行记录中错误信息的最大长度
+	TaskSlotKeyPrefix  = "lottery_task_slot_"
+	TaskLockKeyPrefix  = "lottery_task_lock_"
+	TaskPausedCacheKey = "lottery_task_paused"
+)
+
 const (
 	Issuer              = "lottery"
 	Expires             = 3600
@@ -30,4 +122,14 @@ const (
 
 const (
 	LotteryLockKeyPrefix = "lucky_lock_"
+	TokenDenyKeyPrefix   = "token_deny_"
+)
+
+const (
+	Authorization = "Authorization"
+	BearerPrefix  = "Bearer "
+	UserName      = "User-Name"
+	ClientIP      = "Client-IP" // 服务端确定的客户端IP
+	ForwardedFor  = "X-Forwarded-For"
+	RealIP        = "X-Real-IP"
 )
@@ -25,7 +25,27 @@ const (
 	ErrBlackedIP        ErrCode = 10003
 	ErrBlackedUser      ErrCode = 10004
 	ErrPrizeNotEnough   ErrCode = 10005
+	ErrActivityInvalid  ErrCode = 10006
+	ErrRegister         ErrCode = 10007
+	ErrPermissionDenied ErrCode = 10008
+	ErrPrizeInvalid     ErrCode = 10009
 	ErrNotWon           ErrCode = 100010
+	ErrResultInvalid    ErrCode = 10011
+	ErrTaskNotFound     ErrCode = 10012
+	ErrTaskRunning      ErrCode = 10013
+	ErrRiskDenied       ErrCode = 10014
+	ErrRiskChallenge    ErrCode = 10015
+
+	// 奖品配置字段级错误码
+	ErrPrizeCodeInvalid     ErrCode = 10101
+	ErrPrizeCodeOverlap     ErrCode = 10102
+	ErrPrizeWeightInvalid   ErrCode = 10103
+	ErrPrizeWeightOverflow  ErrCode = 10104
+	ErrPrizeTimeInvalid     ErrCode = 10105
+	ErrPrizeTimeTooLong     ErrCode = 10106
+	ErrPrizeTypeInvalid     ErrCode = 10107
+	ErrPrizeActivityInvalid ErrCode = 10108
+	ErrPlanProfileInvalid   ErrCode = 10109
 )
 
 var errMsgDic = map[ErrCode]string{
@@ -40,7 +60,26 @@ var errMsgDic = map[ErrCode]string{
 	ErrBlackedIP:        "blacked ip",
 	ErrBlackedUser:      "blacked user",
 	ErrPrizeNotEnough:   "prize not enough",
+	ErrActivityInvalid:  "activity not exists or not in progress",
+	ErrRegister:         "register fail",
+	ErrPermissionDenied: "permission denied",
+	ErrPrizeInvalid:     "prize config invalid",
 	ErrNotWon:           "not won,please try again!",
+	ErrResultInvalid:    "result not exists or status invalid",
+	ErrTaskNotFound:     "task not found",
+	ErrTaskRunning:      "task is running",
+	ErrRiskDenied:       "risk denied",
+	ErrRiskChallenge:    "risk challenge required",
+
+	ErrPrizeCodeInvalid:     "prize_code must be low-high within prize code space",
+	ErrPrizeCodeOverlap:     "prize_code overlaps with another prize",
+	ErrPrizeWeightInvalid:   "weight must be greater than 0",
+	ErrPrizeWeightOverflow:  "weight total of activity exceeds max",
+	ErrPrizeTimeInvalid:     "end_time must be after begin_time",
+	ErrPrizeTimeTooLong:     "prize_time is longer than begin_time-end_time window",
+	ErrPrizeTypeInvalid:     "prize_type invalid",
+	ErrPrizeActivityInvalid: "activity not exists",
+	ErrPlanProfileInvalid:   "plan_profile not configured",
 }
 
 // GetErrMsg 获取错误描述
@@ -1,5 +1,8 @@
 package constant
 
+import "time"
+
+// 以下限制为默认值，可以在配置文件的 lottery 部分修改
 const (
 	UserPrizeMax = 20    // 用户每天最多抽奖次数
 	IpPrizeMax   = 30000 // 同一个IP每天最多抽奖次数
@@ -11,10 +14,30 @@ const (
 	UserFrameSize = 2
 )
 
+const (
+	IpV4PrefixBits = 24 // IP网段限制默认的IPv4前缀长度
+	IpV6PrefixBits = 64 // IPv6通常给一个用户分配一个/64
+)
+
+const (
+	DefaultTimezone  = "Asia/Shanghai" // 默认业务时区
+	DayNumCacheGrace = time.Hour       // 每日次数缓存在业务时区的当天结束后再保留的时间
+)
+
 const (
 	PrizeCodeMax = 10000
 )
 
+// 中奖奖品选择策略，按活动配置
+const (
+	PrizeSelectorRange  = 0 // 中奖编码区间，随机编码落在奖品的 low-high 区间内即中奖
+	PrizeSelectorWeight = 1 // 概率权重，中奖概率 = 奖品权重 / (全部奖品权重 + 不中奖权重)
+)
+
+const (
+	WeightTotalMax = 1<<31 - 1 // 权重总和上限，抽奖编码需要记录在中奖记录中
+)
+
 const (
 	PrizeTypeVirtualCoin  = 0 // 虚拟币
 	PrizeTypeCouponSame   = 1 // 虚拟券，相同的码
@@ -24,6 +47,48 @@ const (
 	PrizeTypeEntityLarge  = 5 // 实物大奖
 )
 
+// 原子抽奖脚本的执行结果
+const (
+	DrawOK          = 0 // 次数校验通过，奖品池和券码扣减成功
+	DrawUserLimit   = 1 // 用户今日抽奖次数达到上限
+	DrawIpLimit     = 2 // IP今日抽奖次数达到上限
+	DrawPoolEmpty   = 3 // 奖品池中该奖品数量不足
+	DrawCouponEmpty = 4 // 优惠券券码已经发完
+)
+
+// 抽奖事务消息状态
+const (
+	OutboxStatusPending   = 0 // redis已经扣减，db事务未提交
+	OutboxStatusCommitted = 1 // db事务已经提交，待清理redis
+	OutboxStatusAborted   = 2 // db事务未提交，待归还redis扣减
+	OutboxStatusDone      = 3 // 处理完成
+)
+
+// 中奖纪录写入方式
+const (
+	ResultSinkSync  = "sync"  // 在发奖事务中同步写入
+	ResultSinkAsync = "async" // 异步批量写入
+)
+
+const (
+	ResultSinkQueueSize      = 10000
+	ResultSinkBatchSize      = 200
+	ResultSinkFlushInterval  = time.Second
+	ResultSinkEnqueueTimeout = 50 * time.Millisecond
+	ResultSinkSpillPath      = "./log/result_spill.log"
+	ResultSinkPingTimeout    = time.Second // 批量写库失败后检查数据库是否可用
+)
+
+const (
+	OutboxRelayTimeout = 60 * time.Second // 超过该时间未处理的事务消息由定时任务修复
+	OutboxRelayLimit   = 100
+)
+
+const (
+	UserWinCacheTime        = 7 * 86400 // 用户中奖次数缓存时间，过期后从中奖记录重新统计
+	UserTypeDayWinCacheTime = 2 * 86400
+)
+
 const (
 	DefaultBlackTime    = 7 * 86400  // 默认1周
 	AllPrizeCacheTime   = 30 * 86400 // 默认1周
@@ -31,10 +96,31 @@ const (
 )
 
 const (
-	AllPrizeCacheKey        = "all_prize"
-	UserCacheKeyPrefix      = "black_user_info_"
-	IpCacheKeyPrefix        = "black_ip_info_"
-	UserLotteryDayNumPrefix = "user_lottery_day_num_"
-	PrizePoolCacheKey       = "prize_pool"
-	PrizeCouponCacheKey     = "prize_coupon_"
+	AllPrizeCacheKey         = "all_prize"
+	UserCacheKeyPrefix       = "black_user_info_"
+	IpCacheKeyPrefix         = "black_ip_info_"
+	UserLotteryDayNumPrefix  = "user_lottery_day_num_" // 后接业务日期和分片，如 user_lottery_day_num_20220625_0
+	UserLotteryMissNumPrefix = "user_lottery_miss_num_"
+	UserWinNumPrefix         = "user_win_num_"
+	UserTypeDayWinNumPrefix  = "user_type_day_win_num_"
+	PrizePoolCacheKey        = "prize_pool"
+	PrizeCouponCacheKey      = "prize_coupon_"
+	DrawPendingCacheKey      = "draw_pending"
+	RecentWinnerCacheKey     = "recent_winners"
+	PoolLedgerCacheKey       = "prize_pool_ledger"  // 已经加到奖品池的放入记录ID集合
+	BlackIpCidrCacheKey      = "black_ip_cidr_list" // 生效中的网段黑名单
+)
+
+// PoolLedgerCacheTime 放入记录ID集合的过期时间，每次加到奖品池时续期
+const PoolLedgerCacheTime = 7 * 24 * time.Hour
+
+const (
+	BlackIpCidrCacheTime       = 10 * time.Minute // 网段黑名单缓存时间，修改网段黑名单时删除缓存
+	BlackIpCidrRefreshInterval = 10 * time.Second // 每个实例从缓存重新加载网段黑名单的间隔
+)
+
+const (
+	RecentWinnerNum       = 20               // 最近中奖用户展示条数
+	RecentWinnerCacheTime = 10 * time.Second // 最近中奖用户缓存时间，过期后从db重新加载
+	ShowcaseMaxAge        = 5                // 奖品展示接口的http缓存时间，单位秒
 )
@@ -0,0 +1,24 @@
+package constant
+
+import "time"
+
+// 风控动作，数值越大越严重
+const (
+	RiskActionAllow     = 0 // 放行
+	RiskActionChallenge = 1 // 需要验证，开启降级时不拒绝，降低中奖概率
+	RiskActionDeny      = 2 // 拒绝
+)
+
+// 风控频率规则的统计维度
+const (
+	RiskDimensionUser   = "user"
+	RiskDimensionIp     = "ip"
+	RiskDimensionDevice = "device"
+)
+
+const (
+	RiskVelocityKeyPrefix = "risk_velocity_" // 后接规则名称、统计对象和时间窗口序号
+	RiskIpUsersKeyPrefix  = "risk_ip_users_" // 后接IP和时间窗口序号
+	RiskReasonsMaxLen     = 255              // 风控记录中命中原因的最大长度
+	RiskWindowMin         = time.Second      // 风控规则的最小时间窗口
+)
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"gorm.io/gorm"
+	"time"
+)
+
+type activityRepo struct {
+	data *Data
+}
+
+func NewActivityRepo(data *Data) biz.ActivityRepo {
+	return &activityRepo{
+		data: data,
+	}
+}
+
+func (r *activityRepo) Get(id uint) (*biz.Activity, error) {
+	db := r.data.db
+	activity := &biz.Activity{}
+	err := db.Model(&biz.Activity{}).Where("id = ?", id).First(activity).Error
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("activityRepo|Get:%v", err)
+	}
+	return activity, nil
+}
+
+// GetWithCache 优先从缓存获取活动信息，缓存没有再从db获取并回写缓存
+func (r *activityRepo) GetWithCache(id uint) (*biz.Activity, error) {
+	activity, err := r.getByCache(id)
+	if err == nil && activity != nil {
+		return activity, nil
+	}
+	activity, err = r.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo|GetWithCache:%v", err)
+	}
+	if activity == nil {
+		return nil, nil
+	}
+	if err = r.setByCache(activity); err != nil {
+		return nil, fmt.Errorf("activityRepo|GetWithCache:%v", err)
+	}
+	return activity, nil
+}
+
+func (r *activityRepo) GetAll() ([]*biz.Activity, error) {
+	db := r.data.db
+	var activities []*biz.Activity
+	err := db.Model(&biz.Activity{}).Order("id asc").Find(&activities).Error
+	if err != nil {
+		return nil, fmt.Errorf("activityRepo|GetAll:%v", err)
+	}
+	return activities, nil
+}
+
+func (r *activityRepo) Create(activity *biz.Activity) error {
+	db := r.data.db
+	err := db.Model(&biz.Activity{}).Create(activity).Error
+	if err != nil {
+		return fmt.Errorf("activityRepo|Create:%v", err)
+	}
+	return nil
+}
+
+func (r *activityRepo) Update(activity *biz.Activity, cols ...string) error {
+	db := r.data.db
+	var err error
+	if len(cols) == 0 {
+		err = db.Model(activity).Updates(activity).Error
+	} else {
+		err = db.Model(activity).Select(cols).Updates(activity).Error
+	}
+	if err != nil {
+		return fmt.Errorf("activityRepo|Update:%v", err)
+	}
+	return nil
+}
+
+func (r *activityRepo) UpdateWithCache(activity *biz.Activity, cols ...string) error {
+	if err := r.UpdateByCache(activity); err != nil {
+		return fmt.Errorf("activityRepo|UpdateWithCache:%v", err)
+	}
+	return r.Update(activity, cols...)
+}
+
+// UpdateByCache 数据更新，直接清空缓存数据
+func (r *activityRepo) UpdateByCache(activity *biz.Activity) error {
+	if activity == nil || activity.Id <= 0 {
+		return nil
+	}
+	key := fmt.Sprintf(constant.ActivityInfoCacheKeyPrefix+"%d", activity.Id)
+	if err := r.data.cache.Delete(context.Background(), key); err != nil {
+		return fmt.Errorf("activityRepo|UpdateByCache:%v", err)
+	}
+	return nil
+}
+
+func (r *activityRepo) getByCache(id uint) (*biz.Activity, error) {
+	key := fmt.Sprintf(constant.ActivityInfoCacheKeyPrefix+"%d", id)
+	ret, exist, err := r.data.cache.Get(context.Background(), key)
+	if err != nil {
+		log.Errorf("activityRepo|getByCache:%v", err)
+		return nil, err
+	}
+	if !exist || ret == "" {
+		return nil, nil
+	}
+	activity := &biz.Activity{}
+	if err = json.Unmarshal([]byte(ret), activity); err != nil {
+		return nil, fmt.Errorf("activityRepo|getByCache|json.Unmarshal:%v", err)
+	}
+	return activity, nil
+}
+
+func (r *activityRepo) setByCache(activity *biz.Activity) error {
+	bytes, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("activityRepo|setByCache|json.Marshal:%v", err)
+	}
+	key := fmt.Sprintf(constant.ActivityInfoCacheKeyPrefix+"%d", activity.Id)
+	if err = r.data.cache.Set(context.Background(), key, string(bytes),
+		time.Second*time.Duration(constant.ActivityInfoCacheTime)); err != nil {
+		return fmt.Errorf("activityRepo|setByCache:%v", err)
+	}
+	return nil
+}
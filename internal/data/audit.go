@@ -0,0 +1,24 @@
+package data
+
+import (
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+)
+
+type adminAuditRepo struct {
+	data *Data
+}
+
+func NewAdminAuditRepo(data *Data) biz.AdminAuditRepo {
+	return &adminAuditRepo{
+		data: data,
+	}
+}
+
+func (r *adminAuditRepo) Create(audit *biz.AdminAudit) error {
+	db := r.data.db
+	if err := db.Model(&biz.AdminAudit{}).Create(audit).Error; err != nil {
+		return fmt.Errorf("adminAuditRepo|Create:%v", err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/BitofferHub/pkg/middlewares/log"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type blackIpRepo struct {
@@ -93,6 +94,27 @@ func (r *blackIpRepo) GetAll() ([]*biz.BlackIp, error) {
 	return BlackIps, nil
 }
 
+// GetPage 按条件分页查询ip黑名单，返回当前页数据和总数
+func (r *blackIpRepo) GetPage(filter *biz.BlackIpFilter, page *biz.PageQuery) ([]*biz.BlackIp, int64, error) {
+	db := r.data.db.Model(&biz.BlackIp{})
+	if filter.Ip != "" {
+		db = db.Where("ip LIKE ?", likePrefix(filter.Ip))
+	}
+	if filter.OnlyLive {
+		db = db.Where("black_time > ?", time.Now())
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("blackIpRepo|GetPage:%v", err)
+	}
+	var blackIps []*biz.BlackIp
+	err := db.Order("sys_updated desc").Offset(page.Offset()).Limit(page.PageSize).Find(&blackIps).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("blackIpRepo|GetPage:%v", err)
+	}
+	return blackIps, total, nil
+}
+
 func (r *blackIpRepo) CountAll() (int64, error) {
 	db := r.data.db
 	var num int64
@@ -103,8 +125,8 @@ func (r *blackIpRepo) CountAll() (int64, error) {
 	return num, nil
 }
 
-func (r *blackIpRepo) Create(blackIp *biz.BlackIp) error {
-	db := r.data.db
+func (r *blackIpRepo) Create(ctx context.Context, blackIp *biz.BlackIp) error {
+	db := r.data.DB(ctx)
 	err := db.Model(blackIp).Create(blackIp).Error
 	if err != nil {
 		return fmt.Errorf("blackIpRepo|Create:%v", err)
@@ -116,13 +138,13 @@ func (r *blackIpRepo) Delete(id uint) error {
 	db := r.data.db
 	blackIp := &biz.BlackIp{Id: id}
 	if err := db.Model(blackIp).Delete(blackIp).Error; err != nil {
-		return fmt.Errorf("blackIpRepo|Delete:%v")
+		return fmt.Errorf("blackIpRepo|Delete:%v", err)
 	}
 	return nil
 }
 
-func (r *blackIpRepo) Update(ip string, blackIp *biz.BlackIp, cols ...string) error {
-	db := r.data.db
+func (r *blackIpRepo) Update(ctx context.Context, ip string, blackIp *biz.BlackIp, cols ...string) error {
+	db := r.data.DB(ctx)
 	if err := r.UpdateByCache(&biz.BlackIp{Ip: ip}); err != nil {
 		return fmt.Errorf("blackIpRepo|UpdateWithCache:%v", err)
 	}
@@ -232,9 +254,55 @@ func (r *blackIpRepo) UpdateByCache(blackIp *biz.BlackIp) error {
 	if blackIp == nil || blackIp.Ip == "" {
 		return fmt.Errorf("blackIpRepo|UpdateByCache invalid blackUser")
 	}
-	key := fmt.Sprintf(constant.UserCacheKeyPrefix+"%s", blackIp.Ip)
+	key := fmt.Sprintf(constant.IpCacheKeyPrefix+"%s", blackIp.Ip)
 	if err := redisCli.Delete(context.Background(), key); err != nil {
 		return fmt.Errorf("blackIpRepo|UpdateByCache:%v", err)
 	}
 	return nil
 }
+
+func (r *blackIpRepo) GetCidrList() ([]*biz.BlackIp, error) {
+	var list []*biz.BlackIp
+	err := r.data.db.Model(&biz.BlackIp{}).Where("ip LIKE ? AND black_time > ?", "%/%", time.Now()).
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("blackIpRepo|GetCidrList:%v", err)
+	}
+	return list, nil
+}
+
+// GetCidrListWithCache 网段黑名单整体缓存为json，数量不多，每个实例定时加载到内存中匹配
+func (r *blackIpRepo) GetCidrListWithCache() ([]*biz.BlackIp, error) {
+	redisCli := r.data.cache
+	ret, exist, err := redisCli.Get(context.Background(), constant.BlackIpCidrCacheKey)
+	if err != nil {
+		log.Errorf("blackIpRepo|GetCidrListWithCache:%v", err)
+	}
+	if err == nil && exist {
+		var list []*biz.BlackIp
+		if err = json.Unmarshal([]byte(ret), &list); err == nil {
+			return list, nil
+		}
+		log.Errorf("blackIpRepo|GetCidrListWithCache|json.Unmarshal:%v", err)
+	}
+	list, err := r.GetCidrList()
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("blackIpRepo|GetCidrListWithCache|json.Marshal:%v", err)
+	}
+	if err = redisCli.Set(context.Background(), constant.BlackIpCidrCacheKey, string(bytes),
+		constant.BlackIpCidrCacheTime); err != nil {
+		log.Errorf("blackIpRepo|GetCidrListWithCache|Set:%v", err)
+	}
+	return list, nil
+}
+
+func (r *blackIpRepo) DeleteCidrCache() error {
+	if err := r.data.cache.Delete(context.Background(), constant.BlackIpCidrCacheKey); err != nil {
+		return fmt.Errorf("blackIpRepo|DeleteCidrCache:%v", err)
+	}
+	return nil
+}
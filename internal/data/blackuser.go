@@ -10,6 +10,7 @@ import (
 	"github.com/BitofferHub/pkg/middlewares/log"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type blackUserRepo struct {
@@ -73,6 +74,30 @@ func (r *blackUserRepo) GetAll() ([]*biz.BlackUser, error) {
 	return BlackUsers, nil
 }
 
+// GetPage 按条件分页查询用户黑名单，返回当前页数据和总数
+func (r *blackUserRepo) GetPage(filter *biz.BlackUserFilter, page *biz.PageQuery) ([]*biz.BlackUser, int64, error) {
+	db := r.data.db.Model(&biz.BlackUser{})
+	if filter.UserId > 0 {
+		db = db.Where("user_id = ?", filter.UserId)
+	}
+	if filter.UserName != "" {
+		db = db.Where("user_name LIKE ?", likePrefix(filter.UserName))
+	}
+	if filter.OnlyLive {
+		db = db.Where("black_time > ?", time.Now())
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("blackUserRepo|GetPage:%v", err)
+	}
+	var blackUsers []*biz.BlackUser
+	err := db.Order("sys_updated desc").Offset(page.Offset()).Limit(page.PageSize).Find(&blackUsers).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("blackUserRepo|GetPage:%v", err)
+	}
+	return blackUsers, total, nil
+}
+
 func (r *blackUserRepo) CountAll() (int64, error) {
 	db := r.data.db
 	var num int64
@@ -83,8 +108,8 @@ func (r *blackUserRepo) CountAll() (int64, error) {
 	return num, nil
 }
 
-func (r *blackUserRepo) Create(blackUser *biz.BlackUser) error {
-	db := r.data.db
+func (r *blackUserRepo) Create(ctx context.Context, blackUser *biz.BlackUser) error {
+	db := r.data.DB(ctx)
 	err := db.Model(blackUser).Create(blackUser).Error
 	if err != nil {
 		return fmt.Errorf("blackUserRepo|Create:%v", err)
@@ -113,8 +138,8 @@ func (r *blackUserRepo) DeleteWithCache(uid uint) error {
 	return nil
 }
 
-func (r *blackUserRepo) Update(userID uint, blackUser *biz.BlackUser, cols ...string) error {
-	db := r.data.db
+func (r *blackUserRepo) Update(ctx context.Context, userID uint, blackUser *biz.BlackUser, cols ...string) error {
+	db := r.data.DB(ctx)
 	var err error
 	if len(cols) == 0 {
 		err = db.Model(blackUser).Where("user_id=?", userID).Updates(blackUser).Error
@@ -94,7 +94,7 @@ func (r *couponRepo) Delete(id uint) error {
 func (r *couponRepo) DeleteAllWithCache() error {
 	db := r.data.db
 	couponList := make([]biz.Coupon, 0)
-	if err := db.Model(&biz.Coupon{}).Select("prize_id").Distinct().Find(&couponList).Error; err != nil {
+	if err := db.Model(&biz.Coupon{}).Select("activity_id", "prize_id").Distinct().Find(&couponList).Error; err != nil {
 		log.Errorf("couponRepo|DeleteAllWithCache:%v", err)
 		return fmt.Errorf("couponRepo|DeleteAllWithCache:%v", err)
 	}
@@ -104,7 +104,7 @@ func (r *couponRepo) DeleteAllWithCache() error {
 	}
 	log.Infof("couponRepo|DeleteAllWithCache|couponList=%v", couponList)
 	for _, coupon := range couponList {
-		key := fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", coupon.PrizeId)
+		key := couponCacheKey(coupon.ActivityId, coupon.PrizeId)
 		if err := r.data.cache.Delete(context.Background(), key); err != nil {
 			log.Errorf("couponRepo|DeleteAllWithCache|redis delete:%v", err)
 			return fmt.Errorf("couponRepo|DeleteAllWithCache|redis delete:%v", err)
@@ -127,8 +127,8 @@ func (r *couponRepo) Update(coupon *biz.Coupon, cols ...string) error {
 	return nil
 }
 
-func (r *couponRepo) UpdateByCode(code string, coupon *biz.Coupon, cols ...string) error {
-	db := r.data.db
+func (r *couponRepo) UpdateByCode(ctx context.Context, code string, coupon *biz.Coupon, cols ...string) error {
+	db := r.data.DB(ctx)
 	var err error
 	if len(cols) == 0 {
 		err = db.Model(coupon).Where("code = ?", code).Updates(coupon).Error
@@ -177,9 +177,9 @@ func (r *couponRepo) GetGetNextUsefulCoupon(prizeID, couponID int) (*biz.Coupon,
 }
 
 // ImportCacheCoupon 往缓存导入优惠券
-func (r *couponRepo) ImportCacheCoupon(prizeID uint, code string) (bool, error) {
+func (r *couponRepo) ImportCacheCoupon(activityID, prizeID uint, code string) (bool, error) {
 	redisCli := r.data.cache
-	key := fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", prizeID)
+	key := couponCacheKey(activityID, prizeID)
 	cnt, err := redisCli.SAdd(context.Background(), key, code)
 	if err != nil {
 		return false, fmt.Errorf("couponRepo|ImportCacheCoupon:%v", err)
@@ -191,17 +191,14 @@ func (r *couponRepo) ImportCacheCoupon(prizeID uint, code string) (bool, error)
 }
 
 // ReSetCacheCoupon 根据库存优惠券重置优惠券缓存
-func (r *couponRepo) ReSetCacheCoupon(prizeID uint) (int64, int64, error) {
+func (r *couponRepo) ReSetCacheCoupon(activityID, prizeID uint) (int64, int64, error) {
 	redisCli := r.data.cache
 	var successNum, failureNum int64 = 0, 0
 	couponList, err := r.GetCouponListByPrizeID(prizeID)
 	if err != nil {
 		return 0, 0, fmt.Errorf("couponRepo")
 	}
-	if couponList == nil || len(couponList) == 0 {
-		return 0, 0, nil
-	}
-	key := fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", prizeID)
+	key := couponCacheKey(activityID, prizeID)
 	// 这里先用临时keu统计，在原key上统计的话，因为db里的数量可能变化，没有同步到缓存中，比如db里面减少了10条数据，如果在原key上增加，那么缓存就会多处10条数据，所以根据db全部统计完了之后，在覆盖
 	tmpKey := "tmp_" + key
 	for _, coupon := range couponList {
@@ -218,6 +215,13 @@ func (r *couponRepo) ReSetCacheCoupon(prizeID uint) (int64, int64, error) {
 			}
 		}
 	}
+	// 没有可用的优惠券时临时key不存在，直接清空缓存
+	if successNum == 0 {
+		if err = redisCli.Delete(context.Background(), key); err != nil {
+			return 0, 0, fmt.Errorf("couponRepo|ReSetCacheCoupon:%v", err)
+		}
+		return successNum, failureNum, nil
+	}
 	_, err = redisCli.Rename(context.Background(), tmpKey, key)
 	if err != nil {
 		return 0, 0, fmt.Errorf("couponRepo|ReSetCacheCoupon:%v", err)
@@ -226,7 +230,7 @@ func (r *couponRepo) ReSetCacheCoupon(prizeID uint) (int64, int64, error) {
 }
 
 // GetCacheCouponNum 获取缓存中的剩余优惠券数量以及数据库中的剩余优惠券数量
-func (r *couponRepo) GetCacheCouponNum(prizeID uint) (int64, int64, error) {
+func (r *couponRepo) GetCacheCouponNum(activityID, prizeID uint) (int64, int64, error) {
 	redisCli := r.data.cache
 	var dbNum, cacheNum int64 = 0, 0
 	couponList, err := r.GetCouponListByPrizeID(prizeID)
@@ -241,7 +245,7 @@ func (r *couponRepo) GetCacheCouponNum(prizeID uint) (int64, int64, error) {
 			dbNum++
 		}
 	}
-	key := fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", prizeID)
+	key := couponCacheKey(activityID, prizeID)
 	cacheNum, err = redisCli.SCard(context.Background(), key)
 	if err != nil {
 		return 0, 0, fmt.Errorf("couponRepo|GetCacheCouponNum:%v", err)
@@ -250,9 +254,9 @@ func (r *couponRepo) GetCacheCouponNum(prizeID uint) (int64, int64, error) {
 }
 
 // GetNextUsefulCouponFromCache 从缓存中拿出一个可用优惠券
-func (r *couponRepo) GetNextUsefulCouponFromCache(prizeID int) (string, error) {
+func (r *couponRepo) GetNextUsefulCouponFromCache(activityID uint, prizeID int) (string, error) {
 	redisCli := r.data.cache
-	key := fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", prizeID)
+	key := couponCacheKey(activityID, uint(prizeID))
 	code, err := redisCli.SPop(context.Background(), key)
 	if err != nil {
 		if err.Error() == "redis: nil" {
@@ -267,3 +271,8 @@ func (r *couponRepo) GetNextUsefulCouponFromCache(prizeID int) (string, error) {
 	}
 	return code, nil
 }
+
+// couponCacheKey 优惠券缓存key，按活动划分命名空间
+func couponCacheKey(activityID, prizeID uint) string {
+	return constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.PrizeCouponCacheKey+"%d", prizeID))
+}
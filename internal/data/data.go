@@ -8,12 +8,17 @@ import (
 	"github.com/BitofferHub/pkg/middlewares/gormcli"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"strings"
 )
 
 // ProviderSet is data providers.
 var ProviderSet = wire.NewSet(NewData, NewDatabase, NewCache, NewCouponRepo, NewPrizeRepo,
-	NewResultRepo, NewBlackIpRepo, NewBlackUserRepo, NewLotteryTimesRepo, NewTransaction)
+	NewResultRepo, NewBlackIpRepo, NewBlackUserRepo, NewLotteryTimesRepo, NewActivityRepo, NewUserRepo,
+	NewAdminAuditRepo, NewWinCapRepo, NewDrawRepo, NewDrawOutboxRepo, NewResultSink, NewPrizeDeliveryRepo,
+	NewPrizeDelivererSet, NewPrizePlanRepo, NewPrizePoolLedgerRepo, NewTaskRepo, NewRiskRepo,
+	NewTransaction)
 
 type Data struct {
 	db    *gorm.DB
@@ -22,7 +27,11 @@ type Data struct {
 
 type contextTxKey struct{}
 
+// InTx 在事务中执行fn，ctx中已经有事务时直接加入该事务
 func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(contextTxKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
 	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		ctx = context.WithValue(ctx, contextTxKey{}, tx)
 		return fn(ctx)
@@ -73,3 +82,28 @@ func NewCache(conf *conf.Data) *cache.Client {
 
 	return cache.GetRedisCli()
 }
+
+// likePrefix 构造前缀匹配的LIKE参数，转义通配符
+func likePrefix(s string) string {
+	s = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(s)
+	return s + "%"
+}
+
+// evalScript 通过EVALSHA执行lua脚本，redis中还没有缓存脚本时用EVAL执行，同时会缓存脚本
+func (d *Data) evalScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	var cmd *redis.Cmd
+	err := d.cache.Pipeline(ctx, func(pipe redis.Pipeliner) error {
+		cmd = script.EvalSha(ctx, pipe, keys, args...)
+		return nil
+	})
+	if err != nil && redis.HasErrorPrefix(err, "NOSCRIPT") {
+		err = d.cache.Pipeline(ctx, func(pipe redis.Pipeliner) error {
+			cmd = script.Eval(ctx, pipe, keys, args...)
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cmd.Result()
+}
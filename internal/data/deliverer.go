@@ -0,0 +1,139 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	v1 "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/conf"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	transgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/grpc"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// NewPrizeDelivererSet 按配置创建各奖品类型的发放方式，服务退出时关闭grpc连接
+func NewPrizeDelivererSet(conf *conf.Data) (biz.PrizeDelivererSet, func(), error) {
+	set := make(biz.PrizeDelivererSet)
+	var conns []*grpc.ClientConn
+	cleanup := func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	for _, c := range conf.GetDeliverers() {
+		prizeType := uint(c.GetPrizeType())
+		// 实物奖品走履约流程，不能配置发放方式
+		if prizeType >= constant.PrizeTypeEntitySmall {
+			cleanup()
+			return nil, nil, fmt.Errorf("NewPrizeDelivererSet|prize type %d is not virtual", prizeType)
+		}
+		if _, ok := set[prizeType]; ok {
+			cleanup()
+			return nil, nil, fmt.Errorf("NewPrizeDelivererSet|duplicate prize type %d", prizeType)
+		}
+		timeout := constant.DeliverTimeout
+		if c.GetTimeout().AsDuration() > 0 {
+			timeout = c.GetTimeout().AsDuration()
+		}
+		switch c.GetType() {
+		case constant.DelivererWebhook:
+			set[prizeType] = &webhookDeliverer{
+				url:    c.GetEndpoint(),
+				client: &http.Client{Timeout: timeout},
+			}
+		case constant.DelivererGrpc:
+			conn, err := transgrpc.DialInsecure(context.Background(), transgrpc.WithEndpoint(c.GetEndpoint()),
+				transgrpc.WithTimeout(timeout))
+			if err != nil {
+				cleanup()
+				return nil, nil, fmt.Errorf("NewPrizeDelivererSet|dial %s:%v", c.GetEndpoint(), err)
+			}
+			conns = append(conns, conn)
+			set[prizeType] = &grpcDeliverer{client: v1.NewPrizeCallbackClient(conn)}
+		default:
+			cleanup()
+			return nil, nil, fmt.Errorf("NewPrizeDelivererSet|unknown type %s", c.GetType())
+		}
+	}
+	return set, cleanup, nil
+}
+
+// webhookDeliverer POST json到业务方接口，幂等键放在 Idempotency-Key 头中，返回2xx表示发放成功
+type webhookDeliverer struct {
+	url    string
+	client *http.Client
+}
+
+type webhookBody struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	ResultId       uint   `json:"result_id"`
+	ActivityId     uint   `json:"activity_id"`
+	PrizeId        uint   `json:"prize_id"`
+	PrizeType      uint   `json:"prize_type"`
+	UserId         uint   `json:"user_id"`
+	UserName       string `json:"user_name"`
+	PrizeProfile   string `json:"prize_profile"`
+}
+
+func (w *webhookDeliverer) Deliver(ctx context.Context, delivery *biz.PrizeDelivery) error {
+	body, err := json.Marshal(&webhookBody{
+		IdempotencyKey: delivery.IdempotencyKey,
+		ResultId:       delivery.ResultId,
+		ActivityId:     delivery.ActivityId,
+		PrizeId:        delivery.PrizeId,
+		PrizeType:      delivery.PrizeType,
+		UserId:         delivery.UserId,
+		UserName:       delivery.UserName,
+		PrizeProfile:   delivery.PrizeProfile,
+	})
+	if err != nil {
+		return fmt.Errorf("webhookDeliverer|Deliver:%v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhookDeliverer|Deliver:%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", delivery.IdempotencyKey)
+	rsp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhookDeliverer|Deliver:%v", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(rsp.Body, 128))
+		// 按字节截取可能截断多字节字符
+		return fmt.Errorf("webhookDeliverer|Deliver status=%d body=%s", rsp.StatusCode, strings.ToValidUTF8(string(msg), ""))
+	}
+	return nil
+}
+
+// grpcDeliverer 调用业务方实现的 PrizeCallback 服务，返回code为0表示发放成功
+type grpcDeliverer struct {
+	client v1.PrizeCallbackClient
+}
+
+func (g *grpcDeliverer) Deliver(ctx context.Context, delivery *biz.PrizeDelivery) error {
+	rsp, err := g.client.DeliverPrize(ctx, &v1.DeliverPrizeReq{
+		IdempotencyKey: delivery.IdempotencyKey,
+		ResultId:       uint32(delivery.ResultId),
+		ActivityId:     uint32(delivery.ActivityId),
+		PrizeId:        uint32(delivery.PrizeId),
+		PrizeType:      uint32(delivery.PrizeType),
+		UserId:         uint32(delivery.UserId),
+		UserName:       delivery.UserName,
+		PrizeProfile:   delivery.PrizeProfile,
+	})
+	if err != nil {
+		return fmt.Errorf("grpcDeliverer|Deliver:%v", err)
+	}
+	if rsp.GetCode() != 0 {
+		return fmt.Errorf("grpcDeliverer|Deliver code=%d msg=%s", rsp.GetCode(), rsp.GetMsg())
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"gorm.io/gorm"
+	"time"
+)
+
+type prizeDeliveryRepo struct {
+	data *Data
+}
+
+func NewPrizeDeliveryRepo(data *Data) biz.PrizeDeliveryRepo {
+	return &prizeDeliveryRepo{
+		data: data,
+	}
+}
+
+func (r *prizeDeliveryRepo) Create(ctx context.Context, delivery *biz.PrizeDelivery) error {
+	db := r.data.DB(ctx)
+	if err := db.Model(&biz.PrizeDelivery{}).Create(delivery).Error; err != nil {
+		return fmt.Errorf("prizeDeliveryRepo|Create:%v", err)
+	}
+	return nil
+}
+
+// UpdateAttempt 条件更新发放结果，零值字段也会更新
+func (r *prizeDeliveryRepo) UpdateAttempt(ctx context.Context, delivery *biz.PrizeDelivery, from, retryNum uint) (bool, error) {
+	db := r.data.DB(ctx)
+	res := db.Model(&biz.PrizeDelivery{}).Where("id = ? and status = ? and retry_num = ?", delivery.Id, from, retryNum).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"retry_num":       delivery.RetryNum,
+			"next_retry_time": delivery.NextRetryTime,
+			"last_error":      delivery.LastError,
+			"sys_updated":     time.Now(),
+		})
+	if res.Error != nil {
+		return false, fmt.Errorf("prizeDeliveryRepo|UpdateAttempt:%v", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *prizeDeliveryRepo) GetRetryList(before time.Time, limit int) ([]*biz.PrizeDelivery, error) {
+	db := r.data.db
+	var list []*biz.PrizeDelivery
+	err := db.Model(&biz.PrizeDelivery{}).Where("status = ? and next_retry_time <= ?", constant.DeliveryStatusPending, before).
+		Order("next_retry_time").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("prizeDeliveryRepo|GetRetryList:%v", err)
+	}
+	return list, nil
+}
+
+func (r *prizeDeliveryRepo) Get(id uint) (*biz.PrizeDelivery, error) {
+	db := r.data.db
+	delivery := &biz.PrizeDelivery{}
+	err := db.Model(&biz.PrizeDelivery{}).Where("id = ?", id).First(delivery).Error
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("prizeDeliveryRepo|Get:%v", err)
+	}
+	return delivery, nil
+}
+
+func (r *prizeDeliveryRepo) CreateDead(ctx context.Context, dead *biz.PrizeDeliveryDead) error {
+	db := r.data.DB(ctx)
+	if err := db.Model(&biz.PrizeDeliveryDead{}).Create(dead).Error; err != nil {
+		return fmt.Errorf("prizeDeliveryRepo|CreateDead:%v", err)
+	}
+	return nil
+}
+
+func (r *prizeDeliveryRepo) GetDead(id uint) (*biz.PrizeDeliveryDead, error) {
+	db := r.data.db
+	dead := &biz.PrizeDeliveryDead{}
+	err := db.Model(&biz.PrizeDeliveryDead{}).Where("id = ?", id).First(dead).Error
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("prizeDeliveryRepo|GetDead:%v", err)
+	}
+	return dead, nil
+}
+
+// GetDeadPage 按状态分页查询死信，status为空时查询全部
+func (r *prizeDeliveryRepo) GetDeadPage(status *uint, page *biz.PageQuery) ([]*biz.PrizeDeliveryDead, int64, error) {
+	db := r.data.db.Model(&biz.PrizeDeliveryDead{})
+	if status != nil {
+		db = db.Where("status = ?", *status)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("prizeDeliveryRepo|GetDeadPage:%v", err)
+	}
+	var list []*biz.PrizeDeliveryDead
+	err := db.Order("id desc").Offset(page.Offset()).Limit(page.PageSize).Find(&list).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("prizeDeliveryRepo|GetDeadPage:%v", err)
+	}
+	return list, total, nil
+}
+
+// UpdateDeadStatus 条件更新死信状态，并发修改时只有一个能成功
+func (r *prizeDeliveryRepo) UpdateDeadStatus(ctx context.Context, id uint, from, to uint) (bool, error) {
+	db := r.data.DB(ctx)
+	res := db.Model(&biz.PrizeDeliveryDead{}).Where("id = ? and status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "sys_updated": time.Now()})
+	if res.Error != nil {
+		return false, fmt.Errorf("prizeDeliveryRepo|UpdateDeadStatus:%v", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
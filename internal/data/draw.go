@@ -0,0 +1,156 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// drawScript 原子抽奖，返回 {结果, 用户今日次数, IP今日次数, 券码}
+// KEYS[1] 用户今日抽奖次数，KEYS[2] IP今日抽奖次数，KEYS[3] 奖品池，KEYS[4] 优惠券券码，KEYS[5] 待提交标记，
+// KEYS[6] 网段今日抽奖次数
+// ARGV[1] 用户ID，ARGV[2] 用户每日上限，ARGV[3] IP，ARGV[4] IP每日上限，ARGV[5] 奖品ID，
+// ARGV[6] 是否扣减奖品池，ARGV[7] 是否弹出券码，ARGV[8] 事务消息ID，ARGV[9] 每日次数的过期时间(秒)，
+// ARGV[10] 是否白名单IP，白名单IP不校验也不计数，ARGV[11] 网段，为空时不限制网段，ARGV[12] 网段每日上限
+var drawScript = redis.NewScript(`
+local userNum = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+if userNum >= tonumber(ARGV[2]) then
+	return {1, userNum, 0, ''}
+end
+local ipNum = 0
+if ARGV[10] ~= '1' then
+	ipNum = tonumber(redis.call('HGET', KEYS[2], ARGV[3]) or '0')
+	if ipNum >= tonumber(ARGV[4]) then
+		return {2, userNum, ipNum, ''}
+	end
+	if ARGV[11] ~= '' and tonumber(redis.call('HGET', KEYS[6], ARGV[11]) or '0') >= tonumber(ARGV[12]) then
+		return {2, userNum, ipNum, ''}
+	end
+end
+userNum = redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+redis.call('EXPIRE', KEYS[1], ARGV[9])
+if ARGV[10] ~= '1' then
+	ipNum = redis.call('HINCRBY', KEYS[2], ARGV[3], 1)
+	redis.call('EXPIRE', KEYS[2], ARGV[9])
+	if ARGV[11] ~= '' then
+		redis.call('HINCRBY', KEYS[6], ARGV[11], 1)
+		redis.call('EXPIRE', KEYS[6], ARGV[9])
+	end
+end
+if ARGV[6] == '1' and tonumber(redis.call('HGET', KEYS[3], ARGV[5]) or '0') <= 0 then
+	return {3, userNum, ipNum, ''}
+end
+local code = ''
+if ARGV[7] == '1' then
+	code = redis.call('SPOP', KEYS[4])
+	if not code then
+		return {4, userNum, ipNum, ''}
+	end
+end
+if ARGV[6] == '1' then
+	redis.call('HINCRBY', KEYS[3], ARGV[5], -1)
+end
+if ARGV[8] ~= '0' then
+	redis.call('HSET', KEYS[5], ARGV[8], code)
+end
+return {0, userNum, ipNum, code}
+`)
+
+// compensateDrawScript 按待提交标记归还奖品池数量和券码，标记不存在说明没有扣减或者已经归还
+// KEYS[1] 奖品池，KEYS[2] 优惠券券码，KEYS[3] 待提交标记，ARGV[1] 是否归还奖品池，ARGV[2] 奖品ID，ARGV[3] 事务消息ID
+var compensateDrawScript = redis.NewScript(`
+local code = redis.call('HGET', KEYS[3], ARGV[3])
+if not code then
+	return 0
+end
+redis.call('HDEL', KEYS[3], ARGV[3])
+if ARGV[1] == '1' then
+	redis.call('HINCRBY', KEYS[1], ARGV[2], 1)
+end
+if code ~= '' then
+	redis.call('SADD', KEYS[2], code)
+end
+return 1
+`)
+
+type drawRepo struct {
+	data        *Data
+	lotteryConf *biz.LotteryConfig
+}
+
+func NewDrawRepo(data *Data, lc *biz.LotteryConfig) biz.DrawRepo {
+	return &drawRepo{
+		data:        data,
+		lotteryConf: lc,
+	}
+}
+
+func boolArg(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// Draw 次数校验、奖品池扣减和券码弹出在一个lua脚本中原子完成
+func (r *drawRepo) Draw(req *biz.DrawReq) (*biz.DrawResult, error) {
+	limits := r.lotteryConf.Limits()
+	clientIp := limits.ParseClientIp(req.Ip)
+	now := time.Now()
+	keys := []string{
+		userDayNumKey(limits, req.ActivityId, req.UserId, now),
+		ipDayNumKey(limits, req.ActivityId, clientIp.Ip, now),
+		constant.ActivityCacheKey(req.ActivityId, constant.PrizePoolCacheKey),
+		couponCacheKey(req.ActivityId, req.PrizeId),
+		constant.ActivityCacheKey(req.ActivityId, constant.DrawPendingCacheKey),
+		ipPrefixDayNumKey(limits, req.ActivityId, clientIp.Prefix, now),
+	}
+	ret, err := r.data.evalScript(context.Background(), drawScript, keys, fmt.Sprint(req.UserId), req.UserDayMax,
+		clientIp.Ip, req.IpDayMax, fmt.Sprint(req.PrizeId), boolArg(req.UsePool), boolArg(req.PopCoupon),
+		fmt.Sprint(req.OutboxId), int64(limits.DayNumTTL(now)/time.Second),
+		boolArg(clientIp.Exempt), clientIp.Prefix, limits.IpPrefixLimitMax)
+	if err != nil {
+		return nil, fmt.Errorf("drawRepo|Draw:%v", err)
+	}
+	values, ok := ret.([]interface{})
+	if !ok || len(values) != 4 {
+		return nil, fmt.Errorf("drawRepo|Draw unexpected result %v", ret)
+	}
+	status, _ := values[0].(int64)
+	userNum, _ := values[1].(int64)
+	ipNum, _ := values[2].(int64)
+	code, _ := values[3].(string)
+	return &biz.DrawResult{
+		Status:     int(status),
+		UserNum:    userNum,
+		IpNum:      ipNum,
+		CouponCode: code,
+	}, nil
+}
+
+// Compensate 按待提交标记归还奖品池数量和券码
+func (r *drawRepo) Compensate(req *biz.DrawReq) error {
+	keys := []string{
+		constant.ActivityCacheKey(req.ActivityId, constant.PrizePoolCacheKey),
+		couponCacheKey(req.ActivityId, req.PrizeId),
+		constant.ActivityCacheKey(req.ActivityId, constant.DrawPendingCacheKey),
+	}
+	_, err := r.data.evalScript(context.Background(), compensateDrawScript, keys,
+		boolArg(req.UsePool), fmt.Sprint(req.PrizeId), fmt.Sprint(req.OutboxId))
+	if err != nil {
+		return fmt.Errorf("drawRepo|Compensate:%v", err)
+	}
+	return nil
+}
+
+// Finish 清理待提交标记
+func (r *drawRepo) Finish(req *biz.DrawReq) error {
+	key := constant.ActivityCacheKey(req.ActivityId, constant.DrawPendingCacheKey)
+	if _, err := r.data.cache.HDel(context.Background(), key, fmt.Sprint(req.OutboxId)); err != nil {
+		return fmt.Errorf("drawRepo|Finish:%v", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package data
+
+import (
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/conf"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"time"
+)
+
+// NewLotteryLimits 配置文件中的抽奖限制，没有配置的使用默认值
+func NewLotteryLimits(c *conf.Lottery) (*biz.LotteryLimits, error) {
+	limits := biz.DefaultLotteryLimits()
+	if c.GetUserPrizeMax() > 0 {
+		limits.UserPrizeMax = uint(c.GetUserPrizeMax())
+	}
+	if c.GetIpLimitMax() > 0 {
+		limits.IpLimitMax = uint(c.GetIpLimitMax())
+	}
+	if c.GetIpFrameSize() > 0 {
+		limits.IpFrameSize = uint(c.GetIpFrameSize())
+	}
+	if c.GetUserFrameSize() > 0 {
+		limits.UserFrameSize = uint(c.GetUserFrameSize())
+	}
+	if c.GetDefaultBlackTime().AsDuration() > 0 {
+		limits.DefaultBlackTime = c.GetDefaultBlackTime().AsDuration()
+	}
+	if c.GetPrizeCodeMax() > 0 {
+		limits.PrizeCodeMax = uint(c.GetPrizeCodeMax())
+	}
+	if c.GetTimezone() != "" {
+		loc, err := time.LoadLocation(c.GetTimezone())
+		if err != nil {
+			return nil, fmt.Errorf("NewLotteryLimits|timezone %q: %v", c.GetTimezone(), err)
+		}
+		limits.Location = loc
+	}
+	if c.GetIpv4PrefixBits() > 0 {
+		if c.GetIpv4PrefixBits() > 32 {
+			return nil, fmt.Errorf("NewLotteryLimits|ipv4_prefix_bits must be at most 32")
+		}
+		limits.IpV4PrefixBits = int(c.GetIpv4PrefixBits())
+	}
+	if c.GetIpv6PrefixBits() > 0 {
+		if c.GetIpv6PrefixBits() > 128 {
+			return nil, fmt.Errorf("NewLotteryLimits|ipv6_prefix_bits must be at most 128")
+		}
+		limits.IpV6PrefixBits = int(c.GetIpv6PrefixBits())
+	}
+	limits.IpPrefixLimitMax = uint(c.GetIpPrefixLimitMax())
+	for _, s := range c.GetIpAllowlist() {
+		prefix, err := utils.ParseIpPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("NewLotteryLimits|ip_allowlist %q: %v", s, err)
+		}
+		limits.IpAllowlist = append(limits.IpAllowlist, prefix)
+	}
+	if len(c.GetHourWeights()) > 0 {
+		weights, err := biz.ParseHourWeights(c.GetHourWeights())
+		if err != nil {
+			return nil, fmt.Errorf("NewLotteryLimits|%v", err)
+		}
+		limits.DayPrizeWeights = weights
+	}
+	if len(c.GetPlanProfiles()) > 0 {
+		limits.PlanProfiles = make(map[string]*biz.PlanProfile)
+		for name, p := range c.GetPlanProfiles() {
+			profile, err := newPlanProfile(limits.DayPrizeWeights, p)
+			if err != nil {
+				return nil, fmt.Errorf("NewLotteryLimits|plan_profiles %s: %v", name, err)
+			}
+			limits.PlanProfiles[name] = profile
+		}
+	}
+	if c.GetDefaultActivity() != nil {
+		activity, err := newDefaultActivity(c.GetDefaultActivity())
+		if err != nil {
+			return nil, fmt.Errorf("NewLotteryLimits|default_activity: %v", err)
+		}
+		limits.DefaultActivity = activity
+	}
+	if c.GetRisk() != nil {
+		rules, err := newRiskRules(c.GetRisk())
+		if err != nil {
+			return nil, fmt.Errorf("NewLotteryLimits|risk: %v", err)
+		}
+		limits.Risk = rules
+	}
+	return limits, nil
+}
+
+// newDefaultActivity 配置文件中的默认活动设置，校验规则同后台修改活动
+func newDefaultActivity(c *conf.DefaultActivity) (*biz.Activity, error) {
+	switch c.GetSelectStrategy() {
+	case constant.PrizeSelectorRange, constant.PrizeSelectorWeight:
+	default:
+		return nil, fmt.Errorf("select_strategy %d must be %d or %d", c.GetSelectStrategy(),
+			constant.PrizeSelectorRange, constant.PrizeSelectorWeight)
+	}
+	if c.GetPityPrizeType() > constant.PrizeTypeEntityLarge {
+		return nil, fmt.Errorf("pity_prize_type %d must not exceed %d", c.GetPityPrizeType(), constant.PrizeTypeEntityLarge)
+	}
+	if _, err := biz.ParseTypeDayWinMax(c.GetTypeDayWinMax()); err != nil {
+		return nil, err
+	}
+	return &biz.Activity{
+		SelectStrategy: uint(c.GetSelectStrategy()),
+		NoPrizeWeight:  uint(c.GetNoPrizeWeight()),
+		PityThreshold:  uint(c.GetPityThreshold()),
+		PityPrizeType:  uint(c.GetPityPrizeType()),
+		UserWinMax:     uint(c.GetUserWinMax()),
+		TypeDayWinMax:  c.GetTypeDayWinMax(),
+	}, nil
+}
+
+// newRiskRules 配置文件中的风控规则，动作只能是challenge或deny
+func newRiskRules(c *conf.Risk) (*biz.RiskRules, error) {
+	rules := &biz.RiskRules{
+		Enabled:   c.GetEnabled(),
+		Downgrade: c.GetDowngrade(),
+	}
+	names := make(map[string]bool)
+	for _, v := range c.GetVelocity() {
+		if v.GetName() == "" || names[v.GetName()] {
+			return nil, fmt.Errorf("velocity name %q must be unique and not empty", v.GetName())
+		}
+		names[v.GetName()] = true
+		switch v.GetDimension() {
+		case constant.RiskDimensionUser, constant.RiskDimensionIp, constant.RiskDimensionDevice:
+		default:
+			return nil, fmt.Errorf("velocity %s dimension %q must be user, ip or device", v.GetName(), v.GetDimension())
+		}
+		if v.GetWindow().AsDuration() < constant.RiskWindowMin || v.GetMax() == 0 {
+			return nil, fmt.Errorf("velocity %s needs a window of at least %s and max > 0", v.GetName(), constant.RiskWindowMin)
+		}
+		action, err := biz.ParseRiskAction(v.GetAction())
+		if err != nil {
+			return nil, fmt.Errorf("velocity %s %v", v.GetName(), err)
+		}
+		rules.Velocity = append(rules.Velocity, &biz.VelocityRule{
+			Name:      v.GetName(),
+			Dimension: v.GetDimension(),
+			Window:    v.GetWindow().AsDuration(),
+			Max:       uint(v.GetMax()),
+			Action:    action,
+		})
+	}
+	if sharedIp := c.GetSharedIp(); sharedIp.GetMaxUsers() > 0 {
+		if sharedIp.GetWindow().AsDuration() < constant.RiskWindowMin {
+			return nil, fmt.Errorf("shared_ip window must be at least %s", constant.RiskWindowMin)
+		}
+		action, err := biz.ParseRiskAction(sharedIp.GetAction())
+		if err != nil {
+			return nil, fmt.Errorf("shared_ip %v", err)
+		}
+		rules.SharedIpWindow = sharedIp.GetWindow().AsDuration()
+		rules.SharedIpMaxUsers = uint(sharedIp.GetMaxUsers())
+		rules.SharedIpAction = action
+	}
+	if newAccount := c.GetNewAccount(); newAccount.GetMinAge().AsDuration() > 0 {
+		action, err := biz.ParseRiskAction(newAccount.GetAction())
+		if err != nil {
+			return nil, fmt.Errorf("new_account %v", err)
+		}
+		rules.NewAccountMinAge = newAccount.GetMinAge().AsDuration()
+		rules.NewAccountAction = action
+	}
+	if c.GetIpMismatchAction() != "" {
+		action, err := biz.ParseRiskAction(c.GetIpMismatchAction())
+		if err != nil {
+			return nil, fmt.Errorf("ip_mismatch_action %v", err)
+		}
+		rules.IpMismatchAction = action
+	}
+	if c.GetDowngradeRate() < 0 || c.GetDowngradeRate() > 1 {
+		return nil, fmt.Errorf("downgrade_rate %v must be between 0 and 1", c.GetDowngradeRate())
+	}
+	rules.DowngradeRate = c.GetDowngradeRate()
+	return rules, nil
+}
+
+// newPlanProfile 配置文件中的分布曲线，没有配置小时权重的使用全局的hour_weights
+func newPlanProfile(dayWeights [100]int, c *conf.PlanProfile) (*biz.PlanProfile, error) {
+	profile := biz.NewPlanProfile(dayWeights)
+	if len(c.GetHourWeights()) > 0 {
+		if len(c.GetHourWeights()) != 24 {
+			return nil, fmt.Errorf("hour_weights must have 24 items, got %d", len(c.GetHourWeights()))
+		}
+		for h, w := range c.GetHourWeights() {
+			profile.HourWeights[h] = int(w)
+		}
+	}
+	if len(c.GetWeekdayMultipliers()) > 0 {
+		if len(c.GetWeekdayMultipliers()) != 7 {
+			return nil, fmt.Errorf("weekday_multipliers must have 7 items, got %d", len(c.GetWeekdayMultipliers()))
+		}
+		for d, m := range c.GetWeekdayMultipliers() {
+			profile.WeekdayMultipliers[d] = m
+		}
+	}
+	for _, b := range c.GetBursts() {
+		burst, err := biz.ParseBurstWindow(b.GetStart(), b.GetDuration().AsDuration(), b.GetPercent())
+		if err != nil {
+			return nil, err
+		}
+		profile.Bursts = append(profile.Bursts, burst)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
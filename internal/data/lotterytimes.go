@@ -5,19 +5,24 @@ import (
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
-	"github.com/BitofferHub/pkg/middlewares/cache"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 	"math"
+	"strconv"
+	"time"
 )
 
 type lotteryTimesRepo struct {
-	data *Data
+	data        *Data
+	lotteryConf *biz.LotteryConfig
 }
 
-func NewLotteryTimesRepo(data *Data) biz.LotteryTimesRepo {
+func NewLotteryTimesRepo(data *Data, lc *biz.LotteryConfig) biz.LotteryTimesRepo {
 	return &lotteryTimesRepo{
-		data: data,
+		data:        data,
+		lotteryConf: lc,
 	}
 }
 
@@ -36,10 +41,10 @@ func (r *lotteryTimesRepo) Get(id uint) (*biz.LotteryTimes, error) {
 	return lotteryTimes, nil
 }
 
-func (r *lotteryTimesRepo) GetByUserIDAndDay(uid uint, day uint) (*biz.LotteryTimes, error) {
+func (r *lotteryTimesRepo) GetByUserIDAndDay(activityID, uid uint, day uint) (*biz.LotteryTimes, error) {
 	db := r.data.db
 	lotteryTimes := &biz.LotteryTimes{}
-	err := db.Model(&biz.LotteryTimes{}).Where("user_id=? and day=?", uid, day).First(lotteryTimes).Error
+	err := db.Model(&biz.LotteryTimes{}).Where("activity_id=? and user_id=? and day=?", activityID, uid, day).First(lotteryTimes).Error
 	if err != nil {
 		if err.Error() == gorm.ErrRecordNotFound.Error() {
 			return nil, nil
@@ -110,13 +115,44 @@ func (r *lotteryTimesRepo) Update(lotteryTimes *biz.LotteryTimes, cols ...string
 	return nil
 }
 
+// userDayNumKey 用户今日抽奖次数的缓存key，按业务日期和用户ID分片
+func userDayNumKey(limits *biz.LotteryLimits, activityID, uid uint, now time.Time) string {
+	return constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserLotteryDayNumPrefix+"%d_%d",
+		limits.Today(now), uid%limits.UserFrameSize))
+}
+
+// ipDayNumKey IP今日抽奖次数的缓存key，按业务日期和IP分片
+func ipDayNumKey(limits *biz.LotteryLimits, activityID uint, ip string, now time.Time) string {
+	return constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.IpLotteryDayNumPrefix+"%d_%d",
+		limits.Today(now), utils.IpHash(ip)%uint64(limits.IpFrameSize)))
+}
+
+// ipPrefixDayNumKey 网段今日抽奖次数的缓存key，和IP共用分片数量
+func ipPrefixDayNumKey(limits *biz.LotteryLimits, activityID uint, prefix string, now time.Time) string {
+	return constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.IpPrefixLotteryDayNumPrefix+"%d_%d",
+		limits.Today(now), utils.IpHash(prefix)%uint64(limits.IpFrameSize)))
+}
+
+// incrDayNum 每日次数递增并设置过期时间，返回递增后的数值
+func (r *lotteryTimesRepo) incrDayNum(key, field string, ttl time.Duration) (int64, error) {
+	var cmd *redis.IntCmd
+	err := r.data.cache.Pipeline(context.Background(), func(pipe redis.Pipeliner) error {
+		cmd = pipe.HIncrBy(context.Background(), key, field, 1)
+		pipe.Expire(context.Background(), key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cmd.Val(), nil
+}
+
 // IncrUserDayLotteryNum 每天缓存的用户抽奖次数递增，返回递增后的数值
-func (r *lotteryTimesRepo) IncrUserDayLotteryNum(uid uint) int64 {
-	redisCli := r.data.cache
-	i := uid % constant.UserFrameSize
+func (r *lotteryTimesRepo) IncrUserDayLotteryNum(activityID, uid uint) int64 {
+	limits := r.lotteryConf.Limits()
+	now := time.Now()
 	// 集群的redis统计数递增
-	key := fmt.Sprintf(constant.UserLotteryDayNumPrefix+"%d", i)
-	ret, err := redisCli.HIncrBy(context.Background(), key, fmt.Sprint(uid), 1)
+	ret, err := r.incrDayNum(userDayNumKey(limits, activityID, uid, now), fmt.Sprint(uid), limits.DayNumTTL(now))
 	if err != nil {
 		log.Errorf("lotteryTimesRepo|IncrUserDayLotteryNum:%v", err)
 		return math.MaxInt32
@@ -124,15 +160,59 @@ func (r *lotteryTimesRepo) IncrUserDayLotteryNum(uid uint) int64 {
 	return ret
 }
 
-// InitUserLuckyNum 从给定的数据直接初始化用户的参与抽奖次数
-func (r *lotteryTimesRepo) InitUserLuckyNum(uid uint, num int64) error {
+// IncrIPDayLotteryNum 每天缓存的IP抽奖次数递增，返回递增后的数值
+func (r *lotteryTimesRepo) IncrIPDayLotteryNum(activityID uint, ip string) (int64, error) {
+	limits := r.lotteryConf.Limits()
+	now := time.Now()
+	ret, err := r.incrDayNum(ipDayNumKey(limits, activityID, ip, now), ip, limits.DayNumTTL(now))
+	if err != nil {
+		return 0, fmt.Errorf("lotteryTimesRepo|IncrIPDayLotteryNum:%v", err)
+	}
+	return ret, nil
+}
+
+// IncrIPPrefixDayLotteryNum 每天缓存的网段抽奖次数递增，返回递增后的数值
+func (r *lotteryTimesRepo) IncrIPPrefixDayLotteryNum(activityID uint, prefix string) (int64, error) {
+	limits := r.lotteryConf.Limits()
+	now := time.Now()
+	ret, err := r.incrDayNum(ipPrefixDayNumKey(limits, activityID, prefix, now), prefix, limits.DayNumTTL(now))
+	if err != nil {
+		return 0, fmt.Errorf("lotteryTimesRepo|IncrIPPrefixDayLotteryNum:%v", err)
+	}
+	return ret, nil
+}
+
+// GetUserDayLotteryNum 获取缓存的用户今天抽奖次数，缓存中没有记录时返回false
+func (r *lotteryTimesRepo) GetUserDayLotteryNum(activityID, uid uint) (int64, bool, error) {
 	redisCli := r.data.cache
+	key := userDayNumKey(r.lotteryConf.Limits(), activityID, uid, time.Now())
+	ret, err := redisCli.HGet(context.Background(), key, fmt.Sprint(uid))
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("lotteryTimesRepo|GetUserDayLotteryNum:%v", err)
+	}
+	num, err := strconv.ParseInt(ret, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("lotteryTimesRepo|GetUserDayLotteryNum:%v", err)
+	}
+	return num, true, nil
+}
+
+// InitUserLuckyNum 从给定的数据直接初始化用户的参与抽奖次数
+func (r *lotteryTimesRepo) InitUserLuckyNum(activityID, uid uint, num int64) error {
 	if num <= 1 {
 		return nil
 	}
-	i := uid % constant.UserFrameSize
-	key := fmt.Sprintf(constant.UserLotteryDayNumPrefix+"%d", i)
-	_, err := redisCli.HSet(context.Background(), key, fmt.Sprint(uid), num)
+	limits := r.lotteryConf.Limits()
+	now := time.Now()
+	key := userDayNumKey(limits, activityID, uid, now)
+	err := r.data.cache.Pipeline(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HSet(context.Background(), key, fmt.Sprint(uid), num)
+		pipe.Expire(context.Background(), key, limits.DayNumTTL(now))
+		return nil
+	})
 	if err != nil {
 		log.Errorf("lotteryTimesRepo|InitUserLuckyNum:%v", err)
 		return fmt.Errorf("lotteryTimesRepo|InitUserLuckyNum:%v", err)
@@ -140,23 +220,38 @@ func (r *lotteryTimesRepo) InitUserLuckyNum(uid uint, num int64) error {
 	return nil
 }
 
-func (r *lotteryTimesRepo) ResetIPLotteryNums() {
-	//log.Infof("重置所有的IP抽奖次数")
-	for i := 0; i < constant.IpFrameSize; i++ {
-		key := fmt.Sprintf("day_ip_num_%d", i)
-		if err := cache.GetRedisCli().Delete(context.Background(), key); err != nil {
-			log.Errorf("ResetIPLotteryNums err:%v", err)
-		}
+// GetUserMissNum 获取用户在活动中连续未中奖的次数
+func (r *lotteryTimesRepo) GetUserMissNum(activityID, uid uint) (int64, error) {
+	redisCli := r.data.cache
+	i := uid % r.lotteryConf.Limits().UserFrameSize
+	key := constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserLotteryMissNumPrefix+"%d", i))
+	// 递增0读取计数，用户没有记录时返回0
+	ret, err := redisCli.HIncrBy(context.Background(), key, fmt.Sprint(uid), 0)
+	if err != nil {
+		return 0, fmt.Errorf("lotteryTimesRepo|GetUserMissNum:%v", err)
 	}
-	//log.Infof("重置所有的IP抽奖次数完成！！！")
+	return ret, nil
 }
 
-func (r *lotteryTimesRepo) ResetUserLotteryNums() {
-	//log.Infof("重置今日用户抽奖次数")
-	for i := 0; i < constant.UserFrameSize; i++ {
-		key := fmt.Sprintf(constant.UserLotteryDayNumPrefix+"%d", i)
-		if err := cache.GetRedisCli().Delete(context.Background(), key); err != nil {
-			log.Errorf("ResetIPLotteryNums err:%v", err)
-		}
+// IncrUserMissNum 用户连续未中奖次数递增，返回递增后的数值
+func (r *lotteryTimesRepo) IncrUserMissNum(activityID, uid uint) (int64, error) {
+	redisCli := r.data.cache
+	i := uid % r.lotteryConf.Limits().UserFrameSize
+	key := constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserLotteryMissNumPrefix+"%d", i))
+	ret, err := redisCli.HIncrBy(context.Background(), key, fmt.Sprint(uid), 1)
+	if err != nil {
+		return 0, fmt.Errorf("lotteryTimesRepo|IncrUserMissNum:%v", err)
 	}
+	return ret, nil
+}
+
+// ResetUserMissNum 用户中奖后清空连续未中奖次数
+func (r *lotteryTimesRepo) ResetUserMissNum(activityID, uid uint) error {
+	redisCli := r.data.cache
+	i := uid % r.lotteryConf.Limits().UserFrameSize
+	key := constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserLotteryMissNumPrefix+"%d", i))
+	if _, err := redisCli.HDel(context.Background(), key, fmt.Sprint(uid)); err != nil {
+		return fmt.Errorf("lotteryTimesRepo|ResetUserMissNum:%v", err)
+	}
+	return nil
 }
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"time"
+)
+
+type drawOutboxRepo struct {
+	data *Data
+}
+
+func NewDrawOutboxRepo(data *Data) biz.DrawOutboxRepo {
+	return &drawOutboxRepo{
+		data: data,
+	}
+}
+
+func (r *drawOutboxRepo) Create(ctx context.Context, outbox *biz.DrawOutbox) error {
+	db := r.data.DB(ctx)
+	if err := db.Model(&biz.DrawOutbox{}).Create(outbox).Error; err != nil {
+		return fmt.Errorf("drawOutboxRepo|Create:%v", err)
+	}
+	return nil
+}
+
+// UpdateStatus 条件更新状态，并发修改时只有一个能成功
+func (r *drawOutboxRepo) UpdateStatus(ctx context.Context, id uint, from, to uint) (bool, error) {
+	db := r.data.DB(ctx)
+	res := db.Model(&biz.DrawOutbox{}).Where("id = ? and status = ?", id, from).
+		Updates(map[string]interface{}{"status": to, "sys_updated": time.Now()})
+	if res.Error != nil {
+		return false, fmt.Errorf("drawOutboxRepo|UpdateStatus:%v", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *drawOutboxRepo) GetStaleList(status uint, before time.Time, limit int) ([]*biz.DrawOutbox, error) {
+	db := r.data.db
+	var list []*biz.DrawOutbox
+	err := db.Model(&biz.DrawOutbox{}).Where("status = ? and sys_updated < ?", status, before).
+		Order("id").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("drawOutboxRepo|GetStaleList:%v", err)
+	}
+	return list, nil
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// applyPoolLedgerScript 放入记录ID不在集合中时才增加奖品池，奖品池为负数时先置0
+// 负数是奖品池为空时抽奖多扣的，不是真实发出的奖品
+var applyPoolLedgerScript = redis.NewScript(`
+if redis.call('SADD', KEYS[2], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+if tonumber(redis.call('HGET', KEYS[1], ARGV[2]) or '0') < 0 then
+	redis.call('HSET', KEYS[1], ARGV[2], 0)
+end
+redis.call('HINCRBY', KEYS[1], ARGV[2], ARGV[3])
+return 1
+`)
+
+type prizePoolLedgerRepo struct {
+	data *Data
+}
+
+func NewPrizePoolLedgerRepo(data *Data) biz.PrizePoolLedgerRepo {
+	return &prizePoolLedgerRepo{
+		data: data,
+	}
+}
+
+func (r *prizePoolLedgerRepo) CreateIgnore(ctx context.Context, list []*biz.PrizePoolLedger) error {
+	db := r.data.DB(ctx)
+	err := db.Model(&biz.PrizePoolLedger{}).Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(list, constant.PrizePlanBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("prizePoolLedgerRepo|CreateIgnore:%v", err)
+	}
+	return nil
+}
+
+func (r *prizePoolLedgerRepo) GetPendingList(limit int) ([]*biz.PrizePoolLedger, error) {
+	db := r.data.db
+	var list []*biz.PrizePoolLedger
+	err := db.Model(&biz.PrizePoolLedger{}).Where("applied = 0").Order("id").Limit(limit).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("prizePoolLedgerRepo|GetPendingList:%v", err)
+	}
+	return list, nil
+}
+
+func (r *prizePoolLedgerRepo) ApplyToPool(ledger *biz.PrizePoolLedger) error {
+	keys := []string{
+		constant.ActivityCacheKey(ledger.ActivityId, constant.PrizePoolCacheKey),
+		constant.ActivityCacheKey(ledger.ActivityId, constant.PoolLedgerCacheKey),
+	}
+	_, err := r.data.evalScript(context.Background(), applyPoolLedgerScript, keys,
+		ledger.Id, ledger.PrizeId, ledger.Num, int64(constant.PoolLedgerCacheTime/time.Second))
+	if err != nil {
+		return fmt.Errorf("prizePoolLedgerRepo|ApplyToPool:%v", err)
+	}
+	err = r.data.db.Model(&biz.PrizePoolLedger{}).Where("id = ?", ledger.Id).
+		Updates(map[string]interface{}{
+			"applied":     1,
+			"sys_updated": time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("prizePoolLedgerRepo|ApplyToPool:%v", err)
+	}
+	return nil
+}
+
+func (r *prizePoolLedgerRepo) SumNum(prizeID uint, since time.Time) (int, int, error) {
+	db := r.data.db
+	var row struct {
+		Released int
+		Applied  int
+	}
+	err := db.Model(&biz.PrizePoolLedger{}).
+		Select("coalesce(sum(num), 0) as released, coalesce(sum(case when applied = 1 then num else 0 end), 0) as applied").
+		Where("prize_id = ? and release_at >= ?", prizeID, since).Scan(&row).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("prizePoolLedgerRepo|SumNum:%v", err)
+	}
+	return row.Released, row.Applied, nil
+}
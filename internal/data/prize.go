@@ -38,8 +38,8 @@ func (r *prizeRepo) Get(id uint) (*biz.Prize, error) {
 	return prize, nil
 }
 
-func (r *prizeRepo) GetWithCache(id uint) (*biz.Prize, error) {
-	prizeList, err := r.GetAllWithCache()
+func (r *prizeRepo) GetWithCache(activityID, id uint) (*biz.Prize, error) {
+	prizeList, err := r.GetAllWithCache(activityID)
 	if err != nil {
 		return nil, fmt.Errorf("prizeRepo|GetWithCache:%v", err)
 	}
@@ -61,19 +61,30 @@ func (r *prizeRepo) GetAll() ([]*biz.Prize, error) {
 	return prizes, nil
 }
 
-func (r *prizeRepo) GetAllWithCache() ([]*biz.Prize, error) {
-	prizeList, err := r.GetAllByCache()
+// GetAllByActivity 获取某个活动下的所有奖品
+func (r *prizeRepo) GetAllByActivity(activityID uint) ([]*biz.Prize, error) {
+	db := r.data.db
+	var prizes []*biz.Prize
+	err := db.Model(&biz.Prize{}).Where("activity_id = ?", activityID).Find(&prizes).Error
+	if err != nil {
+		return nil, fmt.Errorf("prizeRepo|GetAllByActivity:%v", err)
+	}
+	return prizes, nil
+}
+
+func (r *prizeRepo) GetAllWithCache(activityID uint) ([]*biz.Prize, error) {
+	prizeList, err := r.GetAllByCache(activityID)
 	if err != nil {
 		return nil, fmt.Errorf("prizeRepo|GetAllWithCache:%v", err)
 	}
 	if prizeList == nil {
 		// 缓存没查到，从db获取
-		prizeList, err = r.GetAll()
+		prizeList, err = r.GetAllByActivity(activityID)
 		if err != nil {
 			return nil, fmt.Errorf("prizeRepo|GetAllWithCache:%v", err)
 		}
 		// 将数据更新到缓存中
-		if err = r.SetAllByCache(prizeList); err != nil {
+		if err = r.SetAllByCache(activityID, prizeList); err != nil {
 			return nil, fmt.Errorf("prizeRepo|GetAllWithCache:%v", err)
 		}
 	}
@@ -90,8 +101,8 @@ func (r *prizeRepo) CountAll() (int64, error) {
 	return num, nil
 }
 
-func (r *prizeRepo) CountAllWithCache() (int64, error) {
-	prizeList, err := r.GetAllWithCache()
+func (r *prizeRepo) CountAllWithCache(activityID uint) (int64, error) {
+	prizeList, err := r.GetAllWithCache(activityID)
 	if err != nil {
 		return 0, fmt.Errorf("prizeRepo|CountAllWithCache:%v", err)
 	}
@@ -132,17 +143,31 @@ func (r *prizeRepo) Delete(id uint) error {
 	return nil
 }
 
-func (r *prizeRepo) DeleteAll() error {
+// DeleteAll 软删除全部奖品，记录操作人IP，并清理各活动的奖品缓存
+func (r *prizeRepo) DeleteAll(sysIp string) error {
 	db := r.data.db
-	if err := db.Exec("DELETE FROM t_prize").Error; err != nil {
+	var activityIDs []uint
+	if err := db.Model(&biz.Prize{}).Distinct().Pluck("activity_id", &activityIDs).Error; err != nil {
 		return fmt.Errorf("prizeRepo|DeleteAll:%v", err)
 	}
+	err := db.Model(&biz.Prize{}).Where("sys_status = ?", constant.PrizeStatusNormal).
+		Updates(map[string]interface{}{"sys_status": constant.PrizeStatusDelete, "sys_ip": sysIp}).Error
+	if err != nil {
+		return fmt.Errorf("prizeRepo|DeleteAll:%v", err)
+	}
+	for _, activityID := range activityIDs {
+		key := constant.ActivityCacheKey(activityID, constant.AllPrizeCacheKey)
+		if err = r.data.cache.Delete(context.Background(), key); err != nil {
+			return fmt.Errorf("prizeRepo|DeleteAll:%v", err)
+		}
+	}
 	return nil
 }
 
-func (r *prizeRepo) DeleteWithCache(id uint) error {
+func (r *prizeRepo) DeleteWithCache(activityID, id uint) error {
 	prize := &biz.Prize{
-		Id: id,
+		Id:         id,
+		ActivityId: activityID,
 	}
 	if err := r.UpdateByCache(prize); err != nil {
 		return fmt.Errorf("prizeRepo|DeleteWithCache:%v", err)
@@ -191,11 +216,11 @@ func (r *prizeRepo) GetFromCache(id uint) (*biz.Prize, error) {
 	return &prize, nil
 }
 
-func (r *prizeRepo) GetAllUsefulPrizeList() ([]*biz.Prize, error) {
+func (r *prizeRepo) GetAllUsefulPrizeList(activityID uint) ([]*biz.Prize, error) {
 	db := r.data.db
 	now := time.Now()
 	list := make([]*biz.Prize, 0)
-	err := db.Model(&biz.Prize{}).Where("begin_time<=?", now).Where("end_time >= ?", now).
+	err := db.Model(&biz.Prize{}).Where("activity_id=?", activityID).Where("begin_time<=?", now).Where("end_time >= ?", now).
 		Where("prize_num>?", 0).Where("sys_status=?", 1).Order("sys_updated desc").
 		Order("display_order asc").Find(&list).Error
 	if err != nil {
@@ -205,9 +230,9 @@ func (r *prizeRepo) GetAllUsefulPrizeList() ([]*biz.Prize, error) {
 }
 
 // GetAllUsefulPrizeListWithCache 筛选出符合条件的奖品列表
-func (r *prizeRepo) GetAllUsefulPrizeListWithCache() ([]*biz.Prize, error) {
+func (r *prizeRepo) GetAllUsefulPrizeListWithCache(activityID uint) ([]*biz.Prize, error) {
 	// 优先从缓存取，缓存没取到，从db取
-	prizeList, err := r.GetAllWithCache()
+	prizeList, err := r.GetAllWithCache(activityID)
 	if err != nil {
 		return nil, fmt.Errorf("prizeRepo|GetAllUsefulPrizeListWithCache:%v", err)
 	}
@@ -222,8 +247,8 @@ func (r *prizeRepo) GetAllUsefulPrizeListWithCache() ([]*biz.Prize, error) {
 	return dataList, nil
 }
 
-func (r *prizeRepo) DecrLeftNum(id int, num int) (bool, error) {
-	db := r.data.db
+func (r *prizeRepo) DecrLeftNum(ctx context.Context, id int, num int) (bool, error) {
+	db := r.data.DB(ctx)
 	//log.Infof("id: %d, num: %d\n", id, num)
 	res := db.Model(&biz.Prize{}).Where("id = ? and left_num >= ?", id, num).UpdateColumn("left_num", gorm.Expr("left_num - ?", num))
 	if res.Error != nil {
@@ -236,9 +261,9 @@ func (r *prizeRepo) DecrLeftNum(id int, num int) (bool, error) {
 }
 
 // DecrLeftNumByPool 奖品缓冲池 对应奖品数量递减
-func (r *prizeRepo) DecrLeftNumByPool(prizeID int) (int64, error) {
+func (r *prizeRepo) DecrLeftNumByPool(activityID uint, prizeID int) (int64, error) {
 	redisCli := r.data.cache
-	key := constant.PrizePoolCacheKey
+	key := constant.ActivityCacheKey(activityID, constant.PrizePoolCacheKey)
 	field := strconv.Itoa(prizeID)
 	cnt, err := redisCli.HIncrBy(context.Background(), key, field, -1)
 	if err != nil {
@@ -247,17 +272,17 @@ func (r *prizeRepo) DecrLeftNumByPool(prizeID int) (int64, error) {
 	return cnt, nil
 }
 
-func (r *prizeRepo) IncrLeftNum(id int, column string, num int) error {
-	db := r.data.db
+func (r *prizeRepo) IncrLeftNum(ctx context.Context, id int, column string, num int) error {
+	db := r.data.DB(ctx)
 	if err := db.Model(&biz.Prize{}).Where("id = ?", id).
-		Update(column, gorm.Expr(column+" + ？", num)).Error; err != nil {
+		Update(column, gorm.Expr(column+" + ?", num)).Error; err != nil {
 		return fmt.Errorf("prizeRepo|IncrLeftNum err: %v", err)
 	}
 	return nil
 }
 
 // SetAllByCache 全量数据保存到redis中
-func (r *prizeRepo) SetAllByCache(prizeList []*biz.Prize) error {
+func (r *prizeRepo) SetAllByCache(activityID uint, prizeList []*biz.Prize) error {
 	redisCli := r.data.cache
 	value := ""
 	if len(prizeList) > 0 {
@@ -266,10 +291,13 @@ func (r *prizeRepo) SetAllByCache(prizeList []*biz.Prize) error {
 			prize := prizeList[i]
 			prizeMap := make(map[string]interface{})
 			prizeMap["Id"] = prize.Id
+			prizeMap["ActivityId"] = prize.ActivityId
 			prizeMap["Title"] = prize.Title
 			prizeMap["PrizeNum"] = prize.PrizeNum
 			prizeMap["LeftNum"] = prize.LeftNum
 			prizeMap["PrizeCode"] = prize.PrizeCode
+			prizeMap["Weight"] = prize.Weight
+			prizeMap["UserWinMax"] = prize.UserWinMax
 			prizeMap["PrizeTime"] = prize.PrizeTime
 			prizeMap["Img"] = prize.Img
 			prizeMap["DisplayOrder"] = prize.DisplayOrder
@@ -277,7 +305,6 @@ func (r *prizeRepo) SetAllByCache(prizeList []*biz.Prize) error {
 			prizeMap["PrizeProfile"] = prize.PrizeProfile
 			prizeMap["BeginTime"] = utils.FormatFromUnixTime(prize.BeginTime.Unix())
 			prizeMap["EndTime"] = utils.FormatFromUnixTime(prize.EndTime.Unix())
-			prizeMap["PrizePlan"] = prize.PrizePlan
 			prizeMap["PrizeBegin"] = utils.FormatFromUnixTime(prize.PrizeBegin.Unix())
 			prizeMap["PrizeEnd"] = utils.FormatFromUnixTime(prize.PrizeEnd.Unix())
 			prizeMap["SysStatus"] = prize.SysStatus
@@ -293,7 +320,8 @@ func (r *prizeRepo) SetAllByCache(prizeList []*biz.Prize) error {
 		}
 		value = string(bytes)
 	}
-	if err := redisCli.Set(context.Background(), constant.AllPrizeCacheKey, value, time.Second*time.Duration(constant.AllPrizeCacheTime)); err != nil {
+	key := constant.ActivityCacheKey(activityID, constant.AllPrizeCacheKey)
+	if err := redisCli.Set(context.Background(), key, value, time.Second*time.Duration(constant.AllPrizeCacheTime)); err != nil {
 		log.Errorf("SetAllByCache|set cache err:%v", err)
 		return fmt.Errorf("SetAllByCache|set cache err:%v", err)
 	}
@@ -301,9 +329,10 @@ func (r *prizeRepo) SetAllByCache(prizeList []*biz.Prize) error {
 }
 
 // GetAllByCache 从缓存中获取所有的奖品信息
-func (r *prizeRepo) GetAllByCache() ([]*biz.Prize, error) {
+func (r *prizeRepo) GetAllByCache(activityID uint) ([]*biz.Prize, error) {
 	redisCli := r.data.cache
-	valueStr, ok, err := redisCli.Get(context.Background(), constant.AllPrizeCacheKey)
+	key := constant.ActivityCacheKey(activityID, constant.AllPrizeCacheKey)
+	valueStr, ok, err := redisCli.Get(context.Background(), key)
 	if err != nil {
 		return nil, fmt.Errorf("prizeRepo|GetAllByCache:%v", err)
 	}
@@ -362,10 +391,13 @@ func (r *prizeRepo) GetAllByCache() ([]*biz.Prize, error) {
 		}
 		prize := &biz.Prize{
 			Id:           uint(id),
+			ActivityId:   uint(utils.GetInt64FromMap(prizeMap, "ActivityId", 0)),
 			Title:        utils.GetStringFromMap(prizeMap, "Title", ""),
 			PrizeNum:     int(utils.GetInt64FromMap(prizeMap, "PrizeNum", 0)),
 			LeftNum:      int(utils.GetInt64FromMap(prizeMap, "LeftNum", 0)),
 			PrizeCode:    utils.GetStringFromMap(prizeMap, "PrizeCode", ""),
+			Weight:       uint(utils.GetInt64FromMap(prizeMap, "Weight", 0)),
+			UserWinMax:   uint(utils.GetInt64FromMap(prizeMap, "UserWinMax", 0)),
 			PrizeTime:    uint(utils.GetInt64FromMap(prizeMap, "PrizeTime", 0)),
 			Img:          utils.GetStringFromMap(prizeMap, "Img", ""),
 			DisplayOrder: uint(utils.GetInt64FromMap(prizeMap, "DisplayOrder", 0)),
@@ -392,16 +424,17 @@ func (r *prizeRepo) UpdateByCache(prize *biz.Prize) error {
 		return nil
 	}
 	redisCli := r.data.cache
-	if err := redisCli.Delete(context.Background(), constant.AllPrizeCacheKey); err != nil {
+	key := constant.ActivityCacheKey(prize.ActivityId, constant.AllPrizeCacheKey)
+	if err := redisCli.Delete(context.Background(), key); err != nil {
 		return fmt.Errorf("prizeRepo|UpdateByCache err:%v", err)
 	}
 	return nil
 }
 
 // GetPrizePoolNum 获取奖品缓冲池中获取数据
-func (r *prizeRepo) GetPrizePoolNum(prizeID uint) (int, error) {
+func (r *prizeRepo) GetPrizePoolNum(activityID, prizeID uint) (int, error) {
 	redisCli := r.data.cache
-	key := constant.PrizePoolCacheKey
+	key := constant.ActivityCacheKey(activityID, constant.PrizePoolCacheKey)
 	field := strconv.Itoa(int(prizeID))
 	res, err := redisCli.HGet(context.Background(), key, field)
 	if err != nil {
@@ -414,6 +447,29 @@ func (r *prizeRepo) GetPrizePoolNum(prizeID uint) (int, error) {
 	return num, nil
 }
 
+// GetPrizePoolNums 获取活动奖品池中所有奖品的数量
+func (r *prizeRepo) GetPrizePoolNums(activityID uint) (map[uint]int, error) {
+	redisCli := r.data.cache
+	key := constant.ActivityCacheKey(activityID, constant.PrizePoolCacheKey)
+	valueMap, err := redisCli.HGetAll(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("prizeRepo|GetPrizePoolNums:%v", err)
+	}
+	nums := make(map[uint]int, len(valueMap))
+	for field, value := range valueMap {
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		num, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		nums[uint(id)] = num
+	}
+	return nums, nil
+}
+
 func (r *prizeRepo) SetPrizePoolNum(key string, prizeID uint, num int) error {
 	idStr := strconv.Itoa(int(prizeID))
 	_, err := r.data.cache.HSet(context.Background(), key, idStr, strconv.Itoa(num))
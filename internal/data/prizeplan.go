@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"time"
+)
+
+type prizePlanRepo struct {
+	data *Data
+}
+
+func NewPrizePlanRepo(data *Data) biz.PrizePlanRepo {
+	return &prizePlanRepo{
+		data: data,
+	}
+}
+
+func (r *prizePlanRepo) Replace(ctx context.Context, prizeID uint, list []*biz.PrizePlan) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+		if err := db.Where("prize_id = ?", prizeID).Delete(&biz.PrizePlan{}).Error; err != nil {
+			return fmt.Errorf("prizePlanRepo|Replace:%v", err)
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		if err := db.Model(&biz.PrizePlan{}).CreateInBatches(list, constant.PrizePlanBatchSize).Error; err != nil {
+			return fmt.Errorf("prizePlanRepo|Replace:%v", err)
+		}
+		return nil
+	})
+}
+
+func (r *prizePlanRepo) Clear(ctx context.Context, prizeID uint) error {
+	db := r.data.DB(ctx)
+	if err := db.Where("prize_id = ?", prizeID).Delete(&biz.PrizePlan{}).Error; err != nil {
+		return fmt.Errorf("prizePlanRepo|Clear:%v", err)
+	}
+	return nil
+}
+
+func (r *prizePlanRepo) GetPlannedPrizeIds() (map[uint]bool, error) {
+	db := r.data.db
+	var ids []uint
+	if err := db.Model(&biz.PrizePlan{}).Distinct().Pluck("prize_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("prizePlanRepo|GetPlannedPrizeIds:%v", err)
+	}
+	idMap := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		idMap[id] = true
+	}
+	return idMap, nil
+}
+
+func (r *prizePlanRepo) GetDueList(prizeID uint, now time.Time) ([]*biz.PrizePlan, error) {
+	db := r.data.db
+	var list []*biz.PrizePlan
+	err := db.Model(&biz.PrizePlan{}).Where("prize_id = ? and released = 0 and release_at <= ?", prizeID, now).
+		Order("release_at").Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("prizePlanRepo|GetDueList:%v", err)
+	}
+	return list, nil
+}
+
+func (r *prizePlanRepo) MarkReleased(ctx context.Context, ids []uint) (int64, error) {
+	db := r.data.DB(ctx)
+	res := db.Model(&biz.PrizePlan{}).Where("id in ? and released = 0", ids).
+		Updates(map[string]interface{}{
+			"released":    1,
+			"sys_updated": time.Now(),
+		})
+	if res.Error != nil {
+		return 0, fmt.Errorf("prizePlanRepo|MarkReleased:%v", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+func (r *prizePlanRepo) SumPending(prizeID uint) (int, error) {
+	db := r.data.db
+	var num int
+	err := db.Model(&biz.PrizePlan{}).Select("coalesce(sum(num), 0)").
+		Where("prize_id = ? and released = 0", prizeID).Scan(&num).Error
+	if err != nil {
+		return 0, fmt.Errorf("prizePlanRepo|SumPending:%v", err)
+	}
+	return num, nil
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/BitofferHub/pkg/middlewares/log"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type resultRepo struct {
@@ -50,6 +52,39 @@ func (r *resultRepo) GetAll() ([]*biz.Result, error) {
 	return results, nil
 }
 
+// GetPage 按条件分页查询中奖记录，返回当前页数据和总数
+func (r *resultRepo) GetPage(filter *biz.ResultFilter, page *biz.PageQuery) ([]*biz.Result, int64, error) {
+	db := r.data.db.Model(&biz.Result{})
+	if filter.ActivityId != nil {
+		db = db.Where("activity_id = ?", *filter.ActivityId)
+	}
+	if filter.UserId > 0 {
+		db = db.Where("user_id = ?", filter.UserId)
+	}
+	if filter.PrizeId > 0 {
+		db = db.Where("prize_id = ?", filter.PrizeId)
+	}
+	if filter.SysStatus != nil {
+		db = db.Where("sys_status = ?", *filter.SysStatus)
+	}
+	if !filter.BeginTime.IsZero() {
+		db = db.Where("sys_created >= ?", filter.BeginTime)
+	}
+	if !filter.EndTime.IsZero() {
+		db = db.Where("sys_created < ?", filter.EndTime)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("resultRepo|GetPage:%v", err)
+	}
+	var results []*biz.Result
+	err := db.Order("id desc").Offset(page.Offset()).Limit(page.PageSize).Find(&results).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("resultRepo|GetPage:%v", err)
+	}
+	return results, total, nil
+}
+
 func (r *resultRepo) CountAll() (int64, error) {
 	db := r.data.db
 	var num int64
@@ -60,8 +95,8 @@ func (r *resultRepo) CountAll() (int64, error) {
 	return num, nil
 }
 
-func (r *resultRepo) Create(result *biz.Result) error {
-	db := r.data.db
+func (r *resultRepo) Create(ctx context.Context, result *biz.Result) error {
+	db := r.data.DB(ctx)
 	err := db.Model(&biz.Result{}).Create(result).Error
 	if err != nil {
 		return fmt.Errorf("resultRepo|Create:%v", err)
@@ -73,7 +108,7 @@ func (r *resultRepo) Delete(id uint) error {
 	db := r.data.db
 	result := &biz.Result{Id: id}
 	if err := db.Model(&biz.Result{}).Delete(result).Error; err != nil {
-		return fmt.Errorf("resultRepo|Delete:%v")
+		return fmt.Errorf("resultRepo|Delete:%v", err)
 	}
 	return nil
 }
@@ -120,3 +155,122 @@ func (r *resultRepo) GetFromCache(id uint) (*biz.Result, error) {
 
 	return &result, nil
 }
+
+// CountUserWin 统计用户在活动中每个奖品的中奖次数，以及dayBegin之后每种奖品类型的中奖次数
+func (r *resultRepo) CountUserWin(activityID, uid uint, dayBegin time.Time) (*biz.UserWinCount, error) {
+	db := r.data.db
+	count := &biz.UserWinCount{
+		Prize:   make(map[uint]int64),
+		TypeDay: make(map[uint]int64),
+	}
+	var prizeRows []struct {
+		PrizeId uint
+		Num     int64
+	}
+	err := db.Model(&biz.Result{}).Select("prize_id, count(*) as num").
+		Where("activity_id = ? and user_id = ?", activityID, uid).Group("prize_id").Scan(&prizeRows).Error
+	if err != nil {
+		return nil, fmt.Errorf("resultRepo|CountUserWin:%v", err)
+	}
+	for _, row := range prizeRows {
+		count.Prize[row.PrizeId] = row.Num
+		count.Total += row.Num
+	}
+	var typeRows []struct {
+		PrizeType uint
+		Num       int64
+	}
+	err = db.Model(&biz.Result{}).Select("prize_type, count(*) as num").
+		Where("activity_id = ? and user_id = ? and sys_created >= ?", activityID, uid, dayBegin).
+		Group("prize_type").Scan(&typeRows).Error
+	if err != nil {
+		return nil, fmt.Errorf("resultRepo|CountUserWin:%v", err)
+	}
+	for _, row := range typeRows {
+		count.TypeDay[row.PrizeType] = row.Num
+	}
+	return count, nil
+}
+
+// UpdateFulfill 条件更新履约状态，并发修改时只有一个能成功
+func (r *resultRepo) UpdateFulfill(ctx context.Context, result *biz.Result, from uint, cols ...string) (bool, error) {
+	db := r.data.DB(ctx)
+	cols = append(cols, "fulfill_status")
+	res := db.Model(&biz.Result{}).Where("id = ? and fulfill_status = ?", result.Id, from).
+		Select(cols).Updates(result)
+	if res.Error != nil {
+		return false, fmt.Errorf("resultRepo|UpdateFulfill:%v", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *resultRepo) GetFulfillList(filter *biz.FulfillFilter, limit int) ([]*biz.Result, error) {
+	db := r.data.db.Model(&biz.Result{}).Where("fulfill_status = ?", filter.FulfillStatus)
+	if filter.ActivityId != nil {
+		db = db.Where("activity_id = ?", *filter.ActivityId)
+	}
+	if !filter.DeadlineBefore.IsZero() {
+		db = db.Where("claim_deadline < ?", filter.DeadlineBefore)
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	var results []*biz.Result
+	if err := db.Order("id").Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("resultRepo|GetFulfillList:%v", err)
+	}
+	return results, nil
+}
+
+// GetRecentList 获取活动最近的正常中奖记录
+func (r *resultRepo) GetRecentList(activityID uint, limit int) ([]*biz.Result, error) {
+	db := r.data.db
+	var results []*biz.Result
+	err := db.Model(&biz.Result{}).Where("activity_id = ? and sys_status = 1", activityID).
+		Order("id desc").Limit(limit).Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("resultRepo|GetRecentList:%v", err)
+	}
+	return results, nil
+}
+
+// GetRecentWinnersFromCache 从缓存获取最近中奖用户，缓存不存在时返回nil
+func (r *resultRepo) GetRecentWinnersFromCache(activityID uint) ([]*biz.RecentWinner, error) {
+	key := constant.ActivityCacheKey(activityID, constant.RecentWinnerCacheKey)
+	ret, exist, err := r.data.cache.Get(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("resultRepo|GetRecentWinnersFromCache:%v", err)
+	}
+	if !exist {
+		return nil, nil
+	}
+	winners := make([]*biz.RecentWinner, 0)
+	if err = json.Unmarshal([]byte(ret), &winners); err != nil {
+		return nil, fmt.Errorf("resultRepo|GetRecentWinnersFromCache:%v", err)
+	}
+	return winners, nil
+}
+
+func (r *resultRepo) SetRecentWinnersCache(activityID uint, list []*biz.RecentWinner) error {
+	key := constant.ActivityCacheKey(activityID, constant.RecentWinnerCacheKey)
+	bytes, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("resultRepo|SetRecentWinnersCache:%v", err)
+	}
+	if err = r.data.cache.Set(context.Background(), key, string(bytes), constant.RecentWinnerCacheTime); err != nil {
+		return fmt.Errorf("resultRepo|SetRecentWinnersCache:%v", err)
+	}
+	return nil
+}
+
+func (r *resultRepo) CountIssued(prizeID uint, since time.Time) (int64, error) {
+	db := r.data.db.Model(&biz.Result{}).Where("prize_id = ? and fulfill_status != ?", prizeID, constant.FulfillStatusRestocked)
+	if !since.IsZero() {
+		db = db.Where("sys_created >= ?", since)
+	}
+	var num int64
+	if err := db.Count(&num).Error; err != nil {
+		return 0, fmt.Errorf("resultRepo|CountIssued:%v", err)
+	}
+	return num, nil
+}
@@ -0,0 +1,316 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/conf"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// resultSinkVars 异步写入的统计，通过 /admin/debug_vars 查看
+// enqueued 入队，written 写库，spilled 落盘，replayed 落盘后重新写库，failed 落盘失败丢弃，
+// quarantined 单条写库也失败，隔离到.bad文件等待人工处理，queue_len 当前队列长度
+var resultSinkVars = expvar.NewMap("result_sink")
+
+// NewResultSink 按配置创建中奖纪录写入方式，异步写入在服务退出时写完队列中的纪录
+func NewResultSink(conf *conf.Data, data *Data) (biz.ResultSink, func(), error) {
+	c := conf.GetResultSink()
+	switch c.GetType() {
+	case "", constant.ResultSinkSync:
+		return &syncResultSink{data: data}, func() {}, nil
+	case constant.ResultSinkAsync:
+		sink := newAsyncResultSink(data, c)
+		go sink.run()
+		return sink, sink.close, nil
+	default:
+		return nil, nil, fmt.Errorf("NewResultSink|unknown type %s", c.GetType())
+	}
+}
+
+type syncResultSink struct {
+	data *Data
+}
+
+// Write 在ctx中的事务里写入
+func (s *syncResultSink) Write(ctx context.Context, result *biz.Result) error {
+	if err := s.data.DB(ctx).Model(&biz.Result{}).Create(result).Error; err != nil {
+		return fmt.Errorf("syncResultSink|Write:%v", err)
+	}
+	return nil
+}
+
+func (s *syncResultSink) Async() bool {
+	return false
+}
+
+// asyncResultSink 有界队列加批量写库，队列满时等待一段时间，超时或者写库失败时落盘，之后重新写库
+type asyncResultSink struct {
+	data           *Data
+	queue          chan *biz.Result
+	batchSize      int
+	flushInterval  time.Duration
+	enqueueTimeout time.Duration
+	spillPath      string
+	spillMu        sync.Mutex
+	mu             sync.RWMutex
+	closed         bool
+	done           chan struct{}
+}
+
+func newAsyncResultSink(data *Data, c *conf.Data_ResultSink) *asyncResultSink {
+	s := &asyncResultSink{
+		data:           data,
+		queue:          make(chan *biz.Result, constant.ResultSinkQueueSize),
+		batchSize:      constant.ResultSinkBatchSize,
+		flushInterval:  constant.ResultSinkFlushInterval,
+		enqueueTimeout: constant.ResultSinkEnqueueTimeout,
+		spillPath:      constant.ResultSinkSpillPath,
+		done:           make(chan struct{}),
+	}
+	if c.GetQueueSize() > 0 {
+		s.queue = make(chan *biz.Result, c.GetQueueSize())
+	}
+	if c.GetBatchSize() > 0 {
+		s.batchSize = int(c.GetBatchSize())
+	}
+	if c.GetFlushInterval().AsDuration() > 0 {
+		s.flushInterval = c.GetFlushInterval().AsDuration()
+	}
+	if c.GetEnqueueTimeout().AsDuration() > 0 {
+		s.enqueueTimeout = c.GetEnqueueTimeout().AsDuration()
+	}
+	if c.GetSpillPath() != "" {
+		s.spillPath = c.GetSpillPath()
+	}
+	resultSinkVars.Set("queue_len", expvar.Func(func() interface{} {
+		return len(s.queue)
+	}))
+	return s
+}
+
+// Write 入队，队列满时最多等待enqueueTimeout，超时写入落盘文件
+func (s *asyncResultSink) Write(ctx context.Context, result *biz.Result) error {
+	if result.SysCreated == nil {
+		now := time.Now()
+		result.SysCreated = &now
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		// 服务退出中，不再入队
+		return s.insert([]*biz.Result{result})
+	}
+	select {
+	case s.queue <- result:
+		resultSinkVars.Add("enqueued", 1)
+		return nil
+	default:
+	}
+	timer := time.NewTimer(s.enqueueTimeout)
+	defer timer.Stop()
+	select {
+	case s.queue <- result:
+		resultSinkVars.Add("enqueued", 1)
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return s.spill([]*biz.Result{result})
+}
+
+func (s *asyncResultSink) Async() bool {
+	return true
+}
+
+func (s *asyncResultSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	s.replay()
+	batch := make([]*biz.Result, 0, s.batchSize)
+	for {
+		select {
+		case result, ok := <-s.queue:
+			if !ok {
+				s.insert(batch)
+				s.replay()
+				return
+			}
+			batch = append(batch, result)
+			if len(batch) >= s.batchSize {
+				s.insert(batch)
+				batch = make([]*biz.Result, 0, s.batchSize)
+			}
+		case <-ticker.C:
+			s.insert(batch)
+			batch = make([]*biz.Result, 0, s.batchSize)
+			s.replay()
+		}
+	}
+}
+
+// close 停止入队，等待队列中的纪录写完
+func (s *asyncResultSink) close() {
+	s.mu.Lock()
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+	<-s.done
+}
+
+// insert 批量写库，失败时逐条写库
+func (s *asyncResultSink) insert(list []*biz.Result) error {
+	return s.store(list, "written")
+}
+
+// store 批量写库，成功的条数计入metric，批量失败时逐条重试
+// 数据库不可用时剩下的纪录落盘等待重放，单条写库失败的纪录隔离到.bad文件，避免一条坏数据反复阻塞整个落盘文件
+func (s *asyncResultSink) store(list []*biz.Result, metric string) error {
+	if len(list) == 0 {
+		return nil
+	}
+	err := s.data.db.Model(&biz.Result{}).CreateInBatches(list, s.batchSize).Error
+	if err == nil {
+		resultSinkVars.Add(metric, int64(len(list)))
+		return nil
+	}
+	log.Errorf("asyncResultSink|store num=%d err:%v", len(list), err)
+	var bad []*biz.Result
+	written := 0
+	for i, result := range list {
+		// 批量写库失败时回滚的自增ID不能复用
+		result.Id = 0
+		if err = s.data.db.Model(&biz.Result{}).Create(result).Error; err == nil {
+			written++
+			continue
+		}
+		if !s.dbAvailable() {
+			resultSinkVars.Add(metric, int64(written))
+			s.quarantine(bad)
+			return s.spill(list[i:])
+		}
+		log.Errorf("asyncResultSink|store user_id=%d prize_id=%d err:%v", result.UserId, result.PrizeId, err)
+		bad = append(bad, result)
+	}
+	resultSinkVars.Add(metric, int64(written))
+	return s.quarantine(bad)
+}
+
+// dbAvailable 区分是数据库不可用还是纪录本身有问题
+func (s *asyncResultSink) dbAvailable() bool {
+	sqlDB, err := s.data.db.DB()
+	if err != nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), constant.ResultSinkPingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx) == nil
+}
+
+// spill 追加写入落盘文件，每行一条json
+func (s *asyncResultSink) spill(list []*biz.Result) error {
+	if err := s.appendResults(s.spillPath, list); err != nil {
+		resultSinkVars.Add("failed", int64(len(list)))
+		log.Errorf("asyncResultSink|spill num=%d err:%v", len(list), err)
+		return fmt.Errorf("asyncResultSink|spill:%v", err)
+	}
+	resultSinkVars.Add("spilled", int64(len(list)))
+	return nil
+}
+
+// quarantine 单条写库失败的纪录追加写入.bad文件，不再重放
+func (s *asyncResultSink) quarantine(list []*biz.Result) error {
+	if len(list) == 0 {
+		return nil
+	}
+	if err := s.appendResults(s.spillPath+".bad", list); err != nil {
+		resultSinkVars.Add("failed", int64(len(list)))
+		log.Errorf("asyncResultSink|quarantine num=%d err:%v", len(list), err)
+		return fmt.Errorf("asyncResultSink|quarantine:%v", err)
+	}
+	resultSinkVars.Add("quarantined", int64(len(list)))
+	return nil
+}
+
+func (s *asyncResultSink) appendResults(path string, list []*biz.Result) error {
+	var buf bytes.Buffer
+	for _, result := range list {
+		// 写库失败时回滚的自增ID不能复用
+		r := *result
+		r.Id = 0
+		b, _ := json.Marshal(&r)
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	s.spillMu.Lock()
+	defer s.spillMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return appendFile(path, buf.Bytes())
+}
+
+func appendFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// replay 落盘文件重新写库，先改名再读取，避免和追加写入冲突，数据库不可用时重新落盘
+func (s *asyncResultSink) replay() {
+	replayPath := s.spillPath + ".replay"
+	s.spillMu.Lock()
+	if _, err := os.Stat(replayPath); os.IsNotExist(err) {
+		if err = os.Rename(s.spillPath, replayPath); err != nil {
+			s.spillMu.Unlock()
+			return
+		}
+	}
+	s.spillMu.Unlock()
+	f, err := os.Open(replayPath)
+	if err != nil {
+		log.Errorf("asyncResultSink|replay err:%v", err)
+		return
+	}
+	var list []*biz.Result
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		result := &biz.Result{}
+		if err = json.Unmarshal(scanner.Bytes(), result); err != nil {
+			log.Errorf("asyncResultSink|replay line=%s err:%v", scanner.Text(), err)
+			continue
+		}
+		list = append(list, result)
+	}
+	f.Close()
+	if err = scanner.Err(); err != nil {
+		log.Errorf("asyncResultSink|replay err:%v", err)
+		return
+	}
+	if len(list) == 0 {
+		os.Remove(replayPath)
+		return
+	}
+	if err = s.store(list, "replayed"); err != nil {
+		return
+	}
+	os.Remove(replayPath)
+}
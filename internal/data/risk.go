@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+type riskRepo struct {
+	data *Data
+}
+
+func NewRiskRepo(data *Data) biz.RiskRepo {
+	return &riskRepo{
+		data: data,
+	}
+}
+
+// windowIndex 固定时间窗口的序号，同一窗口内的请求使用同一个key
+func windowIndex(window time.Duration, now time.Time) int64 {
+	return now.UnixNano() / int64(window)
+}
+
+// IncrVelocity 计数key在窗口结束后过期
+func (r *riskRepo) IncrVelocity(rule, value string, window time.Duration, now time.Time) (int64, error) {
+	key := fmt.Sprintf("%s%s_%s_%d", constant.RiskVelocityKeyPrefix, rule, value, windowIndex(window, now))
+	var cmd *redis.IntCmd
+	err := r.data.cache.Pipeline(context.Background(), func(pipe redis.Pipeliner) error {
+		cmd = pipe.Incr(context.Background(), key)
+		pipe.Expire(context.Background(), key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("riskRepo|IncrVelocity:%v", err)
+	}
+	return cmd.Val(), nil
+}
+
+// AddIpUser 用集合记录IP在窗口内的用户，集合在窗口结束后过期
+func (r *riskRepo) AddIpUser(ip string, uid uint, window time.Duration, now time.Time) (int64, error) {
+	key := fmt.Sprintf("%s%s_%d", constant.RiskIpUsersKeyPrefix, ip, windowIndex(window, now))
+	var cmd *redis.IntCmd
+	err := r.data.cache.Pipeline(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.SAdd(context.Background(), key, uid)
+		pipe.Expire(context.Background(), key, window)
+		cmd = pipe.SCard(context.Background(), key)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("riskRepo|AddIpUser:%v", err)
+	}
+	return cmd.Val(), nil
+}
+
+func (r *riskRepo) CreateLog(riskLog *biz.RiskLog) error {
+	if err := r.data.db.Model(&biz.RiskLog{}).Create(riskLog).Error; err != nil {
+		return fmt.Errorf("riskRepo|CreateLog:%v", err)
+	}
+	return nil
+}
+
+// GetLogPage 按条件分页查询风控记录，返回当前页数据和总数
+func (r *riskRepo) GetLogPage(filter *biz.RiskLogFilter, page *biz.PageQuery) ([]*biz.RiskLog, int64, error) {
+	db := r.data.db.Model(&biz.RiskLog{})
+	if filter.ActivityId != nil {
+		db = db.Where("activity_id = ?", *filter.ActivityId)
+	}
+	if filter.UserId > 0 {
+		db = db.Where("user_id = ?", filter.UserId)
+	}
+	if filter.Ip != "" {
+		db = db.Where("ip = ?", filter.Ip)
+	}
+	if filter.Action > 0 {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if !filter.BeginTime.IsZero() {
+		db = db.Where("sys_created >= ?", filter.BeginTime)
+	}
+	if !filter.EndTime.IsZero() {
+		db = db.Where("sys_created < ?", filter.EndTime)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("riskRepo|GetLogPage:%v", err)
+	}
+	var list []*biz.RiskLog
+	err := db.Order("id desc").Offset(page.Offset()).Limit(page.PageSize).Find(&list).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("riskRepo|GetLogPage:%v", err)
+	}
+	return list, total, nil
+}
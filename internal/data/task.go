@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/lock"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+type taskRepo struct {
+	data *Data
+}
+
+func NewTaskRepo(data *Data) biz.TaskRepo {
+	return &taskRepo{
+		data: data,
+	}
+}
+
+func (r *taskRepo) CreateRun(run *biz.TaskRun) error {
+	if err := r.data.db.Model(&biz.TaskRun{}).Create(run).Error; err != nil {
+		return fmt.Errorf("taskRepo|CreateRun:%v", err)
+	}
+	return nil
+}
+
+func (r *taskRepo) FinishRun(run *biz.TaskRun) error {
+	err := r.data.db.Model(&biz.TaskRun{}).Where("id = ?", run.Id).
+		Updates(map[string]interface{}{
+			"status":      run.Status,
+			"error":       run.Error,
+			"end_time":    run.EndTime,
+			"sys_updated": time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("taskRepo|FinishRun:%v", err)
+	}
+	return nil
+}
+
+func (r *taskRepo) GetRunPage(name string, page *biz.PageQuery) ([]*biz.TaskRun, int64, error) {
+	db := r.data.db.Model(&biz.TaskRun{})
+	if name != "" {
+		db = db.Where("task_name = ?", name)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("taskRepo|GetRunPage:%v", err)
+	}
+	var list []*biz.TaskRun
+	err := db.Order("id desc").Offset(page.Offset()).Limit(page.PageSize).Find(&list).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("taskRepo|GetRunPage:%v", err)
+	}
+	return list, total, nil
+}
+
+func (r *taskRepo) GetLastRun(name string) (*biz.TaskRun, error) {
+	var list []*biz.TaskRun
+	err := r.data.db.Model(&biz.TaskRun{}).Where("task_name = ?", name).Order("id desc").Limit(1).Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("taskRepo|GetLastRun:%v", err)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (r *taskRepo) SetPaused(name string, paused bool) error {
+	var err error
+	if paused {
+		_, err = r.data.cache.HSet(context.Background(), constant.TaskPausedCacheKey, name, time.Now().Unix())
+	} else {
+		_, err = r.data.cache.HDel(context.Background(), constant.TaskPausedCacheKey, name)
+	}
+	if err != nil {
+		return fmt.Errorf("taskRepo|SetPaused:%v", err)
+	}
+	return nil
+}
+
+func (r *taskRepo) GetPausedNames() (map[string]bool, error) {
+	valueMap, err := r.data.cache.HGetAll(context.Background(), constant.TaskPausedCacheKey)
+	if err != nil {
+		return nil, fmt.Errorf("taskRepo|GetPausedNames:%v", err)
+	}
+	paused := make(map[string]bool, len(valueMap))
+	for name := range valueMap {
+		paused[name] = true
+	}
+	return paused, nil
+}
+
+// AcquireSlot 时间点的key在过期前一直保留，时钟稍有偏差的实例晚一点醒来也抢不到同一个时间点
+func (r *taskRepo) AcquireSlot(ctx context.Context, name string, slot time.Time, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s%s_%d", constant.TaskSlotKeyPrefix, name, slot.Unix())
+	ok, err := r.data.cache.SetNX(ctx, key, name, ttl)
+	if err != nil {
+		return false, fmt.Errorf("taskRepo|AcquireSlot:%v", err)
+	}
+	return ok, nil
+}
+
+// Lock 看门狗每ttl/3续期一次，实例退出后锁在ttl后过期
+func (r *taskRepo) Lock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error) {
+	locker := lock.NewRedisLock(constant.TaskLockKeyPrefix+name,
+		lock.WithExpireSeconds(int64(ttl/time.Second)), lock.WithWatchDogMode())
+	if err := locker.Lock(ctx); err != nil {
+		if errors.Is(err, lock.ErrLockAcquiredByOthers) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("taskRepo|Lock:%v", err)
+	}
+	unlock := func() {
+		if err := locker.Unlock(context.Background()); err != nil {
+			log.Errorf("taskRepo|Lock|Unlock task=%s err:%v", name, err)
+		}
+	}
+	return unlock, true, nil
+}
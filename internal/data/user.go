@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"gorm.io/gorm"
+	"time"
+)
+
+type userRepo struct {
+	data *Data
+}
+
+func NewUserRepo(data *Data) biz.UserRepo {
+	return &userRepo{
+		data: data,
+	}
+}
+
+func (r *userRepo) Get(id uint) (*biz.User, error) {
+	db := r.data.db
+	user := &biz.User{}
+	err := db.Model(&biz.User{}).Where("id = ?", id).First(user).Error
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("userRepo|Get:%v", err)
+	}
+	return user, nil
+}
+
+func (r *userRepo) GetByUserName(userName string) (*biz.User, error) {
+	db := r.data.db
+	user := &biz.User{}
+	err := db.Model(&biz.User{}).Where("user_name = ?", userName).First(user).Error
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("userRepo|GetByUserName:%v", err)
+	}
+	return user, nil
+}
+
+func (r *userRepo) Create(user *biz.User) error {
+	db := r.data.db
+	if err := db.Model(&biz.User{}).Create(user).Error; err != nil {
+		return fmt.Errorf("userRepo|Create:%v", err)
+	}
+	return nil
+}
+
+// DenyToken 将token加入黑名单
+func (r *userRepo) DenyToken(tokenID string, expire time.Duration) error {
+	key := constant.TokenDenyKeyPrefix + tokenID
+	if err := r.data.cache.Set(context.Background(), key, "1", expire); err != nil {
+		return fmt.Errorf("userRepo|DenyToken:%v", err)
+	}
+	return nil
+}
+
+// IsTokenDenied token是否在黑名单中
+func (r *userRepo) IsTokenDenied(tokenID string) (bool, error) {
+	key := constant.TokenDenyKeyPrefix + tokenID
+	_, exist, err := r.data.cache.Get(context.Background(), key)
+	if err != nil {
+		return false, fmt.Errorf("userRepo|IsTokenDenied:%v", err)
+	}
+	return exist, nil
+}
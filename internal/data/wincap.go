@@ -0,0 +1,132 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/redis/go-redis/v9"
+)
+
+// incrUserWinScript 计数hash中的 _loaded 字段标记已经从中奖记录初始化
+// 校验全部上限后同时递增计数，返回 -1 计数未初始化，0 达到上限，1 成功
+// KEYS[1] 用户活动中奖计数，KEYS[2] 用户当天奖品类型中奖计数
+// ARGV[1] 奖品字段，ARGV[2] 奖品上限，ARGV[3] 活动上限，ARGV[4] 类型字段，ARGV[5] 类型上限
+var incrUserWinScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], '_loaded') == 0 or redis.call('HEXISTS', KEYS[2], '_loaded') == 0 then
+	return -1
+end
+local function over(key, field, max)
+	if tonumber(max) == 0 then
+		return false
+	end
+	return tonumber(redis.call('HGET', key, field) or '0') >= tonumber(max)
+end
+if over(KEYS[1], ARGV[1], ARGV[2]) or over(KEYS[1], 'total', ARGV[3]) or over(KEYS[2], ARGV[4], ARGV[5]) then
+	return 0
+end
+redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+redis.call('HINCRBY', KEYS[1], 'total', 1)
+redis.call('HINCRBY', KEYS[2], ARGV[4], 1)
+return 1
+`)
+
+// decrUserWinScript 归还中奖次数，计数已经过期的不处理
+var decrUserWinScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], '_loaded') == 1 then
+	redis.call('HINCRBY', KEYS[1], ARGV[1], -1)
+	redis.call('HINCRBY', KEYS[1], 'total', -1)
+end
+if redis.call('HEXISTS', KEYS[2], '_loaded') == 1 then
+	redis.call('HINCRBY', KEYS[2], ARGV[2], -1)
+end
+return 1
+`)
+
+// initUserWinScript 初始化计数，已经存在的字段不覆盖
+// ARGV[1] KEYS[1]的过期时间，ARGV[2] KEYS[2]的过期时间，ARGV[3] KEYS[1]的字段个数，之后为字段和值
+var initUserWinScript = redis.NewScript(`
+local n = tonumber(ARGV[3])
+for i = 0, n - 1 do
+	redis.call('HSETNX', KEYS[1], ARGV[4 + i * 2], ARGV[5 + i * 2])
+end
+for i = 4 + n * 2, #ARGV, 2 do
+	redis.call('HSETNX', KEYS[2], ARGV[i], ARGV[i + 1])
+end
+redis.call('HSETNX', KEYS[1], '_loaded', 1)
+redis.call('HSETNX', KEYS[2], '_loaded', 1)
+redis.call('EXPIRE', KEYS[1], ARGV[1])
+redis.call('EXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+type winCapRepo struct {
+	data *Data
+}
+
+func NewWinCapRepo(data *Data) biz.WinCapRepo {
+	return &winCapRepo{
+		data: data,
+	}
+}
+
+func userWinKeys(activityID, uid uint, day int) []string {
+	return []string{
+		constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserWinNumPrefix+"%d", uid)),
+		constant.ActivityCacheKey(activityID, fmt.Sprintf(constant.UserTypeDayWinNumPrefix+"%d_%d", day, uid)),
+	}
+}
+
+func prizeWinField(prizeID uint) string {
+	return fmt.Sprintf("prize_%d", prizeID)
+}
+
+// IncrUserWin 未达到上限时中奖次数加一
+func (r *winCapRepo) IncrUserWin(activityID, uid uint, winCap *biz.WinCap) (bool, error) {
+	ret, err := r.data.evalScript(context.Background(), incrUserWinScript,
+		userWinKeys(activityID, uid, winCap.Day), prizeWinField(winCap.PrizeId), winCap.PrizeMax,
+		winCap.TotalMax, fmt.Sprint(winCap.PrizeType), winCap.TypeDayMax)
+	if err != nil {
+		return false, fmt.Errorf("winCapRepo|IncrUserWin:%v", err)
+	}
+	code, ok := ret.(int64)
+	if !ok {
+		return false, fmt.Errorf("winCapRepo|IncrUserWin unexpected result %v", ret)
+	}
+	switch code {
+	case -1:
+		return false, biz.ErrWinCountNotLoaded
+	case 0:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// DecrUserWin 归还中奖次数
+func (r *winCapRepo) DecrUserWin(activityID, uid uint, winCap *biz.WinCap) error {
+	_, err := r.data.evalScript(context.Background(), decrUserWinScript,
+		userWinKeys(activityID, uid, winCap.Day), prizeWinField(winCap.PrizeId), fmt.Sprint(winCap.PrizeType))
+	if err != nil {
+		return fmt.Errorf("winCapRepo|DecrUserWin:%v", err)
+	}
+	return nil
+}
+
+// InitUserWin 用中奖记录的统计初始化计数
+func (r *winCapRepo) InitUserWin(activityID, uid uint, day int, count *biz.UserWinCount) error {
+	args := []interface{}{constant.UserWinCacheTime, constant.UserTypeDayWinCacheTime, len(count.Prize) + 1,
+		"total", count.Total}
+	for prizeID, num := range count.Prize {
+		args = append(args, prizeWinField(prizeID), num)
+	}
+	for prizeType, num := range count.TypeDay {
+		args = append(args, fmt.Sprint(prizeType), num)
+	}
+	_, err := r.data.evalScript(context.Background(), initUserWinScript,
+		userWinKeys(activityID, uid, day), args...)
+	if err != nil {
+		return fmt.Errorf("winCapRepo|InitUserWin:%v", err)
+	}
+	return nil
+}
@@ -1,9 +1,9 @@
 package interfaces
 
 import (
-	"context"
+	"errors"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
-	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/log"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -24,19 +24,11 @@ func (h *Handler) AddPrize(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("AddPrize|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	err := h.adminService.AddPrize(ctx, req.Prize)
 	if err != nil {
 		log.Errorf("AddPrize|err:%v", err)
-		rsp.Code = constant.ErrInternalServer
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		setPrizeErrorRsp(&rsp, err)
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
@@ -58,25 +50,28 @@ func (h *Handler) AddPrizeList(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("AddPrizeList|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	err := h.adminService.AddPrizeList(ctx, req.PrizeList)
 	if err != nil {
 		log.Errorf("AddPrizeList|err:%v", err)
-		rsp.Code = constant.ErrInternalServer
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		setPrizeErrorRsp(&rsp, err)
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
 
+// setPrizeErrorRsp 奖品配置校验不通过时返回字段级的问题列表，其他错误按内部错误返回
+func setPrizeErrorRsp(rsp *HttpResponse, err error) {
+	rsp.Code = constant.ErrInternalServer
+	var validationErr *biz.PrizeValidationError
+	if errors.As(err, &validationErr) {
+		rsp.Code = constant.ErrPrizeInvalid
+		rsp.Data = validationErr.Issues
+	}
+	rsp.Msg = constant.GetErrMsg(rsp.Code)
+}
+
 // ClearPrize 清空奖品数据
 func (h *Handler) ClearPrize(c *gin.Context) {
 	req := ClearPrizeReq{}
@@ -91,19 +86,13 @@ func (h *Handler) ClearPrize(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ClearPrize|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	if err := h.adminService.ClearPrize(ctx); err != nil {
 		log.Errorf("ClearPrize|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -122,19 +111,13 @@ func (h *Handler) ImportCoupon(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ImportCoupon|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	if err := h.adminService.ImportCoupon(ctx, req.CouponInfo.PrizeId, req.CouponInfo.Code); err != nil {
 		log.Errorf("ImportCoupon|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -153,19 +136,13 @@ func (h *Handler) ImportCouponWithCache(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ImportCoupon|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	if err := h.adminService.ImportCouponWithCache(ctx, req.CouponInfo.PrizeId, req.CouponInfo.Code); err != nil {
 		log.Errorf("ImportCoupon|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -184,19 +161,13 @@ func (h *Handler) ClearCoupon(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ClearCoupon|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	if err := h.adminService.ClearCoupon(ctx); err != nil {
 		log.Errorf("ClearCoupon|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -215,19 +186,13 @@ func (h *Handler) ClearLotteryTimes(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ClearLotteryTimes|user_id invalid")
-		rsp.Code = constant.ErrInputInvalid
-		rsp.Msg = constant.GetErrMsg(rsp.Code)
-		c.JSON(http.StatusOK, rsp)
-		return
-	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx := adminContext(c)
 	if err := h.adminService.ClearLotteryTimes(ctx); err != nil {
 		log.Errorf("ClearLotteryTimes|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -246,19 +211,588 @@ func (h *Handler) ClearResult(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	if req.UserID <= 0 {
-		log.Errorf("ClearResult|user_id invalid")
+	ctx := adminContext(c)
+	if err := h.adminService.ClearResult(ctx); err != nil {
+		log.Errorf("ClearResult|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetActivityList 获取活动列表
+func (h *Handler) GetActivityList(c *gin.Context) {
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	ctx := adminContext(c)
+	list, err := h.adminService.GetActivityList(ctx)
+	if err != nil {
+		log.Errorf("GetActivityList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = list
+	c.JSON(http.StatusOK, rsp)
+}
+
+// AddActivity 添加活动
+func (h *Handler) AddActivity(c *gin.Context) {
+	req := AddActivityReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("AddActivity|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.Activity == nil {
+		log.Errorf("AddActivity|input invalid")
 		rsp.Code = constant.ErrInputInvalid
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
-	if err := h.adminService.ClearResult(ctx); err != nil {
-		log.Errorf("ClearResult|err:%v", err)
+	ctx := adminContext(c)
+	if err := h.adminService.AddActivity(ctx, req.Activity); err != nil {
+		log.Errorf("AddActivity|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = req.Activity
+	c.JSON(http.StatusOK, rsp)
+}
+
+// UpdateActivity 修改活动
+func (h *Handler) UpdateActivity(c *gin.Context) {
+	req := UpdateActivityReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("UpdateActivity|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.Activity == nil {
+		log.Errorf("UpdateActivity|input invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.UpdateActivity(ctx, req.Activity); err != nil {
+		log.Errorf("UpdateActivity|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetPrizeList 获取某个活动的奖品列表
+func (h *Handler) GetPrizeList(c *gin.Context) {
+	req := GetPrizeListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetPrizeList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, err := h.adminService.GetPrizeList(ctx, req.ActivityID)
+	if err != nil {
+		log.Errorf("GetPrizeList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = list
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetPrize 获取某个奖品
+func (h *Handler) GetPrize(c *gin.Context) {
+	req := IDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetPrize|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.ID <= 0 {
+		log.Errorf("GetPrize|id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	prize, err := h.adminService.GetPrize(ctx, req.ID)
+	if err != nil {
+		log.Errorf("GetPrize|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = prize
+	c.JSON(http.StatusOK, rsp)
+}
+
+// UpdatePrize 修改奖品
+func (h *Handler) UpdatePrize(c *gin.Context) {
+	req := UpdatePrizeReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("UpdatePrize|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.Prize == nil || req.Prize.Id <= 0 {
+		log.Errorf("UpdatePrize|input invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.UpdatePrize(ctx, req.Prize); err != nil {
+		log.Errorf("UpdatePrize|err:%v", err)
+		setPrizeErrorRsp(&rsp, err)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ReconcilePrizePool 奖品池、发出数量、剩余数量和奖品数量对账，只返回对账结果不做修改
+func (h *Handler) ReconcilePrizePool(c *gin.Context) {
+	req := ReconcilePrizePoolReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("ReconcilePrizePool|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, err := h.adminService.ReconcilePrizePool(ctx, req.ActivityId)
+	if err != nil {
+		log.Errorf("ReconcilePrizePool|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = list
+	c.JSON(http.StatusOK, rsp)
+}
+
+// LintPrizes 检查全部有效奖品的配置，只返回问题列表不做修改
+func (h *Handler) LintPrizes(c *gin.Context) {
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	ctx := adminContext(c)
+	issues, err := h.adminService.LintPrizes(ctx)
+	if err != nil {
+		log.Errorf("LintPrizes|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = issues
+	c.JSON(http.StatusOK, rsp)
+}
+
+// PreviewPrizePlan 预览奖品的发奖计划，不保存
+func (h *Handler) PreviewPrizePlan(c *gin.Context) {
+	req := PreviewPrizePlanReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("PreviewPrizePlan|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.ID <= 0 {
+		log.Errorf("PreviewPrizePlan|id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	preview, err := h.adminService.PreviewPrizePlan(ctx, req.ID, req.Profile)
+	if err != nil {
+		log.Errorf("PreviewPrizePlan|err:%v", err)
+		setPrizeErrorRsp(&rsp, err)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = preview
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeletePrize 软删除奖品
+func (h *Handler) DeletePrize(c *gin.Context) {
+	req := IDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("DeletePrize|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.ID <= 0 {
+		log.Errorf("DeletePrize|id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.DeletePrize(ctx, req.ID); err != nil {
+		log.Errorf("DeletePrize|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetCouponList 获取优惠券列表，prize_id为0时获取全部优惠券
+func (h *Handler) GetCouponList(c *gin.Context) {
+	req := PrizeIDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetCouponList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, dbNum, cacheNum, err := h.adminService.GetCouponList(ctx, req.PrizeID)
+	if err != nil {
+		log.Errorf("GetCouponList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &CouponListData{
+		List:     list,
+		DBNum:    dbNum,
+		CacheNum: cacheNum,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetCoupon 获取某个优惠券
+func (h *Handler) GetCoupon(c *gin.Context) {
+	req := IDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetCoupon|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.ID <= 0 {
+		log.Errorf("GetCoupon|id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	coupon, err := h.adminService.GetCoupon(ctx, req.ID)
+	if err != nil {
+		log.Errorf("GetCoupon|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = coupon
+	c.JSON(http.StatusOK, rsp)
+}
+
+// UpdateCoupon 修改优惠券
+func (h *Handler) UpdateCoupon(c *gin.Context) {
+	req := UpdateCouponReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("UpdateCoupon|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.Coupon == nil || req.Coupon.Id <= 0 {
+		log.Errorf("UpdateCoupon|input invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.UpdateCoupon(ctx, req.Coupon); err != nil {
+		log.Errorf("UpdateCoupon|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeleteCoupon 作废优惠券
+func (h *Handler) DeleteCoupon(c *gin.Context) {
+	req := IDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("DeleteCoupon|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.ID <= 0 {
+		log.Errorf("DeleteCoupon|id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.DeleteCoupon(ctx, req.ID); err != nil {
+		log.Errorf("DeleteCoupon|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ReCacheCoupon 根据db重置某个奖品的优惠券缓存
+func (h *Handler) ReCacheCoupon(c *gin.Context) {
+	req := PrizeIDReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("ReCacheCoupon|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.PrizeID <= 0 {
+		log.Errorf("ReCacheCoupon|prize_id invalid")
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	successNum, failNum, err := h.adminService.ReCacheCoupon(ctx, req.PrizeID)
+	if err != nil {
+		log.Errorf("ReCacheCoupon|err:%v", err)
 		rsp.Code = constant.ErrInternalServer
 		rsp.Msg = constant.GetErrMsg(rsp.Code)
 		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &ReCacheCouponData{
+		SuccessNum: successNum,
+		FailNum:    failNum,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetResultList 分页查询中奖记录
+func (h *Handler) GetResultList(c *gin.Context) {
+	req := GetResultListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetResultList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetResultList(ctx, &req.ResultFilter, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetResultList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetBlackUserList 分页查询用户黑名单
+func (h *Handler) GetBlackUserList(c *gin.Context) {
+	req := GetBlackUserListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetBlackUserList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetBlackUserList(ctx, &req.BlackUserFilter, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetBlackUserList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetBlackIpList 分页查询ip黑名单
+func (h *Handler) GetBlackIpList(c *gin.Context) {
+	req := GetBlackIpListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetBlackIpList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetBlackIpList(ctx, &req.BlackIpFilter, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetBlackIpList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// AddBlackIp 添加或修改ip黑名单，支持CIDR网段，到期时间早于当前时间相当于解除
+func (h *Handler) AddBlackIp(c *gin.Context) {
+	req := AddBlackIpReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("AddBlackIp|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.AddBlackIp(ctx, req.Ip, req.BlackTime); err != nil {
+		log.Errorf("AddBlackIp|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		if errors.Is(err, biz.ErrInvalidIp) {
+			rsp.Code = constant.ErrInputInvalid
+		}
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
 	}
 	c.JSON(http.StatusOK, rsp)
 }
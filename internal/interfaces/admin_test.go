@@ -7,12 +7,14 @@ import (
 	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"io"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 )
 
+// 接口需要登录，测试前先登录有管理员角色的账号，并通过环境变量 LOTTERY_TEST_TOKEN 传入token
 func TestAddPrizeList(t *testing.T) {
 	client := &http.Client{}
 	addPrize1 := biz.ViewPrize{
@@ -70,6 +72,7 @@ func TestAddPrizeList(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/add_prize_list", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
 	bodystr := string(body)
@@ -91,6 +94,7 @@ func TestClearPrize(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/clear_prize", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -131,6 +135,7 @@ func TestImportCoupon(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/import_coupon", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -171,6 +176,7 @@ func TestImportCouponWithCache(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/import_coupon_cache", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -192,6 +198,7 @@ func TestClearCoupon(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/clear_coupon", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -213,6 +220,7 @@ func TestClearLotteryTimes(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/clear_lottery_times", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -234,6 +242,7 @@ func TestClearResult(t *testing.T) {
 	t.Logf("req json = %s\n", string(bytesData))
 	req, _ := http.NewRequest("POST", "http://localhost:10080/admin/clear_result", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -256,6 +265,7 @@ func TestLotteryV1(t *testing.T) {
 	}
 	req, _ := http.NewRequest("POST", "http://localhost:10080/lottery/v1/get_lucky", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -278,6 +288,7 @@ func TestLotteryV2(t *testing.T) {
 	}
 	req, _ := http.NewRequest("POST", "http://localhost:10080/lottery/v2/get_lucky", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
@@ -300,6 +311,7 @@ func TestLotteryV3(t *testing.T) {
 	}
 	req, _ := http.NewRequest("POST", "http://localhost:10080/lottery/v3/get_lucky", bytes.NewReader(bytesData))
 	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(constant.Authorization, constant.BearerPrefix+os.Getenv("LOTTERY_TEST_TOKEN"))
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	body, _ := io.ReadAll(resp.Body)
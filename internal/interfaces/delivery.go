@@ -0,0 +1,70 @@
+package interfaces
+
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetDeliveryDeadList 分页查询虚拟奖品发放死信
+func (h *Handler) GetDeliveryDeadList(c *gin.Context) {
+	req := GetDeliveryDeadListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetDeliveryDeadList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetDeliveryDeadList(ctx, req.Status, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetDeliveryDeadList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ReplayDelivery 死信重新发放，返回不是待处理状态的死信ID
+func (h *Handler) ReplayDelivery(c *gin.Context) {
+	req := ReplayDeliveryReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("ReplayDelivery|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	failIds, err := h.adminService.ReplayDelivery(ctx, req.Ids)
+	if err != nil {
+		log.Errorf("ReplayDelivery|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &FulfillData{
+		SuccessNum: len(req.Ids) - len(failIds),
+		FailIds:    failIds,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
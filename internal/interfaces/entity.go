@@ -3,6 +3,7 @@ package interfaces
 import (
 	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"time"
 )
 
 // HttpResponse http独立请求返回结构体,这个通用的，不需要修改
@@ -13,39 +14,193 @@ type HttpResponse struct {
 	UserID uint32           `json:"user_id"`
 }
 
+// LotteryReq 抽奖请求，用户信息以登录token为准，IP以服务端确定的客户端IP为准，请求中的ip只做记录
 type LotteryReq struct {
-	UserID   uint   `json:"user_id"`
-	UserName string `json:"user_name"`
-	IP       string `json:"ip"`
+	UserID     uint   `json:"-"`
+	UserName   string `json:"-"`
+	IP         string `json:"-"`
+	ClaimedIP  string `json:"ip"`
+	ActivityID uint   `json:"activity_id"`
+	DeviceID   string `json:"device_id"`
 }
 
+// AddPrizeReq 后台请求的操作人以登录token为准
 type AddPrizeReq struct {
-	UserID uint           `json:"user_id"`
+	UserID uint           `json:"-"`
 	Prize  *biz.ViewPrize `json:"prize"`
 }
 
 type AddPrizeListReq struct {
-	UserID    uint             `json:"user_id"`
+	UserID    uint             `json:"-"`
 	PrizeList []*biz.ViewPrize `json:"prize_list"`
 }
 
 type ClearPrizeReq struct {
-	UserID uint `json:"user_id"`
+	UserID uint `json:"-"`
 }
 
 type ImportCouponReq struct {
-	UserID     uint                `json:"user_id"`
+	UserID     uint                `json:"-"`
 	CouponInfo *biz.ViewCouponInfo `json:"coupon"`
 }
 
 type ClearCouponReq struct {
-	UserID uint `json:"user_id"`
+	UserID uint `json:"-"`
 }
 
 type ClearLotteryTimesReq struct {
-	UserID uint `json:"user_id"`
+	UserID uint `json:"-"`
 }
 
 type ClearResultReq struct {
-	UserID uint `json:"user_id"`
+	UserID uint `json:"-"`
+}
+
+type AddActivityReq struct {
+	UserID   uint          `json:"-"`
+	Activity *biz.Activity `json:"activity"`
+}
+
+type UpdateActivityReq struct {
+	UserID   uint          `json:"-"`
+	Activity *biz.Activity `json:"activity"`
+}
+
+type LoginReq struct {
+	UserName string `json:"user_name"`
+	PassWord string `json:"pass_word"`
+}
+
+type GetPrizeListReq struct {
+	ActivityID uint `form:"activity_id" json:"activity_id"`
+}
+
+// GetMyWinsReq activity_id为空时查询所有活动
+type GetMyWinsReq struct {
+	ActivityID *uint `form:"activity_id" json:"activity_id"`
+	biz.PageQuery
+}
+
+// LotteryTimesData 用户今天的抽奖次数
+type LotteryTimesData struct {
+	DayMax    uint32 `json:"day_max"`
+	UsedNum   uint32 `json:"used_num"`
+	RemainNum uint32 `json:"remain_num"`
+}
+
+// IDReq 按id查询或删除奖品、优惠券
+type IDReq struct {
+	ID uint `form:"id" json:"id"`
+}
+
+// PreviewPrizePlanReq 预览发奖计划，profile为空时使用奖品配置的分布曲线
+type PreviewPrizePlanReq struct {
+	ID      uint   `form:"id" json:"id"`
+	Profile string `form:"profile" json:"profile"`
+}
+
+type UpdatePrizeReq struct {
+	Prize *biz.ViewPrize `json:"prize"`
+}
+
+type PrizeIDReq struct {
+	PrizeID uint `form:"prize_id" json:"prize_id"`
+}
+
+type UpdateCouponReq struct {
+	Coupon *biz.ViewCouponInfo `json:"coupon"`
+}
+
+type GetResultListReq struct {
+	biz.ResultFilter
+	biz.PageQuery
+}
+
+// ClaimPrizeReq 领取实物奖品，用户信息以登录token为准
+type ClaimPrizeReq struct {
+	biz.ClaimInfo
+}
+
+type ExportShipmentReq struct {
+	ActivityID *uint `form:"activity_id" json:"activity_id"`
+}
+
+type ShipResultReq struct {
+	List []*biz.ShipInfo `json:"list"`
+}
+
+type DeliverResultReq struct {
+	Ids []uint `json:"ids"`
+}
+
+type GetDeliveryDeadListReq struct {
+	Status *uint `form:"status" json:"status"`
+	biz.PageQuery
+}
+
+// ReconcilePrizePoolReq 不传活动ID时对账全部活动
+type ReconcilePrizePoolReq struct {
+	ActivityId *uint `form:"activity_id" json:"activity_id"`
+}
+
+type ReplayDeliveryReq struct {
+	Ids []uint `json:"ids"`
+}
+
+// GetTaskRunListReq 不传任务名称时查询全部任务的执行记录
+type GetTaskRunListReq struct {
+	Name string `form:"name" json:"name"`
+	biz.PageQuery
+}
+
+type TaskNameReq struct {
+	Name string `form:"name" json:"name"`
+}
+
+type GetBlackUserListReq struct {
+	biz.BlackUserFilter
+	biz.PageQuery
+}
+
+type GetBlackIpListReq struct {
+	biz.BlackIpFilter
+	biz.PageQuery
+}
+
+type GetRiskLogListReq struct {
+	biz.RiskLogFilter
+	biz.PageQuery
+}
+
+// AddBlackIpReq 添加ip黑名单，Ip为单个IP或者CIDR网段，BlackTime为限制到期时间
+type AddBlackIpReq struct {
+	Ip        string    `form:"ip" json:"ip"`
+	BlackTime time.Time `form:"black_time" time_format:"2006-01-02 15:04:05" json:"black_time"`
+}
+
+// CouponListData 优惠券列表，以及db和缓存中的可用优惠券数量，两者不一致时需要重置缓存
+type CouponListData struct {
+	List     []*biz.ViewCouponInfo `json:"list"`
+	DBNum    int64                 `json:"db_num"`
+	CacheNum int64                 `json:"cache_num"`
+}
+
+// ReCacheCouponData 重置优惠券缓存结果
+type ReCacheCouponData struct {
+	SuccessNum int64 `json:"success_num"`
+	FailNum    int64 `json:"fail_num"`
+}
+
+// FulfillData 批量更新履约状态结果
+type FulfillData struct {
+	SuccessNum int    `json:"success_num"`
+	FailIds    []uint `json:"fail_ids"`
+}
+
+// PageData 分页查询结果
+type PageData struct {
+	List     interface{} `json:"list"`
+	Total    int64       `json:"total"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
 }
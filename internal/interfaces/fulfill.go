@@ -0,0 +1,151 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// ClaimPrize 中奖用户填写收货信息领取实物奖品
+func (h *Handler) ClaimPrize(c *gin.Context) {
+	req := ClaimPrizeReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("ClaimPrize|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	uid := c.GetUint(constant.UserID)
+	rsp.UserID = uint32(uid)
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	if err := h.lotteryService.ClaimPrize(ctx, uid, &req.ClaimInfo); err != nil {
+		switch {
+		case errors.Is(err, biz.ErrClaimInfoInvalid):
+			rsp.Code = constant.ErrInputInvalid
+		case errors.Is(err, biz.ErrResultInvalid):
+			rsp.Code = constant.ErrResultInvalid
+		default:
+			rsp.Code = constant.ErrInternalServer
+		}
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ExportShipment 导出已领取待发货的实物奖品，csv格式
+func (h *Handler) ExportShipment(c *gin.Context) {
+	req := ExportShipmentReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("ExportShipment|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, err := h.adminService.GetShipmentList(ctx, req.ActivityID)
+	if err != nil {
+		log.Errorf("ExportShipment|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	var buf bytes.Buffer
+	// 带BOM，excel打开时中文不乱码
+	buf.WriteString("\xEF\xBB\xBF")
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"result_id", "activity_id", "prize_id", "prize_name", "user_id", "user_name",
+		"contact_name", "contact_phone", "address", "claim_time", "tracking_no"})
+	for _, result := range list {
+		claimTime := ""
+		if result.ClaimTime != nil {
+			claimTime = result.ClaimTime.Format(constant.SysTimeFormat)
+		}
+		w.Write([]string{fmt.Sprint(result.Id), fmt.Sprint(result.ActivityId), fmt.Sprint(result.PrizeId),
+			result.PrizeName, fmt.Sprint(result.UserId), result.UserName, result.ContactName,
+			result.ContactPhone, result.Address, claimTime, ""})
+	}
+	w.Flush()
+	fileName := fmt.Sprintf("shipment_%s.csv", time.Now().Format("20060102150405"))
+	c.Header("Content-Disposition", "attachment; filename="+fileName)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// ShipResult 批量录入物流单号，返回录入失败的中奖记录ID
+func (h *Handler) ShipResult(c *gin.Context) {
+	req := ShipResultReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("ShipResult|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	failIds, err := h.adminService.ShipResults(ctx, req.List)
+	if err != nil {
+		log.Errorf("ShipResult|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &FulfillData{
+		SuccessNum: len(req.List) - len(failIds),
+		FailIds:    failIds,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeliverResult 批量标记签收，返回标记失败的中奖记录ID
+func (h *Handler) DeliverResult(c *gin.Context) {
+	req := DeliverResultReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("DeliverResult|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	failIds, err := h.adminService.DeliverResults(ctx, req.Ids)
+	if err != nil {
+		log.Errorf("DeliverResult|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &FulfillData{
+		SuccessNum: len(req.Ids) - len(failIds),
+		FailIds:    failIds,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
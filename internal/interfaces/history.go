@@ -0,0 +1,116 @@
+package interfaces
+
+import (
+	"context"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetMyWins 分页查询当前用户的中奖纪录
+func (h *Handler) GetMyWins(c *gin.Context) {
+	req := GetMyWinsReq{}
+	rsp := HttpResponse{}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetMyWins|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	uid := c.GetUint(constant.UserID)
+	rsp.UserID = uint32(uid)
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	pbReq := &pb.GetMyWinsReq{
+		UserId:   uint32(uid),
+		Page:     int32(req.Page),
+		PageSize: int32(req.PageSize),
+	}
+	if req.ActivityID != nil {
+		activityID := uint32(*req.ActivityID)
+		pbReq.ActivityId = &activityID
+	}
+	pbRsp, err := h.lotteryService.GetMyWins(ctx, pbReq)
+	if err != nil {
+		log.ErrorContextf(ctx, "GetMyWins|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Code = constant.ErrCode(pbRsp.CommonRsp.Code)
+	rsp.Msg = pbRsp.CommonRsp.Msg
+	rsp.Data = &PageData{
+		List:     pbRsp.WinList,
+		Total:    pbRsp.Total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetMyLotteryTimes 查询当前用户今天剩余的抽奖次数
+func (h *Handler) GetMyLotteryTimes(c *gin.Context) {
+	req := GetPrizeListReq{}
+	rsp := HttpResponse{}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetMyLotteryTimes|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	uid := c.GetUint(constant.UserID)
+	rsp.UserID = uint32(uid)
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	pbRsp, err := h.lotteryService.GetMyLotteryTimes(ctx, &pb.GetMyLotteryTimesReq{
+		UserId:     uint32(uid),
+		ActivityId: uint32(req.ActivityID),
+	})
+	if err != nil {
+		log.ErrorContextf(ctx, "GetMyLotteryTimes|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Code = constant.ErrCode(pbRsp.CommonRsp.Code)
+	rsp.Msg = pbRsp.CommonRsp.Msg
+	rsp.Data = &LotteryTimesData{
+		DayMax:    pbRsp.DayMax,
+		UsedNum:   pbRsp.UsedNum,
+		RemainNum: pbRsp.RemainNum,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetRecentWinners 最近中奖的用户，不需要登录
+func (h *Handler) GetRecentWinners(c *gin.Context) {
+	req := GetPrizeListReq{}
+	rsp := HttpResponse{}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetRecentWinners|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	pbRsp, err := h.lotteryService.GetRecentWinners(ctx, &pb.GetRecentWinnersReq{
+		ActivityId: uint32(req.ActivityID),
+	})
+	if err != nil {
+		log.ErrorContextf(ctx, "GetRecentWinners|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Code = constant.ErrCode(pbRsp.CommonRsp.Code)
+	rsp.Msg = pbRsp.CommonRsp.Msg
+	rsp.Data = pbRsp.WinnerList
+	c.JSON(http.StatusOK, rsp)
+}
@@ -2,13 +2,12 @@ package interfaces
 
 import (
 	"context"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/log"
-	pb "github.com/BitofferHub/proto_center/api/lotterysvr/v1"
 	"github.com/gin-gonic/gin"
 	"net/http"
-	"strconv"
 )
 
 func (h *Handler) LotteryV3(c *gin.Context) {
@@ -22,11 +21,10 @@ func (h *Handler) LotteryV3(c *gin.Context) {
 		c.JSON(http.StatusOK, rsp)
 		return
 	}
-	userIDStr := c.Request.Header.Get(constant.UserID)
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		req.UserID = uint(userID)
-	}
+	// 用户信息从鉴权中间件解析出来的token中获取
+	req.UserID = c.GetUint(constant.UserID)
+	req.UserName = c.GetString(constant.UserName)
+	req.IP = c.GetString(constant.ClientIP)
 	h.lotteryV3(&req, &rsp)
 	c.JSON(http.StatusOK, rsp)
 }
@@ -34,9 +32,12 @@ func (h *Handler) LotteryV3(c *gin.Context) {
 func (h *Handler) lotteryV3(lotteryReq *LotteryReq, lotteryRsp *HttpResponse) {
 	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
 	req := &pb.LotteryReq{
-		UserId:   uint32(lotteryReq.UserID),
-		UserName: lotteryReq.UserName,
-		Ip:       lotteryReq.IP,
+		UserId:     uint32(lotteryReq.UserID),
+		UserName:   lotteryReq.UserName,
+		Ip:         lotteryReq.IP,
+		ClaimedIp:  lotteryReq.ClaimedIP,
+		ActivityId: uint32(lotteryReq.ActivityID),
+		DeviceId:   lotteryReq.DeviceID,
 	}
 	// 2. 验证用户今日抽奖次数
 	rsp, err := h.lotteryService.LotteryV3(ctx, req)
@@ -0,0 +1,124 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ClientIpMiddleware 根据连接地址和可信代理转发的请求头确定客户端IP，写入上下文
+func (h *Handler) ClientIpMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := h.ipResolver.Resolve(c.Request.RemoteAddr, c.Request.Header.Get(constant.ForwardedFor),
+			c.Request.Header.Get(constant.RealIP))
+		c.Set(constant.ClientIP, ip)
+		c.Next()
+	}
+}
+
+// AuthMiddleware 校验登录token，并将token中的用户信息写入上下文
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+		claims, err := h.userService.ParseToken(ctx, GetToken(c))
+		if err != nil {
+			log.InfoContextf(ctx, "AuthMiddleware|ParseToken:%v", err)
+			rsp := HttpResponse{
+				Code: constant.ErrJwtParse,
+				Msg:  constant.GetErrMsg(constant.ErrJwtParse),
+			}
+			c.AbortWithStatusJSON(http.StatusOK, rsp)
+			return
+		}
+		c.Set(constant.UserID, claims.UserID)
+		c.Set(constant.UserName, claims.UserName)
+		c.Next()
+	}
+}
+
+// GetToken 从请求头中获取token，支持 Authorization: Bearer <token>
+func GetToken(c *gin.Context) string {
+	token := c.Request.Header.Get(constant.Authorization)
+	return strings.TrimSpace(strings.TrimPrefix(token, constant.BearerPrefix))
+}
+
+// RoleMiddleware 校验登录用户是否拥有指定的后台角色，需要在AuthMiddleware之后使用
+func (h *Handler) RoleMiddleware(role int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := adminContext(c)
+		ok, err := h.userService.CheckRole(ctx, c.GetUint(constant.UserID), role)
+		if err != nil || !ok {
+			log.InfoContextf(ctx, "RoleMiddleware|user_id=%d,role=%d,err:%v", c.GetUint(constant.UserID), role, err)
+			rsp := HttpResponse{
+				Code: constant.ErrPermissionDenied,
+				Msg:  constant.GetErrMsg(constant.ErrPermissionDenied),
+			}
+			c.AbortWithStatusJSON(http.StatusOK, rsp)
+			return
+		}
+		c.Next()
+	}
+}
+
+// auditWriter 记录响应内容，用于审计日志
+type auditWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// AuditMiddleware 记录后台写操作的操作人、请求内容和处理结果，包括被拒绝的请求
+func (h *Handler) AuditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet {
+			c.Next()
+			return
+		}
+		var payload []byte
+		if c.Request.Body != nil {
+			payload, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewBuffer(payload))
+		}
+		writer := &auditWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		rsp := HttpResponse{}
+		if err := json.Unmarshal(writer.body.Bytes(), &rsp); err != nil {
+			rsp.Code = constant.ErrInternalServer
+			rsp.Msg = writer.body.String()
+		}
+		audit := &biz.AdminAudit{
+			UserId:     c.GetUint(constant.UserID),
+			UserName:   c.GetString(constant.UserName),
+			Operation:  c.FullPath(),
+			Payload:    string(payload),
+			ResultCode: int(rsp.Code),
+			ResultMsg:  rsp.Msg,
+			SysIp:      c.GetString(constant.ClientIP),
+		}
+		ctx := adminContext(c)
+		if err := h.adminService.AddAudit(ctx, audit); err != nil {
+			log.ErrorContextf(ctx, "AuditMiddleware|AddAudit err:%v", err)
+		}
+	}
+}
+
+// adminContext 生成后台请求的context，携带操作人IP
+func adminContext(c *gin.Context) context.Context {
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	ctx = context.WithValue(ctx, constant.UserID, c.GetUint(constant.UserID))
+	return context.WithValue(ctx, constant.SysIP, c.GetString(constant.ClientIP))
+}
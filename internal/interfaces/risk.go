@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetRiskLogList 分页查询风控记录，只包括需要验证和拒绝的抽奖
+func (h *Handler) GetRiskLogList(c *gin.Context) {
+	req := GetRiskLogListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetRiskLogList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetRiskLogList(ctx, &req.RiskLogFilter, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetRiskLogList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
@@ -3,7 +3,10 @@
 package interfaces
 
 import (
+	"expvar"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/BitofferHub/lotterysvr/internal/service"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	engine "github.com/BitofferHub/pkg/middlewares/gin"
 	"github.com/gin-gonic/gin"
 )
@@ -11,17 +14,24 @@ import (
 type Handler struct {
 	lotteryService *service.LotteryService
 	adminService   *service.AdminService
+	userService    *service.UserService
+	ipResolver     *utils.ClientIpResolver
 }
 
-func NewHandler(s *service.LotteryService, a *service.AdminService) *Handler {
+func NewHandler(s *service.LotteryService, a *service.AdminService, u *service.UserService,
+	resolver *utils.ClientIpResolver) *Handler {
 	return &Handler{
 		lotteryService: s,
 		adminService:   a,
+		userService:    u,
+		ipResolver:     resolver,
 	}
 }
 
 func NewRouter(h *Handler) *gin.Engine {
 	r := engine.NewEngine(engine.WithLogger(false))
+	// 客户端IP以连接地址和可信代理的请求头为准，不使用请求参数中的ip
+	r.Use(h.ClientIpMiddleware())
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -30,31 +40,120 @@ func NewRouter(h *Handler) *gin.Engine {
 	})
 
 	adminGroup := r.Group("admin")
+	// 后台写操作记录审计日志(包括未登录和无权限的请求)，后台接口需要登录，各接口按角色鉴权
+	adminGroup.Use(h.AuditMiddleware(), h.AuthMiddleware())
+	viewer := h.RoleMiddleware(constant.RoleViewer)
+	operator := h.RoleMiddleware(constant.RoleOperator)
+	admin := h.RoleMiddleware(constant.RoleAdmin)
 	// 获取奖品列表
-	//adminGroup.GET("/get_prize_list", handlers.GetPrizeList)
+	adminGroup.GET("/get_prize_list", viewer, h.GetPrizeList)
+	// 获取奖品
+	adminGroup.GET("/get_prize", viewer, h.GetPrize)
+	// 修改奖品
+	adminGroup.POST("/update_prize", operator, h.UpdatePrize)
+	// 删除奖品
+	adminGroup.POST("/delete_prize", operator, h.DeletePrize)
 	// 添加奖品
-	adminGroup.POST("/add_prize", h.AddPrize)
+	adminGroup.POST("/add_prize", operator, h.AddPrize)
 	// 添加奖品列表
-	adminGroup.POST("/add_prize_list", h.AddPrizeList)
+	adminGroup.POST("/add_prize_list", operator, h.AddPrizeList)
+	// 检查奖品配置
+	adminGroup.GET("/lint_prize", viewer, h.LintPrizes)
+	// 预览奖品的发奖计划，不保存
+	adminGroup.GET("/preview_prize_plan", viewer, h.PreviewPrizePlan)
+	// 奖品池对账
+	adminGroup.GET("/reconcile_prize_pool", viewer, h.ReconcilePrizePool)
 	// 清空奖品
-	adminGroup.POST("/clear_prize", h.ClearPrize)
+	adminGroup.POST("/clear_prize", admin, h.ClearPrize)
 	// 导入优惠券
-	adminGroup.POST("/import_coupon", h.ImportCoupon)
+	adminGroup.POST("/import_coupon", operator, h.ImportCoupon)
 	// 导入优惠券，同时导入缓存
-	adminGroup.POST("/import_coupon_cache", h.ImportCouponWithCache)
+	adminGroup.POST("/import_coupon_cache", operator, h.ImportCouponWithCache)
+	// 获取优惠券列表
+	adminGroup.GET("/get_coupon_list", viewer, h.GetCouponList)
+	// 获取优惠券
+	adminGroup.GET("/get_coupon", viewer, h.GetCoupon)
+	// 修改优惠券
+	adminGroup.POST("/update_coupon", operator, h.UpdateCoupon)
+	// 作废优惠券
+	adminGroup.POST("/delete_coupon", operator, h.DeleteCoupon)
+	// 根据db重置优惠券缓存
+	adminGroup.POST("/recache_coupon", operator, h.ReCacheCoupon)
 	// 清空优惠券
-	adminGroup.POST("/clear_coupon", h.ClearCoupon)
+	adminGroup.POST("/clear_coupon", admin, h.ClearCoupon)
 	// 清空用户抽奖次数
-	adminGroup.POST("/clear_lottery_times", h.ClearLotteryTimes)
+	adminGroup.POST("/clear_lottery_times", admin, h.ClearLotteryTimes)
 	// 清空获奖结果
-	adminGroup.POST("/clear_result", h.ClearResult)
+	adminGroup.POST("/clear_result", admin, h.ClearResult)
+	// 分页查询中奖记录
+	adminGroup.GET("/get_result_list", viewer, h.GetResultList)
+	// 分页查询用户黑名单
+	adminGroup.GET("/get_black_user_list", viewer, h.GetBlackUserList)
+	// 分页查询ip黑名单
+	adminGroup.GET("/get_black_ip_list", viewer, h.GetBlackIpList)
+	// 添加或修改ip黑名单，支持CIDR网段
+	adminGroup.POST("/add_black_ip", operator, h.AddBlackIp)
+	// 分页查询风控记录
+	adminGroup.GET("/get_risk_log_list", viewer, h.GetRiskLogList)
+	// 获取活动列表
+	adminGroup.GET("/get_activity_list", viewer, h.GetActivityList)
+	// 添加活动
+	adminGroup.POST("/add_activity", operator, h.AddActivity)
+	// 修改活动
+	adminGroup.POST("/update_activity", operator, h.UpdateActivity)
+	// 导出待发货的实物奖品
+	adminGroup.GET("/export_shipment", operator, h.ExportShipment)
+	// 批量录入物流单号
+	adminGroup.POST("/ship_result", operator, h.ShipResult)
+	// 批量标记签收
+	adminGroup.POST("/deliver_result", operator, h.DeliverResult)
+	// 分页查询虚拟奖品发放死信
+	adminGroup.GET("/get_delivery_dead_list", viewer, h.GetDeliveryDeadList)
+	// 死信重新发放
+	adminGroup.POST("/replay_delivery", operator, h.ReplayDelivery)
+	// 定时任务列表
+	adminGroup.GET("/get_task_list", viewer, h.GetTaskList)
+	// 分页查询定时任务执行记录
+	adminGroup.GET("/get_task_run_list", viewer, h.GetTaskRunList)
+	// 手动触发定时任务
+	adminGroup.POST("/trigger_task", operator, h.TriggerTask)
+	// 暂停定时任务
+	adminGroup.POST("/pause_task", operator, h.PauseTask)
+	// 恢复定时任务
+	adminGroup.POST("/resume_task", operator, h.ResumeTask)
+	// 运行统计，包括中奖纪录异步写入的队列长度和写入数量
+	adminGroup.GET("/debug_vars", viewer, gin.WrapH(expvar.Handler()))
+
+	userGroup := r.Group("user")
+	// 用户注册
+	userGroup.POST("/register", h.Register)
+	// 用户登录
+	userGroup.POST("/login", h.Login)
+	// 刷新token
+	userGroup.POST("/refresh", h.RefreshToken)
+	// 退出登录
+	userGroup.POST("/logout", h.Logout)
+
+	publicGroup := r.Group("lottery")
+	// 最近中奖的用户，不需要登录
+	publicGroup.GET("/recent_winners", h.GetRecentWinners)
+	// 奖品展示，支持ETag缓存
+	publicGroup.GET("/showcase", h.GetShowcase)
 
 	lotteryGroup := r.Group("lottery")
+	// 抽奖需要登录
+	lotteryGroup.Use(h.AuthMiddleware())
 	// V1基础版获取中奖
 	lotteryGroup.POST("/v1/get_lucky", h.LotteryV1)
 	// 优化V2版中奖逻辑
 	lotteryGroup.POST("/v2/get_lucky", h.LotteryV2)
 	// 优化V3版中奖逻辑
 	lotteryGroup.POST("/v3/get_lucky", h.LotteryV3)
+	// 领取实物奖品
+	lotteryGroup.POST("/claim_prize", h.ClaimPrize)
+	// 我的中奖纪录
+	lotteryGroup.GET("/my_wins", h.GetMyWins)
+	// 今天剩余的抽奖次数
+	lotteryGroup.GET("/my_lottery_times", h.GetMyLotteryTimes)
 	return r
 }
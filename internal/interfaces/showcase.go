@@ -0,0 +1,65 @@
+package interfaces
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetShowcase 活动奖品展示，不需要登录，内容没有变化时返回304
+func (h *Handler) GetShowcase(c *gin.Context) {
+	req := GetPrizeListReq{}
+	rsp := HttpResponse{}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetShowcase|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	pbRsp, err := h.lotteryService.GetShowcase(ctx, &pb.GetShowcaseReq{
+		ActivityId: uint32(req.ActivityID),
+	})
+	if err != nil {
+		log.ErrorContextf(ctx, "GetShowcase|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Code = constant.ErrCode(pbRsp.CommonRsp.Code)
+	rsp.Msg = pbRsp.CommonRsp.Msg
+	rsp.Data = pbRsp.PrizeList
+	body, err := json.Marshal(rsp)
+	if err != nil {
+		log.ErrorContextf(ctx, "GetShowcase|err:%v", err)
+		rsp.Code = constant.ErrJsonMarshal
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		rsp.Data = nil
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	writeWithETag(c, body, constant.ShowcaseMaxAge)
+}
+
+// writeWithETag 按响应内容生成ETag，和请求的If-None-Match相同时返回304
+func writeWithETag(c *gin.Context, body []byte, maxAge int) {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
@@ -0,0 +1,127 @@
+package interfaces
+
+import (
+	"errors"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetTaskList 定时任务列表，包括暂停状态、下一次执行时间和最近一次执行记录
+func (h *Handler) GetTaskList(c *gin.Context) {
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	ctx := adminContext(c)
+	list, err := h.adminService.GetTaskList(ctx)
+	if err != nil {
+		log.Errorf("GetTaskList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = list
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetTaskRunList 分页查询定时任务执行记录
+func (h *Handler) GetTaskRunList(c *gin.Context) {
+	req := GetTaskRunListReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Errorf("GetTaskRunList|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	list, total, err := h.adminService.GetTaskRunList(ctx, req.Name, &req.PageQuery)
+	if err != nil {
+		log.Errorf("GetTaskRunList|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = &PageData{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// TriggerTask 手动触发定时任务，任务在后台执行，结果在执行记录中查看
+func (h *Handler) TriggerTask(c *gin.Context) {
+	req := TaskNameReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("TriggerTask|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.TriggerTask(ctx, req.Name); err != nil {
+		log.Errorf("TriggerTask|err:%v", err)
+		setTaskErrorRsp(&rsp, err)
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// PauseTask 暂停定时任务，对所有实例生效
+func (h *Handler) PauseTask(c *gin.Context) {
+	h.setTaskPaused(c, true)
+}
+
+// ResumeTask 恢复定时任务
+func (h *Handler) ResumeTask(c *gin.Context) {
+	h.setTaskPaused(c, false)
+}
+
+func (h *Handler) setTaskPaused(c *gin.Context, paused bool) {
+	req := TaskNameReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("PauseTask|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := adminContext(c)
+	if err := h.adminService.PauseTask(ctx, req.Name, paused); err != nil {
+		log.Errorf("PauseTask|paused=%v err:%v", paused, err)
+		setTaskErrorRsp(&rsp, err)
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// setTaskErrorRsp 任务不存在和正在执行返回对应的错误码，其他错误包括服务正在退出返回内部错误
+func setTaskErrorRsp(rsp *HttpResponse, err error) {
+	switch {
+	case errors.Is(err, biz.ErrTaskNotFound):
+		rsp.Code = constant.ErrTaskNotFound
+	case errors.Is(err, biz.ErrTaskRunning):
+		rsp.Code = constant.ErrTaskRunning
+	default:
+		rsp.Code = constant.ErrInternalServer
+	}
+	rsp.Msg = constant.GetErrMsg(rsp.Code)
+}
@@ -0,0 +1,115 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Register 用户注册
+func (h *Handler) Register(c *gin.Context) {
+	req := LoginReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("Register|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if req.UserName == "" || req.PassWord == "" {
+		rsp.Code = constant.ErrInputInvalid
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	loginRsp, err := h.userService.Register(ctx, req.UserName, req.PassWord)
+	if err != nil {
+		log.Errorf("Register|err:%v", err)
+		rsp.Code = constant.ErrRegister
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = loginRsp
+	rsp.UserID = uint32(loginRsp.UserID)
+	c.JSON(http.StatusOK, rsp)
+}
+
+// Login 用户登录
+func (h *Handler) Login(c *gin.Context) {
+	req := LoginReq{}
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		log.Errorf("Login|Error binding:%v", err)
+		rsp.Code = constant.ErrShouldBind
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	loginRsp, err := h.userService.Login(ctx, req.UserName, req.PassWord)
+	if err != nil {
+		log.Errorf("Login|err:%v", err)
+		rsp.Code = constant.ErrInternalServer
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	if loginRsp == nil {
+		rsp.Code = constant.ErrLogin
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = loginRsp
+	rsp.UserID = uint32(loginRsp.UserID)
+	c.JSON(http.StatusOK, rsp)
+}
+
+// RefreshToken 刷新token，旧token失效
+func (h *Handler) RefreshToken(c *gin.Context) {
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	loginRsp, err := h.userService.Refresh(ctx, GetToken(c))
+	if err != nil {
+		log.Errorf("RefreshToken|err:%v", err)
+		rsp.Code = constant.ErrJwtParse
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	rsp.Data = loginRsp
+	rsp.UserID = uint32(loginRsp.UserID)
+	c.JSON(http.StatusOK, rsp)
+}
+
+// Logout 退出登录，吊销token
+func (h *Handler) Logout(c *gin.Context) {
+	rsp := HttpResponse{
+		Code: constant.Success,
+		Msg:  constant.GetErrMsg(constant.Success),
+	}
+	ctx := context.WithValue(context.Background(), constant.ReqID, utils.NewUuid())
+	if err := h.userService.Logout(ctx, GetToken(c)); err != nil {
+		log.Errorf("Logout|err:%v", err)
+		rsp.Code = constant.ErrJwtParse
+		rsp.Msg = constant.GetErrMsg(rsp.Code)
+		c.JSON(http.StatusOK, rsp)
+		return
+	}
+	c.JSON(http.StatusOK, rsp)
+}
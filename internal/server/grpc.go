@@ -1,11 +1,13 @@
 package server
 
 import (
+	v1 "github.com/BitofferHub/lotterysvr/api/lottery/v1"
 	"github.com/BitofferHub/lotterysvr/internal/conf"
 	"github.com/BitofferHub/lotterysvr/internal/service"
-	v1 "github.com/BitofferHub/proto_center/api/lotterysvr/v1"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	mmd "github.com/go-kratos/kratos/v2/middleware/metadata"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
@@ -15,14 +17,22 @@ import (
 //	@Description: NewGRPCServer new a gRPC server.
 //	@param c
 //	@param greeter
+//	@param admin
+//	@param user
+//	@param adminService
+//	@param resolver
 //	@return *grpc.Server
-func NewGRPCServer(c *conf.Server, greeter *service.LotteryService) *grpc.Server {
+func NewGRPCServer(c *conf.Server, greeter *service.LotteryService, admin *service.LotteryAdminService,
+	user *service.UserService, adminService *service.AdminService, resolver *utils.ClientIpResolver) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
 			mmd.Server(),
 			MiddlewareTraceID(),
 			MiddlewareLog(),
+			MiddlewareClientIp(resolver),
+			selector.Server(MiddlewareAuth(user)).Prefix("/"+v1.Lottery_ServiceDesc.ServiceName+"/").Build(),
+			selector.Server(MiddlewareAdmin(user, adminService, resolver)).Prefix("/"+v1.LotteryAdmin_ServiceDesc.ServiceName+"/").Build(),
 		),
 	}
 	if c.Grpc.Network != "" {
@@ -36,5 +46,6 @@ func NewGRPCServer(c *conf.Server, greeter *service.LotteryService) *grpc.Server
 	}
 	srv := grpc.NewServer(opts...)
 	v1.RegisterLotteryServer(srv, greeter)
+	v1.RegisterLotteryAdminServer(srv, admin)
 	return srv
 }
@@ -2,13 +2,24 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	v1 "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	lconstant "github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/service"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/constant"
 	"github.com/BitofferHub/pkg/middlewares/log"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/metadata"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"net"
+	"strings"
 	"time"
 )
 
@@ -69,3 +80,178 @@ func MiddlewareLog() middleware.Middleware {
 		}
 	}
 }
+
+// MiddlewareClientIp
+//
+//	@Description: kratos middleware for client ip, 抽奖请求的ip以连接地址和可信代理的请求头为准，请求中的ip记为声明的ip
+//	@param resolver
+//	@return middleware.Middleware
+func MiddlewareClientIp(resolver *utils.ClientIpResolver) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			if r, ok := req.(*v1.LotteryReq); ok {
+				r.ClaimedIp = r.Ip
+				r.Ip = clientIp(ctx, resolver)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// MiddlewareAuth
+//
+//	@Description: kratos middleware for jwt auth, 用户信息以token解析结果为准
+//	@param us
+//	@return middleware.Middleware
+func MiddlewareAuth(us *service.UserService) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			token := headerToken(ctx)
+			switch r := req.(type) {
+			case *v1.LotteryReq:
+				if token == "" {
+					token = r.Token
+				}
+			case *v1.GetMyWinsReq, *v1.GetMyLotteryTimesReq:
+			default:
+				// 最近中奖用户等公开接口不需要登录
+				return handler(ctx, req)
+			}
+			claims, err := us.ParseToken(ctx, token)
+			if err != nil {
+				log.ErrorContextf(ctx, "MiddlewareAuth|ParseToken err:%v", err)
+				return nil, errors.Unauthorized("UNAUTHORIZED", "invalid token")
+			}
+			switch r := req.(type) {
+			case *v1.LotteryReq:
+				r.UserId = uint32(claims.UserID)
+				r.UserName = claims.UserName
+			case *v1.GetMyWinsReq:
+				r.UserId = uint32(claims.UserID)
+			case *v1.GetMyLotteryTimesReq:
+				r.UserId = uint32(claims.UserID)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// adminOperationRoles 后台gRPC接口需要的最低角色
+var adminOperationRoles = map[string]int{
+	v1.OperationLotteryAdminAddPrize:              lconstant.RoleOperator,
+	v1.OperationLotteryAdminAddPrizeList:          lconstant.RoleOperator,
+	v1.OperationLotteryAdminClearPrize:            lconstant.RoleAdmin,
+	v1.OperationLotteryAdminImportCoupon:          lconstant.RoleOperator,
+	v1.OperationLotteryAdminImportCouponWithCache: lconstant.RoleOperator,
+	v1.OperationLotteryAdminClearCoupon:           lconstant.RoleAdmin,
+	v1.OperationLotteryAdminClearLotteryTimes:     lconstant.RoleAdmin,
+	v1.OperationLotteryAdminClearResult:           lconstant.RoleAdmin,
+	v1.OperationLotteryAdminGetActivityList:       lconstant.RoleViewer,
+	v1.OperationLotteryAdminAddActivity:           lconstant.RoleOperator,
+	v1.OperationLotteryAdminUpdateActivity:        lconstant.RoleOperator,
+}
+
+// MiddlewareAdmin
+//
+//	@Description: kratos middleware for admin api, 校验token和角色，请求中的user_id以token为准，写操作记录审计日志
+//	@param us
+//	@param as
+//	@param resolver
+//	@return middleware.Middleware
+func MiddlewareAdmin(us *service.UserService, as *service.AdminService,
+	resolver *utils.ClientIpResolver) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			var operation string
+			if info, ok := transport.FromServerContext(ctx); ok {
+				operation = info.Operation()
+			}
+			sysIP := clientIp(ctx, resolver)
+			ctx = context.WithValue(ctx, lconstant.SysIP, sysIP)
+			audit := &biz.AdminAudit{
+				Operation: operation,
+				SysIp:     sysIP,
+			}
+			if payload, err := json.Marshal(req); err == nil {
+				audit.Payload = string(payload)
+			}
+			// 查询接口不记录审计日志
+			if operation != v1.OperationLotteryAdminGetActivityList {
+				defer func() {
+					if err != nil {
+						audit.ResultCode = int(service.ErrInternalServer)
+						audit.ResultMsg = err.Error()
+					} else if r, ok := reply.(interface{ GetCommonRsp() *v1.CommonRspInfo }); ok {
+						audit.ResultCode = int(r.GetCommonRsp().GetCode())
+						audit.ResultMsg = r.GetCommonRsp().GetMsg()
+					}
+					if e := as.AddAudit(ctx, audit); e != nil {
+						log.ErrorContextf(ctx, "MiddlewareAdmin|AddAudit err:%v", e)
+					}
+				}()
+			}
+
+			claims, err := us.ParseToken(ctx, headerToken(ctx))
+			if err != nil {
+				log.ErrorContextf(ctx, "MiddlewareAdmin|ParseToken err:%v", err)
+				return nil, errors.Unauthorized("UNAUTHORIZED", "invalid token")
+			}
+			audit.UserId = claims.UserID
+			audit.UserName = claims.UserName
+			role, ok := adminOperationRoles[operation]
+			if !ok {
+				role = lconstant.RoleAdmin
+			}
+			allowed, err := us.CheckRole(ctx, claims.UserID, role)
+			if err != nil || !allowed {
+				log.ErrorContextf(ctx, "MiddlewareAdmin|user_id=%d,operation=%s permission denied, err:%v",
+					claims.UserID, operation, err)
+				return nil, errors.Forbidden("PERMISSION_DENIED", "permission denied")
+			}
+			ctx = context.WithValue(ctx, lconstant.UserID, claims.UserID)
+			if m, ok := req.(proto.Message); ok {
+				field := m.ProtoReflect().Descriptor().Fields().ByName("user_id")
+				if field != nil {
+					m.ProtoReflect().Set(field, protoreflect.ValueOfUint32(uint32(claims.UserID)))
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// headerToken 从请求头中获取token，支持 Authorization: Bearer <token>
+func headerToken(ctx context.Context) string {
+	info, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return ""
+	}
+	auth := info.RequestHeader().Get(lconstant.Authorization)
+	if !strings.HasPrefix(auth, lconstant.BearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(auth, lconstant.BearerPrefix))
+}
+
+// clientIp 根据连接地址和可信代理转发的请求头确定客户端IP
+func clientIp(ctx context.Context, resolver *utils.ClientIpResolver) string {
+	var forwardedFor, realIp string
+	if info, ok := transport.FromServerContext(ctx); ok {
+		forwardedFor = info.RequestHeader().Get(lconstant.ForwardedFor)
+		realIp = info.RequestHeader().Get(lconstant.RealIP)
+	}
+	return resolver.Resolve(peerIP(ctx), forwardedFor, realIp)
+}
+
+// peerIP 获取gRPC调用方IP
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
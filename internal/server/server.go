@@ -1,8 +1,15 @@
 package server
 
 import (
+	"github.com/BitofferHub/lotterysvr/internal/conf"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/google/wire"
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewClientIpResolver)
+
+// NewClientIpResolver 按配置的可信代理确定客户端IP，gin和gRPC共用
+func NewClientIpResolver(c *conf.Server) (*utils.ClientIpResolver, error) {
+	return utils.NewClientIpResolver(c.GetTrustedProxies())
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
 )
 
 // AddPrize 添加奖品
 func (a *AdminService) AddPrize(ctx context.Context, viewPrize *biz.ViewPrize) error {
 	if err := a.adminCase.AddPrize(ctx, viewPrize); err != nil {
 		log.ErrorContextf(ctx, "adminService|AddPrize err:%v", err)
-		return fmt.Errorf("adminService|AddPrize:%v", err)
+		return fmt.Errorf("adminService|AddPrize:%w", err)
 	}
 	return nil
 }
@@ -20,7 +21,7 @@ func (a *AdminService) AddPrize(ctx context.Context, viewPrize *biz.ViewPrize) e
 func (a *AdminService) AddPrizeList(ctx context.Context, viewPrizeList []*biz.ViewPrize) error {
 	if err := a.adminCase.AddPrizeList(ctx, viewPrizeList); err != nil {
 		log.ErrorContextf(ctx, "adminService|AddPrizeList err:%v", err)
-		return fmt.Errorf("adminService|AddPrizeList:%v", err)
+		return fmt.Errorf("adminService|AddPrizeList:%w", err)
 	}
 	return nil
 }
@@ -75,3 +76,196 @@ func (a *AdminService) ClearResult(ctx context.Context) error {
 	}
 	return nil
 }
+
+// GetActivityList 获取活动列表
+func (a *AdminService) GetActivityList(ctx context.Context) ([]*biz.Activity, error) {
+	list, err := a.adminCase.GetActivityList(ctx)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetActivityList err:%v", err)
+		return nil, fmt.Errorf("adminService|GetActivityList:%v", err)
+	}
+	return list, nil
+}
+
+// AddActivity 添加活动
+func (a *AdminService) AddActivity(ctx context.Context, activity *biz.Activity) error {
+	if err := a.adminCase.AddActivity(ctx, activity); err != nil {
+		log.ErrorContextf(ctx, "adminService|AddActivity err:%v", err)
+		return fmt.Errorf("adminService|AddActivity:%v", err)
+	}
+	return nil
+}
+
+// UpdateActivity 修改活动
+func (a *AdminService) UpdateActivity(ctx context.Context, activity *biz.Activity) error {
+	if err := a.adminCase.UpdateActivity(ctx, activity); err != nil {
+		log.ErrorContextf(ctx, "adminService|UpdateActivity err:%v", err)
+		return fmt.Errorf("adminService|UpdateActivity:%v", err)
+	}
+	return nil
+}
+
+// AddAudit 记录后台操作审计日志
+func (a *AdminService) AddAudit(ctx context.Context, audit *biz.AdminAudit) error {
+	if err := a.adminCase.AddAudit(ctx, audit); err != nil {
+		log.ErrorContextf(ctx, "adminService|AddAudit err:%v", err)
+		return fmt.Errorf("adminService|AddAudit:%v", err)
+	}
+	return nil
+}
+
+// GetPrizeList 获取某个活动的奖品列表
+func (a *AdminService) GetPrizeList(ctx context.Context, activityID uint) ([]*biz.ViewPrize, error) {
+	list, err := a.adminCase.GetViewPrizeList(ctx, activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetPrizeList err:%v", err)
+		return nil, fmt.Errorf("adminService|GetPrizeList:%v", err)
+	}
+	return list, nil
+}
+
+// GetPrize 获取某个奖品
+func (a *AdminService) GetPrize(ctx context.Context, id uint) (*biz.ViewPrize, error) {
+	prize, err := a.adminCase.GetPrize(ctx, id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetPrize err:%v", err)
+		return nil, fmt.Errorf("adminService|GetPrize:%v", err)
+	}
+	return prize, nil
+}
+
+// UpdatePrize 修改奖品，数量或者发奖周期变化时重置发奖计划
+func (a *AdminService) UpdatePrize(ctx context.Context, viewPrize *biz.ViewPrize) error {
+	if err := a.adminCase.UpdatePrizeWithPool(ctx, viewPrize); err != nil {
+		log.ErrorContextf(ctx, "adminService|UpdatePrize err:%v", err)
+		return fmt.Errorf("adminService|UpdatePrize:%w", err)
+	}
+	return nil
+}
+
+// DeletePrize 软删除奖品
+func (a *AdminService) DeletePrize(ctx context.Context, id uint) error {
+	if err := a.adminCase.DeletePrize(ctx, id); err != nil {
+		log.ErrorContextf(ctx, "adminService|DeletePrize err:%v", err)
+		return fmt.Errorf("adminService|DeletePrize:%v", err)
+	}
+	return nil
+}
+
+// LintPrizes 检查全部有效奖品的配置问题
+func (a *AdminService) LintPrizes(ctx context.Context) ([]*biz.PrizeIssue, error) {
+	issues, err := a.adminCase.LintPrizes(ctx)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|LintPrizes err:%v", err)
+		return nil, fmt.Errorf("adminService|LintPrizes:%v", err)
+	}
+	return issues, nil
+}
+
+// PreviewPrizePlan 预览奖品的发奖计划，不保存
+func (a *AdminService) PreviewPrizePlan(ctx context.Context, id uint, profile string) (*biz.PrizePlanPreview, error) {
+	preview, err := a.adminCase.PreviewPrizePlan(ctx, id, profile)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|PreviewPrizePlan err:%v", err)
+		return nil, fmt.Errorf("adminService|PreviewPrizePlan:%w", err)
+	}
+	return preview, nil
+}
+
+// ReconcilePrizePool 奖品池对账
+func (a *AdminService) ReconcilePrizePool(ctx context.Context, activityID *uint) ([]*biz.PrizeReconcile, error) {
+	list, err := a.adminCase.ReconcilePrizePool(ctx, activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|ReconcilePrizePool err:%v", err)
+		return nil, fmt.Errorf("adminService|ReconcilePrizePool:%v", err)
+	}
+	return list, nil
+}
+
+// GetCouponList 获取优惠券列表，以及db和缓存中的可用优惠券数量
+func (a *AdminService) GetCouponList(ctx context.Context, prizeID uint) ([]*biz.ViewCouponInfo, int64, int64, error) {
+	list, dbNum, cacheNum, err := a.adminCase.GetCouponList(ctx, prizeID)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetCouponList err:%v", err)
+		return nil, 0, 0, fmt.Errorf("adminService|GetCouponList:%v", err)
+	}
+	return list, dbNum, cacheNum, nil
+}
+
+// GetCoupon 获取某个优惠券
+func (a *AdminService) GetCoupon(ctx context.Context, id uint) (*biz.ViewCouponInfo, error) {
+	coupon, err := a.adminCase.GetCoupon(ctx, id)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetCoupon err:%v", err)
+		return nil, fmt.Errorf("adminService|GetCoupon:%v", err)
+	}
+	return coupon, nil
+}
+
+// UpdateCoupon 修改优惠券
+func (a *AdminService) UpdateCoupon(ctx context.Context, viewCoupon *biz.ViewCouponInfo) error {
+	if err := a.adminCase.UpdateCoupon(ctx, viewCoupon); err != nil {
+		log.ErrorContextf(ctx, "adminService|UpdateCoupon err:%v", err)
+		return fmt.Errorf("adminService|UpdateCoupon:%v", err)
+	}
+	return nil
+}
+
+// DeleteCoupon 作废优惠券
+func (a *AdminService) DeleteCoupon(ctx context.Context, id uint) error {
+	if err := a.adminCase.DeleteCoupon(ctx, id); err != nil {
+		log.ErrorContextf(ctx, "adminService|DeleteCoupon err:%v", err)
+		return fmt.Errorf("adminService|DeleteCoupon:%v", err)
+	}
+	return nil
+}
+
+// ReCacheCoupon 根据db重置某个奖品的优惠券缓存
+func (a *AdminService) ReCacheCoupon(ctx context.Context, prizeID uint) (int64, int64, error) {
+	successNum, failNum, err := a.adminCase.ReCacheCoupon(ctx, prizeID)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|ReCacheCoupon err:%v", err)
+		return 0, 0, fmt.Errorf("adminService|ReCacheCoupon:%v", err)
+	}
+	log.InfoContextf(ctx, "ReCacheCoupon|prize_id=%d|successNum=%d|failNum=%d", prizeID, successNum, failNum)
+	return successNum, failNum, nil
+}
+
+// GetResultList 分页查询中奖记录
+func (a *AdminService) GetResultList(ctx context.Context, filter *biz.ResultFilter, page *biz.PageQuery) ([]*biz.Result, int64, error) {
+	list, total, err := a.adminCase.GetResultList(ctx, filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetResultList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetResultList:%v", err)
+	}
+	return list, total, nil
+}
+
+// GetBlackUserList 分页查询用户黑名单
+func (a *AdminService) GetBlackUserList(ctx context.Context, filter *biz.BlackUserFilter, page *biz.PageQuery) ([]*biz.BlackUser, int64, error) {
+	list, total, err := a.adminCase.GetBlackUserList(ctx, filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetBlackUserList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetBlackUserList:%v", err)
+	}
+	return list, total, nil
+}
+
+// GetBlackIpList 分页查询ip黑名单
+func (a *AdminService) GetBlackIpList(ctx context.Context, filter *biz.BlackIpFilter, page *biz.PageQuery) ([]*biz.BlackIp, int64, error) {
+	list, total, err := a.adminCase.GetBlackIpList(ctx, filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetBlackIpList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetBlackIpList:%v", err)
+	}
+	return list, total, nil
+}
+
+// AddBlackIp 添加或修改ip黑名单
+func (a *AdminService) AddBlackIp(ctx context.Context, ip string, blackTime time.Time) error {
+	if err := a.adminCase.AddBlackIp(ctx, ip, blackTime); err != nil {
+		log.ErrorContextf(ctx, "adminService|AddBlackIp err:%v", err)
+		return fmt.Errorf("adminService|AddBlackIp:%w", err)
+	}
+	return nil
+}
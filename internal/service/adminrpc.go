@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+	"time"
+)
+
+// LotteryAdminService 奖品管理后台的gRPC接口，逻辑复用AdminService
+type LotteryAdminService struct {
+	pb.UnimplementedLotteryAdminServer
+	adminService *AdminService
+}
+
+func NewLotteryAdminService(as *AdminService) *LotteryAdminService {
+	return &LotteryAdminService{
+		adminService: as,
+	}
+}
+
+// AddPrize 添加奖品
+func (a *LotteryAdminService) AddPrize(ctx context.Context, req *pb.AddPrizeReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 || req.Prize == nil {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	viewPrize, err := toBizViewPrize(req.Prize)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryAdminService|AddPrize|toBizViewPrize:%v", err)
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err = a.adminService.AddPrize(ctx, viewPrize); err != nil {
+		return setAdminRspPrizeErr(rsp, err), nil
+	}
+	return rsp, nil
+}
+
+// AddPrizeList 添加奖品列表
+func (a *LotteryAdminService) AddPrizeList(ctx context.Context, req *pb.AddPrizeListReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	viewPrizeList := make([]*biz.ViewPrize, 0, len(req.PrizeList))
+	for _, prize := range req.PrizeList {
+		viewPrize, err := toBizViewPrize(prize)
+		if err != nil {
+			log.ErrorContextf(ctx, "LotteryAdminService|AddPrizeList|toBizViewPrize:%v", err)
+			return setAdminRspCode(rsp, ErrInputInvalid), nil
+		}
+		viewPrizeList = append(viewPrizeList, viewPrize)
+	}
+	if err := a.adminService.AddPrizeList(ctx, viewPrizeList); err != nil {
+		return setAdminRspPrizeErr(rsp, err), nil
+	}
+	return rsp, nil
+}
+
+// ClearPrize 清空奖品
+func (a *LotteryAdminService) ClearPrize(ctx context.Context, req *pb.AdminClearReq) (*pb.AdminRsp, error) {
+	return a.clear(ctx, req, a.adminService.ClearPrize)
+}
+
+// ImportCoupon 导入优惠券
+func (a *LotteryAdminService) ImportCoupon(ctx context.Context, req *pb.ImportCouponReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 || req.PrizeId <= 0 {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err := a.adminService.ImportCoupon(ctx, uint(req.PrizeId), req.Codes); err != nil {
+		log.ErrorContextf(ctx, "LotteryAdminService|ImportCoupon:%v", err)
+		return setAdminRspCode(rsp, ErrInternalServer), nil
+	}
+	return rsp, nil
+}
+
+// ImportCouponWithCache 导入优惠券，同时导入缓存
+func (a *LotteryAdminService) ImportCouponWithCache(ctx context.Context, req *pb.ImportCouponReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 || req.PrizeId <= 0 {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err := a.adminService.ImportCouponWithCache(ctx, uint(req.PrizeId), req.Codes); err != nil {
+		log.ErrorContextf(ctx, "LotteryAdminService|ImportCouponWithCache:%v", err)
+		return setAdminRspCode(rsp, ErrInternalServer), nil
+	}
+	return rsp, nil
+}
+
+// ClearCoupon 清空优惠券
+func (a *LotteryAdminService) ClearCoupon(ctx context.Context, req *pb.AdminClearReq) (*pb.AdminRsp, error) {
+	return a.clear(ctx, req, a.adminService.ClearCoupon)
+}
+
+// ClearLotteryTimes 清空用户抽奖次数
+func (a *LotteryAdminService) ClearLotteryTimes(ctx context.Context, req *pb.AdminClearReq) (*pb.AdminRsp, error) {
+	return a.clear(ctx, req, a.adminService.ClearLotteryTimes)
+}
+
+// ClearResult 清空获奖结果
+func (a *LotteryAdminService) ClearResult(ctx context.Context, req *pb.AdminClearReq) (*pb.AdminRsp, error) {
+	return a.clear(ctx, req, a.adminService.ClearResult)
+}
+
+// GetActivityList 获取活动列表
+func (a *LotteryAdminService) GetActivityList(ctx context.Context, req *pb.GetActivityListReq) (*pb.GetActivityListRsp, error) {
+	rsp := &pb.GetActivityListRsp{
+		CommonRsp: newAdminRsp(req.UserId).CommonRsp,
+	}
+	list, err := a.adminService.GetActivityList(ctx)
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		rsp.CommonRsp.Msg = GetErrMsg(ErrInternalServer)
+		return rsp, nil
+	}
+	for _, activity := range list {
+		rsp.ActivityList = append(rsp.ActivityList, toPbActivityInfo(activity))
+	}
+	return rsp, nil
+}
+
+// AddActivity 添加活动
+func (a *LotteryAdminService) AddActivity(ctx context.Context, req *pb.ActivityReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 || req.Activity == nil {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	activity, err := toBizActivity(req.Activity)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryAdminService|AddActivity|toBizActivity:%v", err)
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err = a.adminService.AddActivity(ctx, activity); err != nil {
+		return setAdminRspCode(rsp, ErrInternalServer), nil
+	}
+	return rsp, nil
+}
+
+// UpdateActivity 修改活动
+func (a *LotteryAdminService) UpdateActivity(ctx context.Context, req *pb.ActivityReq) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 || req.Activity == nil || req.Activity.Id <= 0 {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	activity, err := toBizActivity(req.Activity)
+	if err != nil {
+		log.ErrorContextf(ctx, "LotteryAdminService|UpdateActivity|toBizActivity:%v", err)
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err = a.adminService.UpdateActivity(ctx, activity); err != nil {
+		return setAdminRspCode(rsp, ErrInternalServer), nil
+	}
+	return rsp, nil
+}
+
+func (a *LotteryAdminService) clear(ctx context.Context, req *pb.AdminClearReq,
+	fn func(ctx context.Context) error) (*pb.AdminRsp, error) {
+	rsp := newAdminRsp(req.UserId)
+	if req.UserId <= 0 {
+		return setAdminRspCode(rsp, ErrInputInvalid), nil
+	}
+	if err := fn(ctx); err != nil {
+		return setAdminRspCode(rsp, ErrInternalServer), nil
+	}
+	return rsp, nil
+}
+
+func newAdminRsp(userID uint32) *pb.AdminRsp {
+	return &pb.AdminRsp{
+		CommonRsp: &pb.CommonRspInfo{
+			Code:   int32(Success),
+			Msg:    GetErrMsg(Success),
+			UserId: userID,
+		},
+	}
+}
+
+func setAdminRspCode(rsp *pb.AdminRsp, code ErrCode) *pb.AdminRsp {
+	rsp.CommonRsp.Code = int32(code)
+	rsp.CommonRsp.Msg = GetErrMsg(code)
+	return rsp
+}
+
+// setAdminRspPrizeErr 奖品配置校验不通过时返回ErrPrizeInvalid，错误描述带上具体问题
+func setAdminRspPrizeErr(rsp *pb.AdminRsp, err error) *pb.AdminRsp {
+	var validationErr *biz.PrizeValidationError
+	if !errors.As(err, &validationErr) {
+		return setAdminRspCode(rsp, ErrInternalServer)
+	}
+	setAdminRspCode(rsp, ErrPrizeInvalid)
+	rsp.CommonRsp.Msg = validationErr.Error()
+	return rsp
+}
+
+// parseOptionalTime 解析 2006-01-02 15:04:05 格式的时间，空字符串返回零值
+func parseOptionalTime(str string) (time.Time, error) {
+	if str == "" {
+		return time.Time{}, nil
+	}
+	return utils.ParseTime(str)
+}
+
+func toBizViewPrize(prize *pb.ViewPrize) (*biz.ViewPrize, error) {
+	beginTime, err := parseOptionalTime(prize.BeginTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := parseOptionalTime(prize.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.ViewPrize{
+		Id:           uint(prize.Id),
+		ActivityId:   uint(prize.ActivityId),
+		Title:        prize.Title,
+		Img:          prize.Img,
+		PrizeNum:     int(prize.PrizeNum),
+		PrizeCode:    prize.PrizeCode,
+		Weight:       uint(prize.Weight),
+		UserWinMax:   uint(prize.UserWinMax),
+		PrizeTime:    uint(prize.PrizeTime),
+		LeftNum:      int(prize.LeftNum),
+		PrizeType:    uint(prize.PrizeType),
+		PlanProfile:  prize.PlanProfile,
+		BeginTime:    beginTime,
+		EndTime:      endTime,
+		DisplayOrder: uint(prize.DisplayOrder),
+		SysStatus:    uint(prize.SysStatus),
+	}, nil
+}
+
+func toBizActivity(activity *pb.ActivityInfo) (*biz.Activity, error) {
+	beginTime, err := parseOptionalTime(activity.BeginTime)
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := parseOptionalTime(activity.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.Activity{
+		Id:             uint(activity.Id),
+		Title:          activity.Title,
+		BeginTime:      beginTime,
+		EndTime:        endTime,
+		UserDayMax:     uint(activity.UserDayMax),
+		IpDayMax:       uint(activity.IpDayMax),
+		PrizeCodeMax:   uint(activity.PrizeCodeMax),
+		SelectStrategy: uint(activity.SelectStrategy),
+		NoPrizeWeight:  uint(activity.NoPrizeWeight),
+		PityThreshold:  uint(activity.PityThreshold),
+		PityPrizeType:  uint(activity.PityPrizeType),
+		UserWinMax:     uint(activity.UserWinMax),
+		TypeDayWinMax:  activity.TypeDayWinMax,
+		SysStatus:      uint(activity.SysStatus),
+	}, nil
+}
+
+func toPbActivityInfo(activity *biz.Activity) *pb.ActivityInfo {
+	return &pb.ActivityInfo{
+		Id:             uint32(activity.Id),
+		Title:          activity.Title,
+		BeginTime:      activity.BeginTime.Format(constant.SysTimeFormat),
+		EndTime:        activity.EndTime.Format(constant.SysTimeFormat),
+		UserDayMax:     uint32(activity.UserDayMax),
+		IpDayMax:       uint32(activity.IpDayMax),
+		PrizeCodeMax:   uint32(activity.PrizeCodeMax),
+		SelectStrategy: uint32(activity.SelectStrategy),
+		NoPrizeWeight:  uint32(activity.NoPrizeWeight),
+		PityThreshold:  uint32(activity.PityThreshold),
+		PityPrizeType:  uint32(activity.PityPrizeType),
+		UserWinMax:     uint32(activity.UserWinMax),
+		TypeDayWinMax:  activity.TypeDayWinMax,
+		SysStatus:      uint32(activity.SysStatus),
+	}
+}
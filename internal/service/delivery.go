@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// CronJobRetryDeliveryTask 定时任务方法, 重试到期的虚拟奖品发放
+func (l *LotteryService) CronJobRetryDeliveryTask(ctx context.Context) error {
+	return l.deliveryCase.RetryDelivery(ctx)
+}
+
+// GetDeliveryDeadList 分页查询发放死信
+func (a *AdminService) GetDeliveryDeadList(ctx context.Context, status *uint, page *biz.PageQuery) ([]*biz.PrizeDeliveryDead, int64, error) {
+	list, total, err := a.deliveryCase.GetDeadList(ctx, status, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetDeliveryDeadList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetDeliveryDeadList:%v", err)
+	}
+	return list, total, nil
+}
+
+// ReplayDelivery 死信重新发放
+func (a *AdminService) ReplayDelivery(ctx context.Context, ids []uint) ([]uint, error) {
+	failIds, err := a.deliveryCase.ReplayDead(ctx, ids)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|ReplayDelivery err:%v", err)
+		return nil, fmt.Errorf("adminService|ReplayDelivery:%v", err)
+	}
+	return failIds, nil
+}
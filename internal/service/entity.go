@@ -12,7 +12,6 @@ type ViewPrize struct {
 	PrizeTime    uint      `json:"prize_time"`
 	LeftNum      int       `json:"left_num"`
 	PrizeType    uint      `json:"prize_type"`
-	PrizePlan    string    `json:"prize_plan"`
 	BeginTime    time.Time `json:"begin_time"`
 	EndTime      time.Time `json:"end_time"`
 	DisplayOrder uint      `json:"display_order"`
@@ -18,7 +18,14 @@ const (
 	ErrBlackedIP        ErrCode = 10003
 	ErrBlackedUser      ErrCode = 10004
 	ErrPrizeNotEnough   ErrCode = 10005
+	ErrActivityInvalid  ErrCode = 10006
+	ErrRegister         ErrCode = 10007
+	ErrPermissionDenied ErrCode = 10008
+	ErrPrizeInvalid     ErrCode = 10009
 	ErrNotWon           ErrCode = 100010
+	ErrResultInvalid    ErrCode = 10011
+	ErrRiskDenied       ErrCode = 10014
+	ErrRiskChallenge    ErrCode = 10015
 )
 
 var errMsgDic = map[ErrCode]string{
@@ -34,7 +41,14 @@ var errMsgDic = map[ErrCode]string{
 	ErrBlackedIP:        "blacked ip",
 	ErrBlackedUser:      "blacked user",
 	ErrPrizeNotEnough:   "prize not enough",
+	ErrActivityInvalid:  "activity not exists or not in progress",
+	ErrRegister:         "register fail",
+	ErrPermissionDenied: "permission denied",
+	ErrPrizeInvalid:     "prize config invalid",
 	ErrNotWon:           "not won,please try again!",
+	ErrResultInvalid:    "result not exists or status invalid",
+	ErrRiskDenied:       "risk denied",
+	ErrRiskChallenge:    "risk challenge required",
 }
 
 // GetErrMsg 获取错误描述
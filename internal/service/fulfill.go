@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// ClaimPrize 中奖用户填写收货信息领取实物奖品
+func (l *LotteryService) ClaimPrize(ctx context.Context, uid uint, info *biz.ClaimInfo) error {
+	if err := l.fulfillCase.ClaimPrize(ctx, uid, info); err != nil {
+		log.ErrorContextf(ctx, "lotteryService|ClaimPrize err:%v", err)
+		return fmt.Errorf("lotteryService|ClaimPrize:%w", err)
+	}
+	return nil
+}
+
+// CronJobExpireFulfillTask 定时任务方法, 超过领取期限的实物奖品回库
+func (l *LotteryService) CronJobExpireFulfillTask(ctx context.Context) error {
+	return l.fulfillCase.ExpireFulfill(ctx)
+}
+
+// GetShipmentList 获取已领取待发货的中奖记录
+func (a *AdminService) GetShipmentList(ctx context.Context, activityID *uint) ([]*biz.Result, error) {
+	list, err := a.fulfillCase.GetShipmentList(ctx, activityID)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetShipmentList err:%v", err)
+		return nil, fmt.Errorf("adminService|GetShipmentList:%v", err)
+	}
+	return list, nil
+}
+
+// ShipResults 批量录入物流单号
+func (a *AdminService) ShipResults(ctx context.Context, list []*biz.ShipInfo) ([]uint, error) {
+	failIds, err := a.fulfillCase.ShipResults(ctx, list)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|ShipResults err:%v", err)
+		return nil, fmt.Errorf("adminService|ShipResults:%v", err)
+	}
+	return failIds, nil
+}
+
+// DeliverResults 批量标记签收
+func (a *AdminService) DeliverResults(ctx context.Context, ids []uint) ([]uint, error) {
+	failIds, err := a.fulfillCase.DeliverResults(ctx, ids)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|DeliverResults err:%v", err)
+		return nil, fmt.Errorf("adminService|DeliverResults:%v", err)
+	}
+	return failIds, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// GetMyWins 分页查询用户的中奖纪录
+func (l *LotteryService) GetMyWins(ctx context.Context, req *pb.GetMyWinsReq) (*pb.GetMyWinsRsp, error) {
+	rsp := &pb.GetMyWinsRsp{
+		CommonRsp: &pb.CommonRspInfo{
+			Code:   int32(Success),
+			Msg:    GetErrMsg(Success),
+			UserId: req.UserId,
+		},
+	}
+	var activityID *uint
+	if req.ActivityId != nil {
+		id := uint(req.GetActivityId())
+		activityID = &id
+	}
+	page := &biz.PageQuery{Page: int(req.Page), PageSize: int(req.PageSize)}
+	list, total, err := l.lotteryCase.GetUserWinList(ctx, uint(req.UserId), activityID, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetMyWins err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetMyWins:%v", err)
+	}
+	rsp.Total = total
+	rsp.WinList = make([]*pb.WinInfo, 0, len(list))
+	for _, result := range list {
+		rsp.WinList = append(rsp.WinList, winInfo(result))
+	}
+	return rsp, nil
+}
+
+func winInfo(result *biz.Result) *pb.WinInfo {
+	info := &pb.WinInfo{
+		Id:            uint32(result.Id),
+		ActivityId:    uint32(result.ActivityId),
+		PrizeId:       uint32(result.PrizeId),
+		PrizeName:     result.PrizeName,
+		PrizeType:     uint32(result.PrizeType),
+		PrizeData:     result.PrizeData,
+		PrizeCode:     uint32(result.PrizeCode),
+		FulfillStatus: uint32(result.FulfillStatus),
+	}
+	if result.ClaimDeadline != nil {
+		info.ClaimDeadline = result.ClaimDeadline.Format(constant.SysTimeFormat)
+	}
+	if result.SysCreated != nil {
+		info.WinTime = result.SysCreated.Format(constant.SysTimeFormat)
+	}
+	return info
+}
+
+// GetMyLotteryTimes 查询用户今天剩余的抽奖次数
+func (l *LotteryService) GetMyLotteryTimes(ctx context.Context, req *pb.GetMyLotteryTimesReq) (*pb.GetMyLotteryTimesRsp, error) {
+	rsp := &pb.GetMyLotteryTimesRsp{
+		CommonRsp: &pb.CommonRspInfo{
+			Code:   int32(Success),
+			Msg:    GetErrMsg(Success),
+			UserId: req.UserId,
+		},
+	}
+	activity, err := l.lotteryCase.GetActivity(ctx, uint(req.ActivityId))
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetMyLotteryTimes err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetMyLotteryTimes:%v", err)
+	}
+	if activity == nil {
+		rsp.CommonRsp.Code = int32(ErrActivityInvalid)
+		rsp.CommonRsp.Msg = GetErrMsg(ErrActivityInvalid)
+		return rsp, nil
+	}
+	times, err := l.limitCase.GetUserLotteryTimes(ctx, activity, uint(req.UserId))
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetMyLotteryTimes err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetMyLotteryTimes:%v", err)
+	}
+	rsp.DayMax = uint32(times.DayMax)
+	rsp.UsedNum = uint32(times.UsedNum)
+	rsp.RemainNum = uint32(times.RemainNum)
+	return rsp, nil
+}
+
+// GetRecentWinners 最近中奖的用户，用户名打码
+func (l *LotteryService) GetRecentWinners(ctx context.Context, req *pb.GetRecentWinnersReq) (*pb.GetRecentWinnersRsp, error) {
+	rsp := &pb.GetRecentWinnersRsp{
+		CommonRsp: &pb.CommonRspInfo{
+			Code: int32(Success),
+			Msg:  GetErrMsg(Success),
+		},
+	}
+	winners, err := l.lotteryCase.GetRecentWinners(ctx, uint(req.ActivityId))
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetRecentWinners err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetRecentWinners:%v", err)
+	}
+	rsp.WinnerList = make([]*pb.RecentWinner, 0, len(winners))
+	for _, winner := range winners {
+		rsp.WinnerList = append(rsp.WinnerList, &pb.RecentWinner{
+			UserName:  winner.UserName,
+			PrizeName: winner.PrizeName,
+			PrizeType: uint32(winner.PrizeType),
+			WinTime:   winner.WinTime.Format(constant.SysTimeFormat),
+		})
+	}
+	return rsp, nil
+}
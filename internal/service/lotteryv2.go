@@ -3,12 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
-	"github.com/BitofferHub/lotterysvr/internal/biz"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
-	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/lock"
 	"github.com/BitofferHub/pkg/middlewares/log"
-	pb "github.com/BitofferHub/proto_center/api/lotterysvr/v1"
 )
 
 func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb.LotteryRsp, error) {
@@ -27,13 +25,7 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 		ok  bool
 		err error
 	)
-	// 1. 根据token解析出用户信息
-	//jwtClaims, err := utils.ParseJwtToken(req.Token, constant.SecretKey)
-	//if err != nil || jwtClaims == nil {
-	//	rsp.CommonRsp.Code = int32(ErrJwtParse)
-	//	log.Errorf("jwt parse err, token=%s,user_id=%s\n", req.Token, req.UserId)
-	//	return nil, fmt.Errorf("LotteryV1|jwt parse err")
-	//}
+	// 用户信息已由鉴权中间件根据token解析并回填
 	userID := uint(req.UserId)
 	log.Infof("LotteryV2|user_id=%d", userID)
 	lockKey := fmt.Sprintf(constant.LotteryLockKeyPrefix+"%d", userID)
@@ -47,8 +39,21 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 	}
 	defer lock1.Unlock(ctx)
 
+	// 活动校验，不存在或者不在有效期内的活动不能抽奖
+	activity, err := l.lotteryCase.GetActivity(ctx, uint(req.ActivityId))
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|GetActivity:%v", err)
+		return nil, fmt.Errorf("LotteryV2|GetActivity err")
+	}
+	if activity == nil {
+		rsp.CommonRsp.Code = int32(ErrActivityInvalid)
+		return rsp, nil
+	}
+	userInfo := newLotteryUserInfo(ctx, activity.Id, req)
+
 	// 2. 验证用户今日抽奖次数
-	ok, err = l.limitCase.CheckUserDayLotteryTimesWithCache(ctx, userID)
+	ok, err = l.limitCase.CheckUserDayLotteryTimesWithCache(ctx, activity, userID)
 	if err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
 		log.ErrorContextf(ctx, "LotteryHandler|CheckUserDayLotteryTimes:%v", err)
@@ -61,8 +66,7 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 	}
 
 	// 3. 验证当天IP参与的抽奖次数
-	ipDayLotteryTimes := l.limitCase.CheckIPLimit(ctx, req.Ip)
-	if ipDayLotteryTimes > constant.IpLimitMax {
+	if !l.limitCase.CheckIPLimit(ctx, activity, req.Ip) {
 		rsp.CommonRsp.Code = int32(ErrIPLimitInvalid)
 		log.InfoContextf(ctx, "LotteryHandler|CheckUserDayLotteryTimes:%v", err)
 		return rsp, nil
@@ -96,23 +100,51 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 		return rsp, nil
 	}
 
+	// 风控评估，拒绝和需要验证的用户不参与抽奖，开启降级时需要验证的用户降低中奖概率
+	risk := l.riskCase.Evaluate(ctx, userInfo)
+	if code := riskErrCode(risk); code != Success {
+		rsp.CommonRsp.Code = int32(code)
+		return rsp, nil
+	}
+
 	// 6. 中奖逻辑实现
-	prizeCode := utils.Random(constant.PrizeCodeMax)
+	// 按活动配置的奖品选择策略抽奖
+	prize, prizeCode, err := l.lotteryCase.GetPrizeWithCache(ctx, activity)
 	log.InfoContextf(ctx, "LotteryHandlerV1|prizeCode=%d\n", prizeCode)
-	prize, err := l.lotteryCase.GetPrizeWithCache(ctx, prizeCode)
 	if err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
 		log.ErrorContextf(ctx, "LotteryHandler|CheckBlackUser:%v", err)
 		return nil, fmt.Errorf("LotteryV1|GetPrize err")
 	}
+	if prize != nil && !l.riskCase.KeepPrize(risk) {
+		prize = nil
+	}
 	if prize == nil || prize.PrizeNum < 0 || (prize.PrizeNum > 0 && prize.LeftNum <= 0) {
 		rsp.CommonRsp.Code = int32(ErrNotWon)
 		return rsp, nil
 	}
 
+	// 校验用户中奖次数上限，达到上限按未中奖处理，不消耗库存
+	ok, winCap, err := l.lotteryCase.AcquireWinCap(ctx, activity, userID, prize)
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|AcquireWinCap:%v", err)
+		return nil, fmt.Errorf("LotteryV2|AcquireWinCap err")
+	}
+	if !ok {
+		rsp.CommonRsp.Code = int32(ErrNotWon)
+		return rsp, nil
+	}
+	// 后续发奖失败时归还占用的中奖次数
+	defer func() {
+		if rsp.CommonRsp.Code != int32(Success) {
+			l.lotteryCase.ReleaseWinCap(ctx, activity.Id, userID, winCap)
+		}
+	}()
+
 	// 7. 有剩余奖品发放
 	if prize.PrizeNum > 0 {
-		ok, err = l.lotteryCase.GiveOutPrizeWithCache(ctx, int(prize.Id))
+		ok, err = l.lotteryCase.GiveOutPrizeWithCache(ctx, activity.Id, int(prize.Id))
 		if err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
 			log.ErrorContextf(ctx, "LotteryHandler|GiveOutPrize:%v", err)
@@ -129,7 +161,7 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 	/***如果中奖记录重要的的话，可以考虑用事务将下面逻辑包裹*****/
 	// 8. 发优惠券
 	if prize.PrizeType == constant.PrizeTypeCouponDiff {
-		code, err := l.lotteryCase.PrizeCouponDiffWithCache(ctx, int(prize.Id))
+		code, err := l.lotteryCase.PrizeCouponDiffWithCache(ctx, activity.Id, int(prize.Id))
 		if err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
 			log.InfoContextf(ctx, "LotteryHandler|PrizeCouponDiff:%v", err)
@@ -157,7 +189,7 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 	}
 
 	// 9 记录中奖纪录
-	if err := l.lotteryCase.LotteryResult(ctx, prize, userID, req.UserName, req.Ip, prizeCode); err != nil {
+	if err := l.lotteryCase.LotteryResult(ctx, prize, userInfo, prizeCode); err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
 		log.InfoContextf(ctx, "LotteryHandler|PrizeCouponDiff:%v", err)
 		return nil, fmt.Errorf("LotteryV1|LotteryResult err")
@@ -165,12 +197,7 @@ func (l *LotteryService) LotteryV2(ctx context.Context, req *pb.LotteryReq) (*pb
 
 	// 10. 如果中了实物大奖，需要把ip和用户置于黑明单中一段时间，防止同一个用户频繁中大奖
 	if prize.PrizeType == constant.PrizeTypeEntityLarge {
-		lotteryUserInfo := biz.LotteryUserInfo{
-			UserID:   userID,
-			UserName: req.UserName,
-			IP:       req.Ip,
-		}
-		if err := l.lotteryCase.PrizeLargeBlackLimit(ctx, blackUserInfo, blackIpInfo, &lotteryUserInfo); err != nil {
+		if err := l.lotteryCase.PrizeLargeBlackLimit(ctx, blackUserInfo, blackIpInfo, userInfo); err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
 			log.InfoContextf(ctx, "LotteryHandler|PrizeLargeBlackLimit:%v", err)
 			return nil, fmt.Errorf("LotteryV1|PrizeLargeBlackLimit err")
@@ -3,12 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
 	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/constant"
-	"github.com/BitofferHub/lotterysvr/internal/utils"
 	"github.com/BitofferHub/pkg/middlewares/lock"
 	"github.com/BitofferHub/pkg/middlewares/log"
-	pb "github.com/BitofferHub/proto_center/api/lotterysvr/v1"
 )
 
 func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb.LotteryRsp, error) {
@@ -27,13 +26,7 @@ func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb
 		ok  bool
 		err error
 	)
-	// 1. 根据token解析出用户信息
-	//jwtClaims, err := utils.ParseJwtToken(req.Token, constant.SecretKey)
-	//if err != nil || jwtClaims == nil {
-	//	rsp.CommonRsp.Code = int32(ErrJwtParse)
-	//	log.Errorf("jwt parse err, token=%s,user_id=%s\n", req.Token, req.UserId)
-	//	return nil, fmt.Errorf("LotteryV3|jwt parse err")
-	//}
+	// 用户信息已由鉴权中间件根据token解析并回填
 	userID := uint(req.UserId)
 	log.Infof("LotteryV3|user_id=%d", userID)
 	lockKey := fmt.Sprintf(constant.LotteryLockKeyPrefix+"%d", userID)
@@ -47,28 +40,20 @@ func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb
 	}
 	defer lock1.Unlock(ctx)
 
-	// 2. 验证用户今日抽奖次数
-	ok, err = l.limitCase.CheckUserDayLotteryTimesWithCache(ctx, userID)
+	// 活动校验，不存在或者不在有效期内的活动不能抽奖
+	activity, err := l.lotteryCase.GetActivity(ctx, uint(req.ActivityId))
 	if err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
-		log.ErrorContextf(ctx, "LotteryHandler|CheckUserDayLotteryTimes:%v", err)
-		return nil, fmt.Errorf("LotteryV3|CheckUserDayLotteryTimes err")
+		log.ErrorContextf(ctx, "LotteryHandler|GetActivity:%v", err)
+		return nil, fmt.Errorf("LotteryV3|GetActivity err")
 	}
-	if !ok {
-		rsp.CommonRsp.Code = int32(ErrUserLimitInvalid)
-		//log.InfoContextf(ctx, "LotteryHandler|CheckUserDayLotteryTimes:%v", err)
-		return rsp, nil
-	}
-
-	// 3. 验证当天IP参与的抽奖次数
-	ipDayLotteryTimes := l.limitCase.CheckIPLimit(ctx, req.Ip)
-	if ipDayLotteryTimes > constant.IpLimitMax {
-		rsp.CommonRsp.Code = int32(ErrIPLimitInvalid)
-		//log.InfoContextf(ctx, "LotteryHandler|CheckUserDayLotteryTimes:%v", err)
+	if activity == nil {
+		rsp.CommonRsp.Code = int32(ErrActivityInvalid)
 		return rsp, nil
 	}
+	userInfo := newLotteryUserInfo(ctx, activity.Id, req)
 
-	// 4. 验证IP是否在ip黑名单
+	// 2. 验证IP是否在ip黑名单
 	ok, blackIpInfo, err := l.limitCase.CheckBlackIPWithCache(ctx, req.Ip)
 	if err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
@@ -82,7 +67,7 @@ func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb
 		return rsp, nil
 	}
 
-	// 5. 验证用户是否在黑明单中
+	// 3. 验证用户是否在黑明单中
 	ok, blackUserInfo, err := l.limitCase.CheckBlackUserWithCache(ctx, userID)
 	if err != nil {
 		rsp.CommonRsp.Code = int32(ErrInternalServer)
@@ -96,63 +81,153 @@ func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb
 		return rsp, nil
 	}
 
-	// 6. 中奖逻辑实现
-	prizeCode := utils.Random(constant.PrizeCodeMax)
-	log.InfoContextf(ctx, "LotteryHandlerV1|prizeCode=%d\n", prizeCode)
-	prize, err := l.lotteryCase.GetPrizeWithCache(ctx, prizeCode)
-	if err != nil {
-		rsp.CommonRsp.Code = int32(ErrInternalServer)
-		log.ErrorContextf(ctx, "LotteryHandler|CheckBlackUser:%v", err)
-		return nil, fmt.Errorf("LotteryV3|GetPrize err")
-	}
-	if prize == nil || prize.PrizeNum < 0 || (prize.PrizeNum > 0 && prize.LeftNum <= 0) {
-		rsp.CommonRsp.Code = int32(ErrNotWon)
+	// 风控评估，拒绝和需要验证的用户不参与抽奖，开启降级时需要验证的用户降低中奖概率
+	risk := l.riskCase.Evaluate(ctx, userInfo)
+	if code := riskErrCode(risk); code != Success {
+		rsp.CommonRsp.Code = int32(code)
 		return rsp, nil
 	}
 
-	// 7. 有剩余奖品发放
-	if prize.PrizeNum > 0 {
-		num, err := l.lotteryCase.GetPrizeNumWithPool(ctx, prize.Id)
+	// 4. 中奖逻辑实现，选奖只读取奖品缓存，次数和库存在第6步原子扣减
+	// 连续未中奖次数达到保底阈值时，从保底奖品中发奖，没有可发放的保底奖品时正常抽奖
+	missNum, err := l.limitCase.GetUserMissNum(ctx, activity.Id, userID)
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|GetUserMissNum:%v", err)
+		return nil, fmt.Errorf("LotteryV3|GetUserMissNum err")
+	}
+	// 抽奖结束后按结果更新连续未中奖次数，内部错误不计数
+	defer func() {
+		switch ErrCode(rsp.CommonRsp.Code) {
+		case Success:
+			l.limitCase.UpdateUserMissNum(ctx, activity.Id, userID, true)
+		case ErrNotWon, ErrPrizeNotEnough:
+			l.limitCase.UpdateUserMissNum(ctx, activity.Id, userID, false)
+		}
+	}()
+	var (
+		prize     *biz.LotteryPrize
+		prizeCode int
+	)
+	if activity.IsPity(missNum) {
+		prize, prizeCode, err = l.lotteryCase.GetPityPrizeWithCache(ctx, activity)
 		if err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
-			log.ErrorContextf(ctx, "LotteryHandler|GiveOutPrize:%v", err)
-			return nil, fmt.Errorf("LotteryV3|GetPrizeNumWithPool err")
-		}
-		// 奖品池奖品不够，不能发奖
-		if num <= 0 {
-			rsp.CommonRsp.Code = int32(ErrNotWon)
-			//log.InfoContextf(ctx, "LotteryHandler|GiveOutPrize|prize num not enough")
-			return rsp, nil
+			log.ErrorContextf(ctx, "LotteryHandler|GetPityPrizeWithCache:%v", err)
+			return nil, fmt.Errorf("LotteryV3|GetPityPrize err")
 		}
-		ok, err = l.lotteryCase.GiveOutPrizeWithPool(ctx, int(prize.Id))
+		log.InfoContextf(ctx, "LotteryHandlerV3|pity user_id=%d miss_num=%d prize=%v", userID, missNum, prize != nil)
+	}
+	if prize == nil {
+		// 按活动配置的奖品选择策略抽奖
+		prize, prizeCode, err = l.lotteryCase.GetPrizeWithCache(ctx, activity)
+		log.InfoContextf(ctx, "LotteryHandlerV1|prizeCode=%d\n", prizeCode)
 		if err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
-			log.ErrorContextf(ctx, "LotteryHandler|GiveOutPrize:%v", err)
-			return nil, fmt.Errorf("LotteryV3|GiveOutPrize err")
-		}
-		// 奖品不足，发放失败
-		if !ok {
-			rsp.CommonRsp.Code = int32(ErrPrizeNotEnough)
-			//log.InfoContextf(ctx, "LotteryHandler|GiveOutPrize:%v", err)
-			return rsp, nil
+			log.ErrorContextf(ctx, "LotteryHandler|CheckBlackUser:%v", err)
+			return nil, fmt.Errorf("LotteryV3|GetPrize err")
 		}
 	}
+	if prize != nil && !l.riskCase.KeepPrize(risk) {
+		prize = nil
+	}
+	if prize != nil && (prize.PrizeNum < 0 || (prize.PrizeNum > 0 && prize.LeftNum <= 0)) {
+		prize = nil
+	}
 
-	/***如果中奖记录重要的的话，可以考虑用事务将下面逻辑包裹*****/
-	// 8. 发优惠券
-	if prize.PrizeType == constant.PrizeTypeCouponDiff {
-		code, err := l.lotteryCase.PrizeCouponDiffWithCache(ctx, int(prize.Id))
+	// 5. 校验用户中奖次数上限，达到上限按未中奖处理，不消耗库存
+	var winCap *biz.WinCap
+	if prize != nil {
+		ok, winCap, err = l.lotteryCase.AcquireWinCap(ctx, activity, userID, prize)
 		if err != nil {
 			rsp.CommonRsp.Code = int32(ErrInternalServer)
-			//log.InfoContextf(ctx, "LotteryHandler|PrizeCouponDiff:%v", err)
-			return nil, fmt.Errorf("LotteryV3|PrizeCouponDiff err")
+			log.ErrorContextf(ctx, "LotteryHandler|AcquireWinCap:%v", err)
+			return nil, fmt.Errorf("LotteryV3|AcquireWinCap err")
+		}
+		if !ok {
+			prize = nil
+		}
+	}
+	// 后续发奖失败时归还占用的中奖次数
+	defer func() {
+		if rsp.CommonRsp.Code != int32(Success) {
+			l.lotteryCase.ReleaseWinCap(ctx, activity.Id, userID, winCap)
 		}
-		if code == "" {
-			rsp.CommonRsp.Code = int32(ErrNotWon)
-			//log.InfoContextf(ctx, "LotteryHandler|PrizeCouponDiff coupon left is nil")
-			return rsp, nil
+	}()
+
+	// 6. 用户和IP今日抽奖次数校验、奖品池扣减、优惠券券码弹出在redis中原子完成
+	// 需要扣减奖品池或者弹出券码时先写入事务消息，进程中途退出时由定时任务修复redis
+	drawReq := biz.NewDrawReq(activity, userID, req.Ip, prize)
+	if err = l.lotteryCase.PrepareDraw(ctx, drawReq); err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|PrepareDraw:%v", err)
+		return nil, fmt.Errorf("LotteryV3|PrepareDraw err")
+	}
+	// 发奖事务没有提交时回滚事务消息，归还奖品池数量和券码
+	committed := false
+	defer func() {
+		if !committed {
+			l.lotteryCase.AbortDraw(ctx, drawReq)
 		}
-		prize.CouponCode = code
+	}()
+	drawResult, err := l.lotteryCase.DrawWithScript(ctx, drawReq)
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|DrawWithScript:%v", err)
+		return nil, fmt.Errorf("LotteryV3|DrawWithScript err")
+	}
+	switch drawResult.Status {
+	case constant.DrawUserLimit:
+		rsp.CommonRsp.Code = int32(ErrUserLimitInvalid)
+		return rsp, nil
+	case constant.DrawIpLimit:
+		rsp.CommonRsp.Code = int32(ErrIPLimitInvalid)
+		return rsp, nil
+	case constant.DrawPoolEmpty, constant.DrawCouponEmpty:
+		// 奖品池或者券码不够，不能发奖
+		rsp.CommonRsp.Code = int32(ErrNotWon)
+		return rsp, nil
+	}
+
+	// 7. 数据库中验证并记录用户今日抽奖次数
+	ok, err = l.limitCase.SyncUserDayLotteryTimes(ctx, activity, userID, drawResult.UserNum)
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|SyncUserDayLotteryTimes:%v", err)
+		return nil, fmt.Errorf("LotteryV3|SyncUserDayLotteryTimes err")
+	}
+	if !ok {
+		rsp.CommonRsp.Code = int32(ErrUserLimitInvalid)
+		return rsp, nil
+	}
+	if prize == nil {
+		rsp.CommonRsp.Code = int32(ErrNotWon)
+		return rsp, nil
+	}
+
+	// 8. 扣减数据库库存、发优惠券、记录中奖纪录、实物大奖拉黑用户和ip在一个事务中完成
+	// 中了实物大奖需要把ip和用户置于黑明单中一段时间，防止同一个用户频繁中大奖
+	ok, err = l.lotteryCase.CommitDraw(ctx, drawReq, &biz.DrawCommit{
+		Prize:      prize,
+		PrizeCode:  prizeCode,
+		CouponCode: drawResult.CouponCode,
+		UserInfo:   userInfo,
+		BlackUser:  blackUserInfo,
+		BlackIp:    blackIpInfo,
+	})
+	if err != nil {
+		rsp.CommonRsp.Code = int32(ErrInternalServer)
+		log.ErrorContextf(ctx, "LotteryHandler|CommitDraw:%v", err)
+		return nil, fmt.Errorf("LotteryV3|CommitDraw err")
+	}
+	// 奖品不足，发放失败
+	if !ok {
+		rsp.CommonRsp.Code = int32(ErrPrizeNotEnough)
+		return rsp, nil
+	}
+	committed = true
+	if prize.PrizeType == constant.PrizeTypeCouponDiff {
+		prize.CouponCode = drawResult.CouponCode
 	}
 	rsp.PrizeInfo = &pb.LotteryPrizeInfo{
 		Id:            uint32(prize.Id),
@@ -168,46 +243,20 @@ func (l *LotteryService) LotteryV3(ctx context.Context, req *pb.LotteryReq) (*pb
 		CouponCode:    prize.CouponCode,
 	}
 
-	// 9 记录中奖纪录
-	if err := l.lotteryCase.LotteryResult(ctx, prize, userID, req.UserName, req.Ip, prizeCode); err != nil {
-		rsp.CommonRsp.Code = int32(ErrInternalServer)
-		//log.InfoContextf(ctx, "LotteryHandler|PrizeCouponDiff:%v", err)
-		return nil, fmt.Errorf("LotteryV3|LotteryResult err")
-	}
-
-	// 10. 如果中了实物大奖，需要把ip和用户置于黑明单中一段时间，防止同一个用户频繁中大奖
-	if prize.PrizeType == constant.PrizeTypeEntityLarge {
-		lotteryUserInfo := biz.LotteryUserInfo{
-			UserID:   userID,
-			UserName: req.UserName,
-			IP:       req.Ip,
-		}
-		if err := l.lotteryCase.PrizeLargeBlackLimit(ctx, blackUserInfo, blackIpInfo, &lotteryUserInfo); err != nil {
-			rsp.CommonRsp.Code = int32(ErrInternalServer)
-			//log.InfoContextf(ctx, "LotteryHandler|PrizeLargeBlackLimit:%v", err)
-			return nil, fmt.Errorf("LotteryV3|PrizeLargeBlackLimit err")
-		}
-	}
-
 	return rsp, nil
 }
 
-// CronJobResetIPLotteryNumsTask 定时任务方法, 重置所有的IP抽奖次数
-func (l *LotteryService) CronJobResetIPLotteryNumsTask() {
-	l.limitCase.CronJobResetIPLotteryNums()
-}
-
-// CronJobResetUserLotteryNumsTask 定时任务方法, 重置今日用户抽奖次数
-func (l *LotteryService) CronJobResetUserLotteryNumsTask() {
-	l.limitCase.CronJobResetUserLotteryNums()
-}
-
 // CronJobResetAllPrizePlanTask 定时任务方法, 重置所有的奖品发奖计划
-func (l *LotteryService) CronJobResetAllPrizePlanTask() {
-	l.adminCase.ResetAllPrizePlan()
+func (l *LotteryService) CronJobResetAllPrizePlanTask(ctx context.Context) error {
+	return l.adminCase.ResetAllPrizePlan()
 }
 
 // CronJobFillAllPrizePoolTask 定时任务方法， 填充奖品池
-func (l *LotteryService) CronJobFillAllPrizePoolTask() {
-	l.adminCase.FillAllPrizePool()
+func (l *LotteryService) CronJobFillAllPrizePoolTask(ctx context.Context) error {
+	return l.adminCase.FillAllPrizePool()
+}
+
+// CronJobRelayDrawOutboxTask 定时任务方法, 修复超时未处理的抽奖事务消息
+func (l *LotteryService) CronJobRelayDrawOutboxTask(ctx context.Context) error {
+	return l.lotteryCase.RelayDrawOutbox(ctx)
 }
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// GetRiskLogList 分页查询风控记录
+func (a *AdminService) GetRiskLogList(ctx context.Context, filter *biz.RiskLogFilter, page *biz.PageQuery) ([]*biz.RiskLog, int64, error) {
+	list, total, err := a.riskCase.GetRiskLogList(ctx, filter, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetRiskLogList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetRiskLogList:%v", err)
+	}
+	return list, total, nil
+}
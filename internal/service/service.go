@@ -1,36 +1,52 @@
 package service
 
 import (
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
 	"github.com/BitofferHub/lotterysvr/internal/biz"
-	pb "github.com/BitofferHub/proto_center/api/lotterysvr/v1"
 	"github.com/google/wire"
 )
 
 // ProviderSet is service providers.
-var ProviderSet = wire.NewSet(NewLotteryService, NewAdminService)
+var ProviderSet = wire.NewSet(NewLotteryService, NewAdminService, NewLotteryAdminService, NewUserService)
 
 type LotteryService struct {
 	pb.UnimplementedLotteryServer
-	lotteryCase *biz.LotteryCase
-	limitCase   *biz.LimitCase
-	adminCase   *biz.AdminCase
+	lotteryCase  *biz.LotteryCase
+	limitCase    *biz.LimitCase
+	adminCase    *biz.AdminCase
+	fulfillCase  *biz.FulfillCase
+	deliveryCase *biz.DeliveryCase
+	riskCase     *biz.RiskCase
 }
 
-func NewLotteryService(loc *biz.LotteryCase, lic *biz.LimitCase, ac *biz.AdminCase) *LotteryService {
+func NewLotteryService(loc *biz.LotteryCase, lic *biz.LimitCase, ac *biz.AdminCase, fc *biz.FulfillCase,
+	dc *biz.DeliveryCase, rc *biz.RiskCase) *LotteryService {
 	return &LotteryService{
-		lotteryCase: loc,
-		limitCase:   lic,
-		adminCase:   ac,
+		lotteryCase:  loc,
+		limitCase:    lic,
+		adminCase:    ac,
+		fulfillCase:  fc,
+		deliveryCase: dc,
+		riskCase:     rc,
 	}
 }
 
 // AdminService 奖品管理后台
 type AdminService struct {
-	adminCase *biz.AdminCase
+	adminCase    *biz.AdminCase
+	fulfillCase  *biz.FulfillCase
+	deliveryCase *biz.DeliveryCase
+	taskCase     *biz.TaskCase
+	riskCase     *biz.RiskCase
 }
 
-func NewAdminService(ac *biz.AdminCase) *AdminService {
+func NewAdminService(ac *biz.AdminCase, fc *biz.FulfillCase, dc *biz.DeliveryCase, tc *biz.TaskCase,
+	rc *biz.RiskCase) *AdminService {
 	return &AdminService{
-		adminCase: ac,
+		adminCase:    ac,
+		fulfillCase:  fc,
+		deliveryCase: dc,
+		taskCase:     tc,
+		riskCase:     rc,
 	}
 }
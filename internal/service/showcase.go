@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/BitofferHub/lotterysvr/api/lottery/v1"
+	"github.com/BitofferHub/lotterysvr/internal/constant"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// GetShowcase 活动当前可抽的奖品，按展示顺序排列
+func (l *LotteryService) GetShowcase(ctx context.Context, req *pb.GetShowcaseReq) (*pb.GetShowcaseRsp, error) {
+	rsp := &pb.GetShowcaseRsp{
+		CommonRsp: &pb.CommonRspInfo{
+			Code: int32(Success),
+			Msg:  GetErrMsg(Success),
+		},
+	}
+	activity, err := l.lotteryCase.GetActivity(ctx, uint(req.ActivityId))
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetShowcase err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetShowcase:%v", err)
+	}
+	if activity == nil {
+		rsp.CommonRsp.Code = int32(ErrActivityInvalid)
+		rsp.CommonRsp.Msg = GetErrMsg(ErrActivityInvalid)
+		return rsp, nil
+	}
+	list, err := l.lotteryCase.GetShowcase(ctx, activity.Id)
+	if err != nil {
+		log.ErrorContextf(ctx, "lotteryService|GetShowcase err:%v", err)
+		return nil, fmt.Errorf("lotteryService|GetShowcase:%v", err)
+	}
+	rsp.PrizeList = make([]*pb.ShowcasePrize, 0, len(list))
+	for _, prize := range list {
+		rsp.PrizeList = append(rsp.PrizeList, &pb.ShowcasePrize{
+			Id:           uint32(prize.Id),
+			Title:        prize.Title,
+			Img:          prize.Img,
+			PrizeType:    uint32(prize.PrizeType),
+			DisplayOrder: uint32(prize.DisplayOrder),
+			PrizeNum:     int32(prize.PrizeNum),
+			LeftNum:      int32(prize.LeftNum),
+			PoolNum:      int32(prize.PoolNum),
+			BeginTime:    prize.BeginTime.Format(constant.SysTimeFormat),
+			EndTime:      prize.EndTime.Format(constant.SysTimeFormat),
+		})
+	}
+	return rsp, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// GetTaskList 获取定时任务列表
+func (a *AdminService) GetTaskList(ctx context.Context) ([]*biz.TaskInfo, error) {
+	list, err := a.taskCase.GetTaskList(ctx)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetTaskList err:%v", err)
+		return nil, fmt.Errorf("adminService|GetTaskList:%v", err)
+	}
+	return list, nil
+}
+
+// GetTaskRunList 分页查询定时任务执行记录
+func (a *AdminService) GetTaskRunList(ctx context.Context, name string, page *biz.PageQuery) ([]*biz.TaskRun, int64, error) {
+	list, total, err := a.taskCase.GetTaskRunList(ctx, name, page)
+	if err != nil {
+		log.ErrorContextf(ctx, "adminService|GetTaskRunList err:%v", err)
+		return nil, 0, fmt.Errorf("adminService|GetTaskRunList:%v", err)
+	}
+	return list, total, nil
+}
+
+// TriggerTask 手动触发定时任务
+func (a *AdminService) TriggerTask(ctx context.Context, name string) error {
+	if err := a.taskCase.TriggerTask(ctx, name); err != nil {
+		log.ErrorContextf(ctx, "adminService|TriggerTask err:%v", err)
+		return fmt.Errorf("adminService|TriggerTask:%w", err)
+	}
+	return nil
+}
+
+// PauseTask 暂停或恢复定时任务
+func (a *AdminService) PauseTask(ctx context.Context, name string, paused bool) error {
+	if err := a.taskCase.PauseTask(ctx, name, paused); err != nil {
+		log.ErrorContextf(ctx, "adminService|PauseTask err:%v", err)
+		return fmt.Errorf("adminService|PauseTask:%w", err)
+	}
+	return nil
+}
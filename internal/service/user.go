@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
+	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
+)
+
+// UserService 用户登录注册以及token管理
+type UserService struct {
+	userCase *biz.UserCase
+}
+
+func NewUserService(uc *biz.UserCase) *UserService {
+	return &UserService{
+		userCase: uc,
+	}
+}
+
+// Register 注册用户
+func (u *UserService) Register(ctx context.Context, userName, password string) (*biz.LoginRsp, error) {
+	rsp, err := u.userCase.Register(ctx, userName, password)
+	if err != nil {
+		log.ErrorContextf(ctx, "userService|Register err:%v", err)
+		return nil, fmt.Errorf("userService|Register:%v", err)
+	}
+	return rsp, nil
+}
+
+// Login 用户登录，用户名或密码错误返回nil
+func (u *UserService) Login(ctx context.Context, userName, password string) (*biz.LoginRsp, error) {
+	rsp, err := u.userCase.Login(ctx, userName, password)
+	if err != nil {
+		log.ErrorContextf(ctx, "userService|Login err:%v", err)
+		return nil, fmt.Errorf("userService|Login:%v", err)
+	}
+	return rsp, nil
+}
+
+// Refresh 刷新token
+func (u *UserService) Refresh(ctx context.Context, token string) (*biz.LoginRsp, error) {
+	rsp, err := u.userCase.Refresh(ctx, token)
+	if err != nil {
+		log.ErrorContextf(ctx, "userService|Refresh err:%v", err)
+		return nil, fmt.Errorf("userService|Refresh:%v", err)
+	}
+	return rsp, nil
+}
+
+// Logout 退出登录，吊销token
+func (u *UserService) Logout(ctx context.Context, token string) error {
+	if err := u.userCase.Logout(ctx, token); err != nil {
+		log.ErrorContextf(ctx, "userService|Logout err:%v", err)
+		return fmt.Errorf("userService|Logout:%v", err)
+	}
+	return nil
+}
+
+// ParseToken 解析并校验token
+func (u *UserService) ParseToken(ctx context.Context, token string) (*utils.JWTClaims, error) {
+	return u.userCase.ParseToken(ctx, token)
+}
+
+// CheckRole 校验用户角色
+func (u *UserService) CheckRole(ctx context.Context, uid uint, role int) (bool, error) {
+	return u.userCase.CheckRole(ctx, uid, role)
+}
@@ -2,11 +2,12 @@ package task
 
 import (
 	"context"
+	"fmt"
+	"github.com/BitofferHub/lotterysvr/internal/biz"
 	"github.com/BitofferHub/lotterysvr/internal/conf"
 	"github.com/BitofferHub/lotterysvr/internal/service"
-	"github.com/BitofferHub/lotterysvr/internal/utils"
+	"github.com/BitofferHub/pkg/middlewares/log"
 	"github.com/google/wire"
-	"time"
 )
 
 // ProviderSet is service providers.
@@ -18,80 +19,51 @@ type TaskServer struct {
 	scheduler *TaskScheduler
 }
 
-func (t *TaskServer) Stop(ctx context.Context) error {
-	t.scheduler.Stop()
+func (t *TaskServer) Start(ctx context.Context) error {
+	t.scheduler.Start()
 	return nil
 }
 
-// NewJobs 添加Job方法
-func (t *TaskServer) NewJobs() []Job {
-	return []Job{t.job1, t.job2, t.job3, t.job4}
+// Stop 停止调度，等待正在执行的任务结束
+func (t *TaskServer) Stop(ctx context.Context) error {
+	return t.scheduler.Stop(ctx)
+}
+
+// NewJobs 按名称添加Job方法，配置文件中的任务按名称对应
+func (t *TaskServer) NewJobs() map[string]biz.TaskJob {
+	return map[string]biz.TaskJob{
+		"reset_prize_plan":  t.service.CronJobResetAllPrizePlanTask,
+		"fill_prize_pool":   t.service.CronJobFillAllPrizePoolTask,
+		"relay_draw_outbox": t.service.CronJobRelayDrawOutboxTask,
+		"expire_fulfill":    t.service.CronJobExpireFulfillTask,
+		"retry_delivery":    t.service.CronJobRetryDeliveryTask,
+	}
 }
 
-// NewTaskServer 注入对应service
-func NewTaskServer(s *service.LotteryService, c *conf.Server) *TaskServer {
+// NewTaskServer 注入对应service，按配置注册定时任务
+// 配置了没有Job方法的任务或者调度计划有误时启动失败，没有配置的Job不会执行
+func NewTaskServer(s *service.LotteryService, tc *biz.TaskCase, c *conf.Server) (*TaskServer, error) {
 	t := &TaskServer{
 		service: s,
 	}
-	conf := c.GetTask()
-	t.scheduler = NewScheduler(conf.GetAddr(), NewTasks(conf, t.NewJobs()))
-
-	return t
-}
-func NewTasks(c *conf.Server_TASK, jobs []Job) []*Task {
-	var tasks []*Task
-	for i, job := range jobs {
-		tasks = append(tasks, &Task{
-			Name:     c.Tasks[i].Name,
-			Type:     c.Tasks[i].Type,
-			Schedule: c.Tasks[i].Schedule,
-			Handler:  job,
-		})
+	jobs := t.NewJobs()
+	configured := make(map[string]bool)
+	for _, task := range c.GetTask().GetTasks() {
+		job, ok := jobs[task.GetName()]
+		if !ok {
+			return nil, fmt.Errorf("task %s has no job, check task names in config", task.GetName())
+		}
+		err := tc.Register(task.GetName(), task.GetType(), task.GetSchedule(), task.GetLockTtl().AsDuration(), job)
+		if err != nil {
+			return nil, err
+		}
+		configured[task.GetName()] = true
 	}
-
-	return tasks
-}
-
-func (t *TaskServer) job1() {
-	t.service.CronJobResetIPLotteryNumsTask()
-	next := utils.NextDayTime()
-	t.scheduler.AddTask(Task{
-		Name:     "job1",
-		Type:     "once",
-		NextTime: next,
-		Handler:  t.job1,
-	})
-}
-
-func (t *TaskServer) job2() {
-	t.service.CronJobResetUserLotteryNumsTask()
-	next := utils.NextDayTime()
-	t.scheduler.AddTask(Task{
-		Name:     "job2",
-		Type:     "once",
-		NextTime: next,
-		Handler:  t.job2,
-	})
-}
-
-func (t *TaskServer) job3() {
-	t.service.CronJobResetAllPrizePlanTask()
-	next := time.Now().Add(5 * time.Minute)
-	t.scheduler.AddTask(Task{
-		Name:     "job3",
-		Type:     "once",
-		NextTime: next,
-		Handler:  t.job3,
-	})
-}
-
-func (t *TaskServer) job4() {
-	t.service.CronJobFillAllPrizePoolTask()
-	next := time.Now().Add(1 * time.Minute)
-	t.scheduler.AddTask(Task{
-		Name:     "job4",
-		Type:     "once",
-		NextTime: next,
-		Handler:  t.job4,
-	})
+	for name := range jobs {
+		if !configured[name] {
+			log.Infof("task %s is not configured, skipped", name)
+		}
+	}
+	t.scheduler = NewScheduler(tc)
+	return t, nil
 }
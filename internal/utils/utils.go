@@ -10,7 +10,10 @@ import (
 	"github.com/BitofferHub/lotterysvr/internal/constant"
 	"github.com/golang-jwt/jwt"
 	uuid2 "github.com/google/uuid"
+	"hash/fnv"
 	"math/rand"
+	"net"
+	"net/netip"
 	"strconv"
 	"strings"
 	"time"
@@ -146,6 +149,123 @@ func Ip4toInt(ip string) int64 {
 	return sum
 }
 
+// ParseIp 解析IPv4或IPv6地址，IPv4映射的IPv6地址转换为IPv4，去掉IPv6的zone
+func ParseIp(ip string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap().WithZone(""), nil
+}
+
+// ParseIpPrefix 解析CIDR网段，单个IP解析为/32或/128，网段中的主机位清零
+func ParseIpPrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		addr, err := ParseIp(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr, bits := prefix.Addr(), prefix.Bits()
+	if addr.Is4In6() {
+		if bits < 96 {
+			return netip.Prefix{}, fmt.Errorf("prefix %s is shorter than the IPv4-mapped range", s)
+		}
+		addr, bits = addr.Unmap(), bits-96
+	}
+	return netip.PrefixFrom(addr, bits).Masked(), nil
+}
+
+// NormalizeIp IP的规范写法，同一个IPv6地址的不同写法得到相同的字符串，无法解析时原样返回
+func NormalizeIp(ip string) string {
+	addr, err := ParseIp(ip)
+	if err != nil {
+		return ip
+	}
+	return addr.String()
+}
+
+// IpHash IP或网段的哈希值，用于缓存分片
+// IPv4地址和Ip4toInt的结果相同，升级前后分片不变，其他情况取fnv哈希
+func IpHash(s string) uint64 {
+	if addr, err := ParseIp(s); err == nil && addr.Is4() {
+		b := addr.As4()
+		return uint64(binary.BigEndian.Uint32(b[:]))
+	}
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// ClientIpResolver 根据连接地址和代理请求头确定客户端IP，只有来自可信代理的请求才使用请求头
+type ClientIpResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIpResolver trustedProxies为可信代理的IP或CIDR网段，为空时只使用连接地址
+func NewClientIpResolver(trustedProxies []string) (*ClientIpResolver, error) {
+	r := &ClientIpResolver{}
+	for _, s := range trustedProxies {
+		prefix, err := ParseIpPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %v", s, err)
+		}
+		r.trusted = append(r.trusted, prefix)
+	}
+	return r, nil
+}
+
+func (r *ClientIpResolver) isTrusted(addr netip.Addr) bool {
+	for _, p := range r.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve remoteAddr为连接地址，可以带端口
+// 连接来自可信代理时，从右向左跳过X-Forwarded-For中的可信代理，第一个不可信的地址为客户端IP，
+// X-Forwarded-For全部是可信代理时使用X-Real-IP，都没有时使用最左边的地址或者连接地址
+func (r *ClientIpResolver) Resolve(remoteAddr, forwardedFor, realIp string) string {
+	host := remoteAddr
+	if h, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		host = h
+	}
+	peer, err := ParseIp(host)
+	if err != nil {
+		return host
+	}
+	if !r.isTrusted(peer) {
+		return peer.String()
+	}
+	client := peer
+	if forwardedFor != "" {
+		hops := strings.Split(forwardedFor, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := ParseIp(hops[i])
+			if err != nil {
+				// 无法解析的地址说明请求头被伪造或者代理配置有误，不再继续向左查找
+				return client.String()
+			}
+			client = addr
+			if !r.isTrusted(addr) {
+				return addr.String()
+			}
+		}
+	}
+	if addr, err := ParseIp(realIp); err == nil {
+		return addr.String()
+	}
+	return client.String()
+}
+
 // NextDayDuration 得到当前时间到下一天零点的延时
 func NextDayDuration() time.Duration {
 	year, month, day := time.Now().Add(time.Hour * 24).Date()
@@ -263,6 +383,21 @@ func GetTodayIntDay() int {
 	return day
 }
 
+// MaskUserName 用户名打码，保留首尾字符，两个字符时只保留首字符
+func MaskUserName(name string) string {
+	runes := []rune(name)
+	switch len(runes) {
+	case 0:
+		return ""
+	case 1:
+		return "*"
+	case 2:
+		return string(runes[0]) + "*"
+	default:
+		return string(runes[0]) + strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-1])
+	}
+}
+
 // JWTClaims 自定义格式内容
 type JWTClaims struct {
 	UserID         uint   `json:"user_id"`
@@ -270,8 +405,9 @@ type JWTClaims struct {
 	StandardClaims jwt.StandardClaims
 }
 
+// Valid 校验token的有效期
 func (j JWTClaims) Valid() error {
-	return nil
+	return j.StandardClaims.Valid()
 }
 
 // GenerateJwtToken 生成token
@@ -286,6 +422,7 @@ func GenerateJwtToken(secret string, issuer string, userId uint, userName string
 			NotBefore: nowTime,                                             // 签名生效时间
 			ExpiresAt: time.Now().Add(constant.TokenExpireDuration).Unix(), // 签名过期时间
 			Issuer:    issuer,                                              // 签名颁发者
+			Id:        NewUuid(),                                           // token唯一标识，用于吊销
 		},
 	}
 	tokenString, err := token.SignedString(hmacSampleSecret)
@@ -297,6 +434,9 @@ func ParseJwtToken(tokenString string, secret string) (*JWTClaims, error) {
 	var hmacSampleSecret = []byte(secret)
 	//前面例子生成的token
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
 		return hmacSampleSecret, nil
 	})
 
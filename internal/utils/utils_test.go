@@ -6,3 +6,87 @@ func TestIp4toInt(t *testing.T) {
 	str := "192.168.32.33"
 	t.Log(Ip4toInt(str))
 }
+
+func TestJwtToken(t *testing.T) {
+	token, err := GenerateJwtToken("secret", "lottery", 1, "tom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := ParseJwtToken(token, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != 1 || claims.UserName != "tom" || claims.StandardClaims.Id == "" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+	if _, err = ParseJwtToken(token, "other"); err == nil {
+		t.Fatal("token signed with other secret should be invalid")
+	}
+}
+
+func TestMaskUserName(t *testing.T) {
+	cases := map[string]string{"": "", "a": "*", "张三": "张*", "tom": "t*m", "欧阳娜娜": "欧**娜"}
+	for name, want := range cases {
+		if got := MaskUserName(name); got != want {
+			t.Errorf("MaskUserName(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestParseIpPrefix(t *testing.T) {
+	cases := map[string]string{
+		"192.168.32.33":       "192.168.32.33/32",
+		"192.168.32.33/24":    "192.168.32.0/24",
+		"::ffff:10.1.2.3/120": "10.1.2.0/24",
+		"2001:DB8::1/64":      "2001:db8::/64",
+		"2001:db8::1":         "2001:db8::1/128",
+	}
+	for s, want := range cases {
+		prefix, err := ParseIpPrefix(s)
+		if err != nil {
+			t.Fatalf("ParseIpPrefix(%s) err:%v", s, err)
+		}
+		if prefix.String() != want {
+			t.Errorf("ParseIpPrefix(%s) = %s, want %s", s, prefix, want)
+		}
+	}
+	for _, s := range []string{"", "1.2.3", "1.2.3.4/33", "::ffff:10.1.2.3/64"} {
+		if _, err := ParseIpPrefix(s); err == nil {
+			t.Errorf("ParseIpPrefix(%s) should fail", s)
+		}
+	}
+}
+
+func TestIpHash(t *testing.T) {
+	if IpHash("192.168.32.33") != uint64(Ip4toInt("192.168.32.33")) {
+		t.Error("IPv4 hash should equal Ip4toInt")
+	}
+	if NormalizeIp("2001:DB8:0::1") != NormalizeIp("2001:db8::1") {
+		t.Error("IPv6 should be normalized")
+	}
+}
+
+func TestClientIpResolver(t *testing.T) {
+	r, err := NewClientIpResolver([]string{"10.0.0.0/8", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		remote, xff, realIp, want string
+	}{
+		// 不可信的连接忽略请求头
+		{"1.2.3.4:5678", "9.9.9.9", "8.8.8.8", "1.2.3.4"},
+		{"10.0.0.2:80", "9.9.9.9, 1.2.3.4, 10.0.0.3", "", "1.2.3.4"},
+		{"[::1]:80", "2001:DB8::1", "", "2001:db8::1"},
+		{"10.0.0.2:80", "", "1.2.3.4", "1.2.3.4"},
+		{"10.0.0.2:80", "10.0.0.5", "1.2.3.4", "1.2.3.4"},
+		{"10.0.0.2:80", "10.0.0.5", "", "10.0.0.5"},
+		{"10.0.0.2:80", "garbage, 10.0.0.5", "", "10.0.0.5"},
+		{"10.0.0.2:80", "", "", "10.0.0.2"},
+	}
+	for _, c := range cases {
+		if got := r.Resolve(c.remote, c.xff, c.realIp); got != c.want {
+			t.Errorf("Resolve(%s, %s, %s) = %s, want %s", c.remote, c.xff, c.realIp, got, c.want)
+		}
+	}
+}
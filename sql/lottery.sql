@@ -1,13 +1,38 @@
 use lottery_system;
 
+DROP TABLE IF EXISTS `t_activity`;
+CREATE TABLE `t_activity`
+(
+    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `title` varchar(255) NOT NULL DEFAULT '' COMMENT '活动名称',
+    `begin_time` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '活动开始时间',
+    `end_time` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '活动结束时间',
+    `user_day_max` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户每天最多抽奖次数，0使用默认值',
+    `ip_day_max` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '同一个IP每天最多抽奖次数，0使用默认值',
+    `prize_code_max` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '中奖编码空间，0使用默认值',
+    `select_strategy` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '奖品选择策略，0 中奖编码区间，1 概率权重',
+    `no_prize_weight` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '不中奖权重，概率权重策略使用',
+    `pity_threshold` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '保底阈值，连续未中奖达到该次数后必中，0不保底',
+    `pity_prize_type` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '保底奖品的最低类型，保底时只发放类型不低于该值的奖品',
+    `user_win_max` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '每个用户在活动中最多中奖次数，0不限制',
+    `type_day_win_max` varchar(255) NOT NULL DEFAULT '' COMMENT '每个用户每天每种奖品类型最多中奖次数，格式 类型:次数,类型:次数',
+    `sys_status` smallint(5) unsigned NOT NULL DEFAULT '1' COMMENT '状态，1-正常，2-删除',
+    `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
+    `sys_updated` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT'修改时间',
+    PRIMARY KEY (`id`)
+)ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 COMMENT='抽奖活动表';
+
 DROP TABLE IF EXISTS `t_prize`;
 CREATE TABLE `t_prize`
 (
     `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+    `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
     `title` varchar(255) NOT NULL DEFAULT '' COMMENT '奖品名称',
     `prize_num` int(11) NOT NULL DEFAULT '-1' COMMENT '奖品数量，0 无限量，>0限量，<0无奖品',
     `left_num` int(11) NOT NULL DEFAULT '0' COMMENT '剩余数量',
     `prize_code` varchar(50) NOT NULL DEFAULT '' COMMENT '0-9999表示100%，0-0表示万分之一的中奖概率',
+    `weight` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '中奖权重，活动使用概率权重策略时生效',
+    `user_win_max` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '每个用户最多中该奖品的次数，0不限制',
     `prize_time` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '发奖周期，多少天，以天为单位',
     `img` varchar(255) NOT NULL DEFAULT '' COMMENT '奖品图片',
     `display_order` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '位置序号，小的排在前面',
@@ -15,20 +40,22 @@ CREATE TABLE `t_prize`
     `prize_profile` varchar(255) NOT NULL DEFAULT '' COMMENT '奖品扩展数据，如：虚拟币数量',
     `begin_time` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '奖品有效周期：开始时间',
     `end_time` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '奖品有效周期：结束时间',
-    `prize_plan` mediumtext COMMENT '发奖计划，[[时间1,数量1],[时间2,数量2]]',
     `prize_begin` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '发奖计划周期的开始',
     `prize_end` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '发奖计划周期的结束',
+    `plan_profile` varchar(50) NOT NULL DEFAULT '' COMMENT '发奖计划的分布曲线名称，空表示default',
     `sys_status` smallint(5) unsigned NOT NULL DEFAULT '1' COMMENT '状态，1-正常，2-删除',
     `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
     `sys_updated` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT'修改时间',
     `sys_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '操作人IP',
-    PRIMARY KEY (`id`)
+    PRIMARY KEY (`id`),
+    KEY `idx_activity_id` (`activity_id`)
 )ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 COMMENT='奖品表';
 
 
 DROP TABLE IF EXISTS `t_coupon`;
 CREATE TABLE `t_coupon` (
                             `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                            `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
                             `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID，关联lt_prize表',
                             `code` varchar(255) NOT NULL DEFAULT '' COMMENT '虚拟券编码',
                             `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
@@ -43,6 +70,7 @@ CREATE TABLE `t_coupon` (
 DROP TABLE IF EXISTS `t_result`;
 CREATE TABLE `t_result` (
                             `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                            `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
                             `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID，关联lt_prize表',
                             `prize_name` varchar(255) NOT NULL DEFAULT '' COMMENT '奖品名称',
                             `prize_type` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品类型，同lt_prize. gtype',
@@ -51,11 +79,23 @@ CREATE TABLE `t_result` (
                             `prize_code` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '抽奖编号（4位的随机数）',
                             `prize_data` varchar(255) NOT NULL DEFAULT '' COMMENT '获奖信息',
                             `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
-                            `sys_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '用户抽奖的IP',
+                            `sys_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '用户抽奖的IP，根据连接地址和可信代理确定',
+                            `claimed_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '请求中声明的IP',
                             `sys_status` smallint(5) unsigned NOT NULL DEFAULT '1' COMMENT '状态，1-正常，2-删除，3-作弊',
+                            `fulfill_status` smallint(5) unsigned NOT NULL DEFAULT '0' COMMENT '履约状态，0 无需履约，1 待领取，2 已领取，3 已发货，4 已签收，5 已过期，6 已回库',
+                            `claim_deadline` datetime DEFAULT NULL COMMENT '领取截止时间',
+                            `contact_name` varchar(50) NOT NULL DEFAULT '' COMMENT '收货人',
+                            `contact_phone` varchar(20) NOT NULL DEFAULT '' COMMENT '收货人电话',
+                            `address` varchar(255) NOT NULL DEFAULT '' COMMENT '收货地址',
+                            `tracking_no` varchar(64) NOT NULL DEFAULT '' COMMENT '物流单号',
+                            `claim_time` datetime DEFAULT NULL COMMENT '领取时间',
+                            `ship_time` datetime DEFAULT NULL COMMENT '发货时间',
+                            `deliver_time` datetime DEFAULT NULL COMMENT '签收时间',
                             PRIMARY KEY (`id`),
                             KEY `idx_user_id` (`user_id`),
-                            KEY `idx_prize_id` (`prize_id`)
+                            KEY `idx_prize_id` (`prize_id`),
+                            KEY `idx_activity_id` (`activity_id`),
+                            KEY `idx_fulfill_status` (`fulfill_status`, `claim_deadline`)
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 COMMENT='抽奖记录表';
 
 
@@ -79,7 +119,7 @@ CREATE TABLE `t_black_user` (
 DROP TABLE IF EXISTS `t_black_ip`;
 CREATE TABLE `t_black_ip` (
                               `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
-                              `ip` varchar(50) NOT NULL DEFAULT '' COMMENT 'IP地址',
+                              `ip` varchar(50) NOT NULL DEFAULT '' COMMENT 'IP地址或CIDR网段',
                               `black_time` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '黑名单限制到期时间',
                               `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
                               `sys_updated` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '修改时间',
@@ -91,13 +131,14 @@ CREATE TABLE `t_black_ip` (
 DROP TABLE IF EXISTS `t_lottery_times`;
 CREATE TABLE `t_lottery_times` (
                                    `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                                   `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
                                    `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户ID',
                                    `day` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '日期，如：20220625',
                                    `num` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '次数',
                                    `sys_created` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '创建时间',
                                    `sys_updated` datetime NOT NULL DEFAULT '1000-01-01 00:00:00' COMMENT '修改时间',
                                    PRIMARY KEY (`id`),
-                                   UNIQUE KEY `idx_user_id_day` (`user_id`,`day`)
+                                   UNIQUE KEY `idx_activity_user_id_day` (`activity_id`,`user_id`,`day`)
 ) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='用户每日抽奖次数表';
 
 
@@ -107,5 +148,142 @@ CREATE TABLE `t_user` (
                           `user_name` varchar(50) NOT NULL DEFAULT '' COMMENT '用户名',
                           `pass_word` varchar(255) NOT NULL DEFAULT '' COMMENT '用户密码',
                           `signature`  varchar(255) NOT NULL DEFAULT '' COMMENT '登录用户签名',
-                          PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='用户表';
\ No newline at end of file
+                          `role` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '角色，0 普通用户，1 只读，2 运营，3 管理员',
+                          `sys_created` datetime DEFAULT NULL COMMENT '注册时间',
+                          PRIMARY KEY (`id`),
+                          UNIQUE KEY `idx_user_name` (`user_name`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='用户表';
+DROP TABLE IF EXISTS `t_admin_audit`;
+CREATE TABLE `t_admin_audit` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '操作人ID',
+                          `user_name` varchar(50) NOT NULL DEFAULT '' COMMENT '操作人用户名',
+                          `operation` varchar(255) NOT NULL DEFAULT '' COMMENT '后台操作',
+                          `payload` text COMMENT '请求内容',
+                          `result_code` int(11) NOT NULL DEFAULT '0' COMMENT '结果错误码，0 成功',
+                          `result_msg` varchar(255) NOT NULL DEFAULT '' COMMENT '结果描述',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '操作人IP',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_user_id` (`user_id`),
+                          KEY `idx_sys_created` (`sys_created`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='后台操作审计表，只允许追加';
+
+
+DROP TABLE IF EXISTS `t_draw_outbox`;
+CREATE TABLE `t_draw_outbox` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
+                          `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户ID',
+                          `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID',
+                          `use_pool` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否扣减了奖品池',
+                          `pop_coupon` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否弹出了券码',
+                          `status` smallint(5) unsigned NOT NULL DEFAULT '0' COMMENT '状态，0 待提交，1 已提交，2 已回滚，3 完成',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_status_updated` (`status`, `sys_updated`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='抽奖事务消息表，修复redis中的奖品池和券码';
+
+DROP TABLE IF EXISTS `t_prize_delivery`;
+CREATE TABLE `t_prize_delivery` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `idempotency_key` varchar(64) NOT NULL DEFAULT '' COMMENT '幂等键，由中奖记录ID生成',
+                          `result_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '中奖记录ID',
+                          `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
+                          `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID',
+                          `prize_type` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品类型',
+                          `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户ID',
+                          `user_name` varchar(50) NOT NULL DEFAULT '' COMMENT '用户名',
+                          `prize_profile` varchar(255) NOT NULL DEFAULT '' COMMENT '奖品详情',
+                          `status` smallint(5) unsigned NOT NULL DEFAULT '0' COMMENT '状态，0 待发放，1 成功，2 死信',
+                          `retry_num` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '已重试次数',
+                          `next_retry_time` datetime NOT NULL COMMENT '下次重试时间',
+                          `last_error` varchar(255) NOT NULL DEFAULT '' COMMENT '最后一次失败原因',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          UNIQUE KEY `uk_idempotency_key` (`idempotency_key`),
+                          KEY `idx_status_retry` (`status`, `next_retry_time`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='虚拟奖品发放任务表';
+
+DROP TABLE IF EXISTS `t_prize_delivery_dead`;
+CREATE TABLE `t_prize_delivery_dead` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `delivery_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '发放任务ID',
+                          `result_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '中奖记录ID',
+                          `prize_type` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品类型',
+                          `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户ID',
+                          `retry_num` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '已重试次数',
+                          `last_error` varchar(255) NOT NULL DEFAULT '' COMMENT '最后一次失败原因',
+                          `status` smallint(5) unsigned NOT NULL DEFAULT '0' COMMENT '状态，0 待处理，1 已重新发放',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_status` (`status`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='虚拟奖品发放死信表';
+
+DROP TABLE IF EXISTS `t_prize_plan`;
+CREATE TABLE `t_prize_plan` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID',
+                          `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
+                          `release_at` datetime NOT NULL COMMENT '放入奖品池的时间',
+                          `num` int(11) NOT NULL DEFAULT '0' COMMENT '奖品数量',
+                          `released` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '是否已放入奖品池，0 否，1 是',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_prize_release` (`prize_id`,`released`,`release_at`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='发奖计划表';
+
+DROP TABLE IF EXISTS `t_prize_pool_ledger`;
+CREATE TABLE `t_prize_pool_ledger` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `plan_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '发奖计划ID，每个计划只放入一次',
+                          `prize_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '奖品ID',
+                          `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID，0表示默认活动',
+                          `release_at` datetime NOT NULL COMMENT '计划时间',
+                          `num` int(11) NOT NULL DEFAULT '0' COMMENT '奖品数量',
+                          `applied` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '是否已加到奖品池，0 否，1 是',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          UNIQUE KEY `uk_plan_id` (`plan_id`),
+                          KEY `idx_prize_release` (`prize_id`,`release_at`),
+                          KEY `idx_applied` (`applied`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='奖品池放入记录表';
+
+DROP TABLE IF EXISTS `t_task_run`;
+CREATE TABLE `t_task_run` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `task_name` varchar(50) NOT NULL DEFAULT '' COMMENT '任务名称',
+                          `trigger_type` tinyint(3) unsigned NOT NULL DEFAULT '1' COMMENT '触发方式，1 按计划，2 手动',
+                          `instance` varchar(100) NOT NULL DEFAULT '' COMMENT '执行的实例',
+                          `status` tinyint(3) unsigned NOT NULL DEFAULT '1' COMMENT '状态，1 执行中，2 成功，3 失败',
+                          `error` varchar(1024) NOT NULL DEFAULT '' COMMENT '错误信息',
+                          `start_time` datetime NOT NULL COMMENT '开始时间',
+                          `end_time` datetime DEFAULT NULL COMMENT '结束时间',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          `sys_updated` datetime DEFAULT NULL COMMENT '修改时间',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_task_name` (`task_name`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='定时任务执行记录表';
+
+DROP TABLE IF EXISTS `t_risk_log`;
+CREATE TABLE `t_risk_log` (
+                          `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+                          `activity_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '活动ID',
+                          `user_id` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '用户ID',
+                          `ip` varchar(50) NOT NULL DEFAULT '' COMMENT '服务端确定的IP',
+                          `claimed_ip` varchar(50) NOT NULL DEFAULT '' COMMENT '请求中声明的IP',
+                          `device_id` varchar(64) NOT NULL DEFAULT '' COMMENT '设备ID',
+                          `action` tinyint(3) unsigned NOT NULL DEFAULT '0' COMMENT '动作，1 需要验证，2 拒绝',
+                          `downgrade` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否降级为降低中奖概率',
+                          `reasons` varchar(255) NOT NULL DEFAULT '' COMMENT '命中原因',
+                          `sys_created` datetime DEFAULT NULL COMMENT '创建时间',
+                          PRIMARY KEY (`id`),
+                          KEY `idx_user_id` (`user_id`),
+                          KEY `idx_ip` (`ip`),
+                          KEY `idx_sys_created` (`sys_created`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8 comment='风控记录表';